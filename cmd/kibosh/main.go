@@ -28,6 +28,7 @@ import (
 	"github.com/cf-platform-eng/kibosh/pkg/config"
 	"github.com/cf-platform-eng/kibosh/pkg/helm"
 	"github.com/cf-platform-eng/kibosh/pkg/httphelpers"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
 	"github.com/cf-platform-eng/kibosh/pkg/k8s"
 	"github.com/cf-platform-eng/kibosh/pkg/logger"
 	"github.com/cf-platform-eng/kibosh/pkg/repository"
@@ -95,10 +96,29 @@ func main() {
 		kiboshLogger.Fatal("Unable to prepare default cluster", err)
 	}
 
+	var instanceStore instancestore.InstanceStore
+	if conf.InstanceStoreConfig.UsesFileStore() {
+		instanceStore, err = instancestore.NewFileInstanceStore(conf.InstanceStoreConfig.Dir)
+		if err != nil {
+			kiboshLogger.Fatal("Unable to create instance store", err)
+		}
+	} else {
+		defaultCluster, err := clusterFactory.DefaultCluster()
+		if err != nil {
+			kiboshLogger.Fatal("Unable to connect to default cluster", err)
+		}
+		instanceStore = instancestore.NewK8sInstanceStore(defaultCluster, conf.TillerNamespace)
+	}
+
 	serviceBroker := broker.NewPksServiceBroker(
 		conf, clusterFactory, helmClientFactory, serviceAccountInstallerFactory, helm.InstallerFactoryDefault,
-		repo, credStore, operatorCharts, kiboshLogger,
+		repo, credStore, instanceStore, operatorCharts, kiboshLogger,
 	)
+	err = serviceBroker.ResumeDeprovisions()
+	if err != nil {
+		kiboshLogger.Error("Unable to resume pending deprovisions", err)
+	}
+
 	brokerCredentials := brokerapi.BrokerCredentials{
 		Username: conf.AdminUsername,
 		Password: conf.AdminPassword,
@@ -115,6 +135,17 @@ func main() {
 	http.Handle("/reload_charts", authFilter.Filter(
 		repositoryAPI.ReloadCharts(),
 	))
+	http.Handle("/rotate_credentials", authFilter.Filter(
+		serviceBroker.RotateCredentialsHandler(),
+	))
+
+	reconciler := broker.NewOrphanReconciler(serviceBroker, cfAPIClient, conf.ReconcilerConfig, kiboshLogger)
+	if conf.ReconcilerConfig.Interval > 0 {
+		go reconciler.Run()
+	}
+	http.Handle("/orphans", authFilter.Filter(
+		reconciler.OrphansHandler(),
+	))
 
 	kiboshLogger.Info(fmt.Sprintf("Listening on %v", conf.Port))
 	err = http.ListenAndServe(fmt.Sprintf(":%v", conf.Port), nil)
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
 
+	"github.com/cf-platform-eng/kibosh/pkg/config"
 	"github.com/cf-platform-eng/kibosh/pkg/helm"
 	"github.com/cf-platform-eng/kibosh/pkg/k8s"
 	"github.com/ghodss/yaml"
+	"github.com/kelseyhightower/envconfig"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func main() {
@@ -24,17 +30,57 @@ func main() {
 	cluster, err := k8s.NewClusterFromDefaultConfig()
 	exitOnErr(err)
 
-	servicesAndSecrets, err := cluster.GetSecretsAndServices(namespace)
+	// NodePort addresses are picked the way the broker is configured to pick them
+	nodeAddressConfig := &config.NodeAddressConfig{}
+	err = envconfig.Process("", nodeAddressConfig)
 	exitOnErr(err)
 
-	println(fmt.Sprintf("servicesAndSecrets %v", servicesAndSecrets))
 	rawTemplate, err := ioutil.ReadFile(filename)
 	exitOnErr(err)
 
 	bind := &helm.Bind{}
 	err = yaml.Unmarshal(rawTemplate, bind)
+	exitOnErr(err)
 
-	renderedTemplate, err := helm.RenderJsonnetTemplate(string(bind.Template), servicesAndSecrets)
+	servicesAndSecrets, err := cluster.GetSecretsAndServices(namespace, bind.SecretExposure, nodeAddressConfig)
+	exitOnErr(err)
+
+	println(fmt.Sprintf("servicesAndSecrets %v", servicesAndSecrets))
+
+	// values and instance need the broker, so only the namespace's resources are available here
+	templateInput := map[string]interface{}{}
+	for key, value := range servicesAndSecrets {
+		templateInput[key] = value
+	}
+	ingresses, err := cluster.GetIngresses(namespace)
+	exitOnErr(err)
+	if ingresses == nil {
+		ingresses = []map[string]interface{}{}
+	}
+	templateInput["ingresses"] = ingresses
+	configMaps := []map[string]interface{}{}
+	for _, name := range bind.ConfigMaps {
+		configMap, err := cluster.GetConfigMap(namespace, name, meta_v1.GetOptions{})
+		if k8s_errors.IsNotFound(err) {
+			// the broker leaves out listed ConfigMaps that don't exist (yet)
+			continue
+		}
+		exitOnErr(err)
+		configMaps = append(configMaps, map[string]interface{}{
+			"name": configMap.Name,
+			"data": configMap.Data,
+		})
+	}
+	templateInput["configmaps"] = configMaps
+
+	libraries, err := loadLibraries(path.Join(path.Dir(filename), helm.BindLibraryDir))
+	exitOnErr(err)
+
+	renderedTemplate, err := helm.RenderTemplate(
+		bind.TemplateType, bind.Template, templateInput,
+		helm.TemplateSource(filename, helm.BindTemplateLine(rawTemplate)),
+		helm.TemplateLibraries(libraries),
+	)
 	exitOnErr(err)
 
 	var bindCredentials map[string]interface{}
@@ -45,6 +91,33 @@ func main() {
 	println(renderedTemplate)
 }
 
+// loadLibraries reads the libraries next to bind.yaml, as the broker does from the chart
+func loadLibraries(dir string) (map[string]string, error) {
+	libraries := map[string]string{}
+	err := filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		library, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		libraries[filepath.ToSlash(relativePath)] = string(library)
+		return nil
+	})
+	return libraries, err
+}
+
 func exitOnErr(err error) {
 	if err != nil {
 		os.Stderr.WriteString(err.Error())
@@ -0,0 +1,192 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"fmt"
+
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/pkg/errors"
+	batch_v1 "k8s.io/api/batch/v1"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const bindOperation = "bind"
+const unbindOperation = "unbind"
+const rotateOperation = "rotate"
+
+const bindingSecretPrefix = "kibosh-credentials-"
+
+var errBindingSecretNotFound = errors.New("the bind hook hasn't written the binding secret yet")
+
+func hasBindHook(chart *my_helm.MyChart) bool {
+	return chart.BindHooks != nil && chart.BindHooks.Bind != nil
+}
+
+func hasUnbindHook(chart *my_helm.MyChart) bool {
+	return chart.BindHooks != nil && chart.BindHooks.Unbind != nil
+}
+
+func hasRotateHook(chart *my_helm.MyChart) bool {
+	return chart.BindHooks != nil && chart.BindHooks.Rotate != nil
+}
+
+func (broker *PksServiceBroker) getBindingSecretName(bindingID string) string {
+	return bindingSecretPrefix + bindingID
+}
+
+func (broker *PksServiceBroker) getBindHookJobName(hook string, bindingID string) string {
+	return fmt.Sprintf("kibosh-%s-hook-%s", hook, bindingID)
+}
+
+// runBindHook starts the hook's Job for the binding if it isn't already running, and is true once it has completed
+func (broker *PksServiceBroker) runBindHook(cluster k8s.Cluster, instanceID string, bindingID string, appGUID string, hook string, spec *batch_v1.JobSpec) (brokerapi.LastOperation, bool, error) {
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, false, err
+	}
+	jobName := broker.getBindHookJobName(hook, bindingID)
+
+	job, err := cluster.GetJob(namespace, jobName, meta_v1.GetOptions{})
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			return brokerapi.LastOperation{}, false, err
+		}
+
+		_, err = cluster.CreateJob(namespace, broker.newBindHookJob(instanceID, bindingID, appGUID, hook, spec))
+		if err != nil && !k8s_errors.IsAlreadyExists(err) {
+			return brokerapi.LastOperation{}, false, err
+		}
+		return brokerapi.LastOperation{
+			State:       brokerapi.InProgress,
+			Description: fmt.Sprintf("started the %s hook", hook),
+		}, false, nil
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != api_v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batch_v1.JobComplete:
+			return brokerapi.LastOperation{}, true, nil
+		case batch_v1.JobFailed:
+			return brokerapi.LastOperation{
+				State:       brokerapi.Failed,
+				Description: fmt.Sprintf("%s hook failed: %s", hook, condition.Message),
+			}, false, nil
+		}
+	}
+
+	return brokerapi.LastOperation{
+		State:       brokerapi.InProgress,
+		Description: fmt.Sprintf("waiting for the %s hook to complete", hook),
+	}, false, nil
+}
+
+// newBindHookJob tells every container of the hook which binding it is acting on, and where its credentials go
+func (broker *PksServiceBroker) newBindHookJob(instanceID string, bindingID string, appGUID string, hook string, spec *batch_v1.JobSpec) *batch_v1.Job {
+	spec = spec.DeepCopy()
+	if spec.Template.Spec.RestartPolicy == "" {
+		spec.Template.Spec.RestartPolicy = api_v1.RestartPolicyNever
+	}
+
+	env := []api_v1.EnvVar{
+		{Name: "KIBOSH_INSTANCE_ID", Value: instanceID},
+		{Name: "KIBOSH_BINDING_ID", Value: bindingID},
+		{Name: "KIBOSH_APP_GUID", Value: appGUID},
+		{Name: "KIBOSH_BINDING_SECRET", Value: broker.getBindingSecretName(bindingID)},
+	}
+	for i := range spec.Template.Spec.InitContainers {
+		spec.Template.Spec.InitContainers[i].Env = append(spec.Template.Spec.InitContainers[i].Env, env...)
+	}
+	for i := range spec.Template.Spec.Containers {
+		spec.Template.Spec.Containers[i].Env = append(spec.Template.Spec.Containers[i].Env, env...)
+	}
+
+	return &batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: broker.getBindHookJobName(hook, bindingID),
+			Labels: map[string]string{
+				"bindingID":                    bindingID,
+				"app.kubernetes.io/managed-by": "kibosh",
+			},
+		},
+		Spec: *spec,
+	}
+}
+
+// unbindState reports on the unbind hook, removing what's left of the binding once it has revoked the credentials
+func (broker *PksServiceBroker) unbindState(cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string, bindingID string, appGUID string) (brokerapi.LastOperation, error) {
+	lastOperation, done, err := broker.runBindHook(cluster, instanceID, bindingID, appGUID, unbindOperation, chart.BindHooks.Unbind)
+	if err != nil || !done {
+		return lastOperation, err
+	}
+
+	err = broker.removeBinding(cluster, chart, instanceID, bindingID)
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
+	return brokerapi.LastOperation{
+		State:       brokerapi.Succeeded,
+		Description: "binding removed",
+	}, nil
+}
+
+// removeBinding deletes the binding record, along with the hook Jobs and the secret the bind hook wrote
+func (broker *PksServiceBroker) removeBinding(cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string, bindingID string) error {
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+
+	if chart.BindHooks != nil {
+		for _, hook := range []string{bindOperation, unbindOperation, rotateOperation} {
+			err := broker.deleteBindHookJob(cluster, instanceID, bindingID, hook)
+			if err != nil {
+				return err
+			}
+		}
+		err := cluster.DeleteSecret(namespace, broker.getBindingSecretName(bindingID), &meta_v1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	err = cluster.DeleteConfigMap(namespace, broker.getBindingConfigMapName(bindingID), &meta_v1.DeleteOptions{})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteBindHookJob deletes the hook's Job along with its pods, so the hook can run again
+func (broker *PksServiceBroker) deleteBindHookJob(cluster k8s.Cluster, instanceID string, bindingID string, hook string) error {
+	propagation := meta_v1.DeletePropagationBackground
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+	err = cluster.DeleteJob(namespace, broker.getBindHookJobName(hook, bindingID), &meta_v1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	batch_v1 "k8s.io/api/batch/v1"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+var _ = Describe("bind hooks", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+	jobsResource := schema.GroupResource{Group: "batch", Resource: "jobs"}
+	secretsResource := schema.GroupResource{Resource: "secrets"}
+
+	var fixture *brokerFixture
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var chart *my_helm.MyChart
+	var broker *PksServiceBroker
+
+	hookSpec := func(image string) *batch_v1.JobSpec {
+		return &batch_v1.JobSpec{
+			Template: api_v1.PodTemplateSpec{
+				Spec: api_v1.PodSpec{
+					Containers: []api_v1.Container{{Name: "hook", Image: image}},
+				},
+			},
+		}
+	}
+
+	completedJob := func(conditionType batch_v1.JobConditionType, message string) *batch_v1.Job {
+		return &batch_v1.Job{
+			Status: batch_v1.JobStatus{
+				Conditions: []batch_v1.JobCondition{
+					{Type: conditionType, Status: api_v1.ConditionTrue, Message: message},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+		fakeCluster = fixture.cluster
+		chart = fixture.chart
+		chart.BindHooks = &my_helm.BindHooks{
+			Bind:   hookSpec("bind-user"),
+			Unbind: hookSpec("drop-user"),
+		}
+
+		fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-my-instance-guid"},
+		}, nil)
+		fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+			Data: map[string]string{"serviceID": serviceID, "appGUID": "my-app-guid"},
+		}, nil)
+		fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+			"secrets": {
+				{"name": "spacebears-admin", "data": map[string]interface{}{"password": "admin"}},
+				{"name": "kibosh-credentials-other-binding-id", "data": map[string]interface{}{"password": "other"}},
+			},
+			"services": {},
+		}, nil)
+		fakeCluster.GetSecretReturns(&api_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-credentials-my-binding-id"},
+			Data:       map[string][]byte{"username": []byte("app"), "password": []byte("mine")},
+			Type:       api_v1.SecretTypeOpaque,
+		}, nil)
+
+		broker = fixture.newBroker(nil, nil)
+	})
+
+	Context("bind", func() {
+		details := brokerapi.BindDetails{
+			AppGUID:   "my-app-guid",
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}
+
+		It("requires async", func() {
+			_, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+			Expect(err).To(Equal(brokerapi.ErrAsyncRequired))
+		})
+
+		It("saves the binding and returns async", func() {
+			binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(binding.IsAsync).To(BeTrue())
+			Expect(binding.OperationData).To(Equal("bind"))
+			Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(1))
+			_, configMap := fakeCluster.CreateOrUpdateConfigMapArgsForCall(0)
+			Expect(configMap.Data["pending"]).To(Equal("true"))
+			Expect(fakeCluster.CreateJobCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("last binding operation", func() {
+		pollDetails := brokerapi.PollDetails{
+			ServiceID:     serviceID,
+			PlanID:        serviceID + "-small",
+			OperationData: "bind",
+		}
+
+		BeforeEach(func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"serviceID": serviceID, "appGUID": "my-app-guid", "pending": "true"},
+			}, nil)
+		})
+
+		It("starts the bind hook", func() {
+			fakeCluster.GetJobReturns(nil, k8s_errors.NewNotFound(jobsResource, "kibosh-bind-hook-my-binding-id"))
+
+			lastOperation, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.InProgress))
+			Expect(fakeCluster.CreateJobCallCount()).To(Equal(1))
+
+			namespace, job := fakeCluster.CreateJobArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+			Expect(job.Name).To(Equal("kibosh-bind-hook-my-binding-id"))
+			Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(api_v1.RestartPolicyNever))
+			Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("bind-user"))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(api_v1.EnvVar{Name: "KIBOSH_BINDING_ID", Value: "my-binding-id"}))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(api_v1.EnvVar{Name: "KIBOSH_APP_GUID", Value: "my-app-guid"}))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(api_v1.EnvVar{Name: "KIBOSH_BINDING_SECRET", Value: "kibosh-credentials-my-binding-id"}))
+			Expect(chart.BindHooks.Bind.Template.Spec.Containers[0].Env).To(BeEmpty())
+		})
+
+		It("waits for the bind hook to complete", func() {
+			fakeCluster.GetJobReturns(&batch_v1.Job{}, nil)
+
+			lastOperation, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.InProgress))
+			Expect(fakeCluster.CreateJobCallCount()).To(Equal(0))
+		})
+
+		It("fails when the bind hook fails", func() {
+			fakeCluster.GetJobReturns(completedJob(batch_v1.JobFailed, "BackoffLimitExceeded"), nil)
+
+			lastOperation, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.Failed))
+			Expect(lastOperation.Description).To(ContainSubstring("BackoffLimitExceeded"))
+		})
+
+		It("succeeds once the bind hook has completed", func() {
+			fakeCluster.GetJobReturns(completedJob(batch_v1.JobComplete, ""), nil)
+
+			lastOperation, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.Succeeded))
+		})
+
+		It("fails when the bind hook completes without writing the secret", func() {
+			fakeCluster.GetJobReturns(completedJob(batch_v1.JobComplete, ""), nil)
+			fakeCluster.GetSecretReturns(nil, k8s_errors.NewNotFound(secretsResource, "kibosh-credentials-my-binding-id"))
+
+			lastOperation, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.Failed))
+			Expect(lastOperation.Description).To(ContainSubstring("kibosh-credentials-my-binding-id"))
+		})
+	})
+
+	Context("get binding", func() {
+		It("returns the data of the binding's secret", func() {
+			binding, err := broker.GetBinding(nil, "my-instance-guid", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(binding.Credentials).To(Equal(map[string]interface{}{
+				"username": "app",
+				"password": "mine",
+			}))
+		})
+
+		It("gives the bind template the binding's secret and hides other bindings'", func() {
+			chart.BindTemplate = `{
+				binding: $.binding.data.password,
+				secrets: std.length($.secrets),
+			}`
+
+			binding, err := broker.GetBinding(nil, "my-instance-guid", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(binding.Credentials).To(Equal(map[string]interface{}{
+				"binding": "mine",
+				"secrets": float64(1),
+			}))
+		})
+
+		It("returns not found before the hook has written the secret", func() {
+			fakeCluster.GetSecretReturns(nil, k8s_errors.NewNotFound(secretsResource, "kibosh-credentials-my-binding-id"))
+
+			_, err := broker.GetBinding(nil, "my-instance-guid", "my-binding-id")
+
+			Expect(err).To(Equal(brokerapi.ErrBindingNotFound))
+		})
+	})
+
+	Context("unbind", func() {
+		details := brokerapi.UnbindDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}
+
+		It("starts the unbind hook and returns async", func() {
+			fakeCluster.GetJobReturns(nil, k8s_errors.NewNotFound(jobsResource, "kibosh-unbind-hook-my-binding-id"))
+
+			spec, err := broker.Unbind(nil, "my-instance-guid", "my-binding-id", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(spec.IsAsync).To(BeTrue())
+			Expect(spec.OperationData).To(Equal("unbind"))
+			Expect(fakeCluster.CreateJobCallCount()).To(Equal(1))
+			_, job := fakeCluster.CreateJobArgsForCall(0)
+			Expect(job.Name).To(Equal("kibosh-unbind-hook-my-binding-id"))
+			Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("drop-user"))
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(0))
+		})
+
+		It("requires async", func() {
+			_, err := broker.Unbind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+			Expect(err).To(Equal(brokerapi.ErrAsyncRequired))
+		})
+
+		It("removes the binding once the unbind hook has completed", func() {
+			fakeCluster.GetJobReturns(completedJob(batch_v1.JobComplete, ""), nil)
+
+			lastOperation, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-id", brokerapi.PollDetails{
+				ServiceID:     serviceID,
+				PlanID:        serviceID + "-small",
+				OperationData: "unbind",
+			})
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.Succeeded))
+			Expect(fakeCluster.DeleteJobCallCount()).To(Equal(3))
+			Expect(fakeCluster.DeleteSecretCallCount()).To(Equal(1))
+			_, secretName, _ := fakeCluster.DeleteSecretArgsForCall(0)
+			Expect(secretName).To(Equal("kibosh-credentials-my-binding-id"))
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(1))
+		})
+
+		It("cleans up bindings made before bindings were recorded synchronously", func() {
+			fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-binding-my-binding-id"))
+
+			spec, err := broker.Unbind(nil, "my-instance-guid", "my-binding-id", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(spec.IsAsync).To(BeFalse())
+			Expect(fakeCluster.CreateJobCallCount()).To(Equal(0))
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(1))
+		})
+
+		It("cleans up synchronously without an unbind hook", func() {
+			chart.BindHooks.Unbind = nil
+
+			spec, err := broker.Unbind(nil, "my-instance-guid", "my-binding-id", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(spec.IsAsync).To(BeFalse())
+			Expect(fakeCluster.DeleteJobCallCount()).To(Equal(3))
+			Expect(fakeCluster.DeleteSecretCallCount()).To(Equal(1))
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(1))
+		})
+	})
+})
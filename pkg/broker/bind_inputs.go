@@ -0,0 +1,164 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"encoding/json"
+	"strings"
+
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pivotal-cf/brokerapi"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// getBindingData is what's kept of the bind request, since polling and fetching the binding don't get it again
+func getBindingData(details brokerapi.BindDetails) map[string]string {
+	data := map[string]string{
+		"serviceID": details.ServiceID,
+		"planID":    details.PlanID,
+		"appGUID":   getAppGUID(details),
+	}
+	if len(details.RawContext) > 0 {
+		data["context"] = string(details.RawContext)
+	}
+	return data
+}
+
+// addBindTemplateInputs adds the ingresses, the configmaps the chart opted in to, the instance the binding is for
+// and, when the chart opted in to them, the release values and instance parameters, alongside the secrets and
+// services bind templates have always had
+func (broker *PksServiceBroker) addBindTemplateInputs(input map[string]interface{}, cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string, bindingID string, bindingData map[string]string) error {
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+
+	ingresses, err := cluster.GetIngresses(namespace)
+	if err != nil {
+		return err
+	}
+	if ingresses == nil {
+		ingresses = []map[string]interface{}{}
+	}
+	input["ingresses"] = ingresses
+
+	configMaps := []map[string]interface{}{}
+	for _, name := range chart.BindConfigMaps {
+		configMap, err := cluster.GetConfigMap(namespace, name, meta_v1.GetOptions{})
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		configMaps = append(configMaps, map[string]interface{}{
+			"name": configMap.Name,
+			"data": configMap.Data,
+		})
+	}
+	input["configmaps"] = configMaps
+
+	serviceID := bindingData["serviceID"]
+	planID, err := broker.getInstancePlanID(cluster, instanceID, namespace, bindingData)
+	if err != nil {
+		return err
+	}
+	planName := strings.TrimPrefix(planID, serviceID+"-")
+
+	platformContext := map[string]interface{}{}
+	if bindingData["context"] != "" {
+		err = json.Unmarshal([]byte(bindingData["context"]), &platformContext)
+		if err != nil {
+			return err
+		}
+	}
+	organizationGUID, _ := platformContext["organization_guid"].(string)
+	spaceGUID, _ := platformContext["space_guid"].(string)
+
+	instance := map[string]interface{}{
+		"id":               instanceID,
+		"bindingID":        bindingID,
+		"serviceID":        serviceID,
+		"planID":           planID,
+		"plan":             planName,
+		"appGUID":          bindingData["appGUID"],
+		"organizationGUID": organizationGUID,
+		"spaceGUID":        spaceGUID,
+		"context":          platformContext,
+	}
+	input["instance"] = instance
+
+	// reading the release takes calls to tiller on every bind and poll, so only charts that use it pay for it
+	if chart.BindReleaseValues {
+		helmClient := broker.helmClientFactory.HelmClient(cluster)
+		values, err := broker.getReleaseValues(helmClient, instanceID)
+		if err != nil {
+			return err
+		}
+		input["values"] = values
+
+		parameters, err := broker.getInstanceParameters(helmClient, chart, planName, instanceID)
+		if err != nil {
+			return err
+		}
+		instance["parameters"] = parameters
+	}
+
+	return nil
+}
+
+// getInstancePlanID is the plan the instance is on now, which may have changed since the binding was made
+func (broker *PksServiceBroker) getInstancePlanID(cluster k8s.Cluster, instanceID string, namespaceName string, bindingData map[string]string) (string, error) {
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil {
+		return "", err
+	}
+	if instance != nil && instance.PlanID != "" {
+		return instance.PlanID, nil
+	}
+
+	namespace, err := cluster.GetNamespace(namespaceName, nil)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return "", err
+	}
+	if namespace != nil && namespace.Labels["planID"] != "" {
+		return namespace.Labels["planID"], nil
+	}
+	return bindingData["planID"], nil
+}
+
+// getReleaseValues returns the values the release was rendered with, including the chart's defaults
+func (broker *PksServiceBroker) getReleaseValues(helmClient my_helm.MyHelmClient, instanceID string) (map[string]interface{}, error) {
+	releaseName, err := broker.getReleaseName(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := helmClient.ReleaseContent(releaseName)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil || content.Release == nil || content.Release.Config == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	if content.Release.Chart == nil {
+		return chartutil.ReadValues([]byte(content.Release.Config.Raw))
+	}
+	return chartutil.CoalesceValues(content.Release.Chart, content.Release.Config)
+}
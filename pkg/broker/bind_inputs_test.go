@@ -0,0 +1,228 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"encoding/json"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	api_v1 "k8s.io/api/core/v1"
+	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	hapi_chart "k8s.io/helm/pkg/proto/hapi/chart"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var _ = Describe("bind template inputs", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+	var fixture *brokerFixture
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var chart *my_helm.MyChart
+	var broker *PksServiceBroker
+	var details brokerapi.BindDetails
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+		fakeHelmClient.ReleaseContentReturns(&hapi_services.GetReleaseContentResponse{
+			Release: &hapi_release.Release{
+				Config: &hapi_chart.Config{Raw: "replicas: 2\n"},
+				Chart: &hapi_chart.Chart{
+					Metadata: &hapi_chart.Metadata{Name: "spacebears", Version: "1.0.0"},
+					Values:   &hapi_chart.Config{Raw: "replicas: 1\nport: 9000\n"},
+				},
+			},
+		}, nil)
+		fakeHelmClient.RenderTemplatedValuesReturns([]byte("replicas: 1\n"), nil)
+		fakeCluster = fixture.cluster
+		chart = fixture.chart
+		chart.BindConfigMaps = []string{"spacebears-settings"}
+		chart.BindReleaseValues = true
+
+		fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+			"secrets":  {},
+			"services": {},
+		}, nil)
+		fakeCluster.GetIngressesReturns([]map[string]interface{}{
+			{"name": "ui", "spec": v1_beta1.IngressSpec{Rules: []v1_beta1.IngressRule{{Host: "ui.example.com"}}}},
+		}, nil)
+		fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "spacebears-settings"},
+			Data:       map[string]string{"bucket": "bears"},
+		}, nil)
+
+		details = brokerapi.BindDetails{
+			AppGUID:    "my-app-guid",
+			ServiceID:  serviceID,
+			PlanID:     serviceID + "-small",
+			RawContext: json.RawMessage(`{"platform": "cloudfoundry", "organization_guid": "my-org-guid", "space_guid": "my-space-guid"}`),
+		}
+
+		broker = fixture.newBroker(nil, nil)
+	})
+
+	It("gives the template the ingresses, configmaps, values and instance", func() {
+		chart.BindTemplate = `{
+			host: $.ingresses[0].spec.rules[0].host,
+			bucket: $.configmaps[0].data.bucket,
+			replicas: $.values.replicas,
+			port: $.values.port,
+			instance: $.instance.id,
+			binding: $.instance.bindingID,
+			plan: $.instance.plan,
+			org: $.instance.organizationGUID,
+			space: $.instance.spaceGUID,
+			platform: $.instance.context.platform,
+			parameters: $.instance.parameters,
+		}`
+
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(Equal(map[string]interface{}{
+			"host":       "ui.example.com",
+			"bucket":     "bears",
+			"replicas":   float64(2),
+			"port":       float64(9000),
+			"instance":   "my-instance-guid",
+			"binding":    "my-binding-id",
+			"plan":       "small",
+			"org":        "my-org-guid",
+			"space":      "my-space-guid",
+			"platform":   "cloudfoundry",
+			"parameters": map[string]interface{}{"replicas": float64(2)},
+		}))
+		namespace, name, _ := fakeCluster.GetConfigMapArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		Expect(name).To(Equal("spacebears-settings"))
+	})
+
+	It("gives go templates the same inputs", func() {
+		chart.BindTemplateType = my_helm.TemplateTypeGoTemplate
+		chart.BindTemplate = `{
+			"host": {{ (index .ingresses 0).spec.rules | first | pluck "host" | first | quote }},
+			"space": {{ .instance.spaceGUID | quote }},
+			"replicas": {{ .values.replicas }}
+		}`
+
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(Equal(map[string]interface{}{
+			"host":     "ui.example.com",
+			"space":    "my-space-guid",
+			"replicas": float64(2),
+		}))
+	})
+
+	It("only reads the release when the chart opted in to its values", func() {
+		chart.BindReleaseValues = false
+		chart.BindTemplate = `{values: std.objectHas($, "values"), parameters: std.objectHas($.instance, "parameters")}`
+
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(Equal(map[string]interface{}{"values": false, "parameters": false}))
+		Expect(fakeHelmClient.ReleaseContentCallCount()).To(Equal(0))
+		Expect(fakeHelmClient.RenderTemplatedValuesCallCount()).To(Equal(0))
+	})
+
+	It("gives the plan the instance is on now", func() {
+		chart.Plans["medium"] = my_helm.Plan{Name: "medium"}
+		fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "kibosh-my-instance-guid",
+				Labels: map[string]string{"planID": serviceID + "-medium"},
+			},
+		}, nil)
+		chart.BindTemplate = `{plan: $.instance.plan, planID: $.instance.planID}`
+
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(Equal(map[string]interface{}{
+			"plan":   "medium",
+			"planID": serviceID + "-medium",
+		}))
+	})
+
+	It("keeps the context with the binding", func() {
+		chart.BindTemplate = `{org: $.instance.organizationGUID}`
+
+		_, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		_, configMap := fakeCluster.CreateOrUpdateConfigMapArgsForCall(0)
+		Expect(configMap.Data["context"]).To(Equal(string(details.RawContext)))
+		Expect(configMap.Data["appGUID"]).To(Equal("my-app-guid"))
+	})
+
+	It("leaves out configmaps that don't exist", func() {
+		chart.BindTemplate = `{configmaps: std.length($.configmaps)}`
+		fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "spacebears-settings"))
+
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(Equal(map[string]interface{}{"configmaps": float64(0)}))
+	})
+
+	It("has empty platform context for bindings made without one", func() {
+		chart.BindTemplate = `{org: $.instance.organizationGUID, context: $.instance.context}`
+		details.RawContext = nil
+
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(Equal(map[string]interface{}{
+			"org":     "",
+			"context": map[string]interface{}{},
+		}))
+	})
+
+	It("returns only secrets and services without a template", func() {
+		binding, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		Expect(binding.Credentials).To(HaveKey("secrets"))
+		Expect(binding.Credentials).To(HaveKey("services"))
+		Expect(binding.Credentials).NotTo(HaveKey("instance"))
+		Expect(fakeCluster.GetIngressesCallCount()).To(Equal(0))
+	})
+
+	It("only asks for the secrets the chart exposes", func() {
+		chart.BindSecretExposure = k8s.SecretExposureOptIn
+
+		_, err := broker.Bind(nil, "my-instance-guid", "my-binding-id", details, false)
+
+		Expect(err).To(BeNil())
+		_, secretExposure, _ := fakeCluster.GetSecretsAndServicesArgsForCall(0)
+		Expect(secretExposure).To(Equal("opt-in"))
+	})
+})
@@ -18,14 +18,18 @@ package broker
 import (
 	"context"
 	"crypto/md5"
-	"encoding/base32"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cf-platform-eng/kibosh/pkg/config"
 	"github.com/cf-platform-eng/kibosh/pkg/credstore"
 	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
 	"github.com/cf-platform-eng/kibosh/pkg/k8s"
 	"github.com/cf-platform-eng/kibosh/pkg/repository"
 	"github.com/ghodss/yaml"
@@ -35,43 +39,58 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/helm/pkg/chartutil"
 	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
 )
 
 const registrySecretName = "registry-secret"
 const credhubClientIdentifier = "kibosh"
+const bindingConfigMapPrefix = "kibosh-binding-"
+const bindingPendingKey = "pending"
+const bindTemplateTimeout = 10 * time.Minute
+const maintenanceVersionAnnotation = "maintenanceVersion"
 
 type PksServiceBroker struct {
-	config    *config.Config
-	repo      repository.Repository
-	credstore credstore.CredStore
-	operators []*my_helm.MyChart
+	config        *config.Config
+	repo          repository.Repository
+	credstore     credstore.CredStore
+	instanceStore instancestore.InstanceStore
+	operators     []*my_helm.MyChart
 
 	clusterFactory                 k8s.ClusterFactory
 	helmClientFactory              my_helm.HelmClientFactory
 	serviceAccountInstallerFactory k8s.ServiceAccountInstallerFactory
 	helmInstallerFactory           my_helm.InstallerFactory
 
+	namesMutex sync.Mutex
+	names      map[string]instanceNames
+
 	logger *logrus.Logger
 }
 
 func NewPksServiceBroker(
 	config *config.Config, clusterFactory k8s.ClusterFactory, helmClientFactory my_helm.HelmClientFactory,
 	serviceAccountInstallerFactory k8s.ServiceAccountInstallerFactory, helmInstallerFactory my_helm.InstallerFactory,
-	repo repository.Repository, cs credstore.CredStore, operators []*my_helm.MyChart, logger *logrus.Logger,
+	repo repository.Repository, cs credstore.CredStore, instanceStore instancestore.InstanceStore,
+	operators []*my_helm.MyChart, logger *logrus.Logger,
 ) *PksServiceBroker {
 	broker := &PksServiceBroker{
-		config:    config,
-		repo:      repo,
-		credstore: cs,
-		operators: operators,
+		config:        config,
+		repo:          repo,
+		credstore:     cs,
+		instanceStore: instanceStore,
+		operators:     operators,
 
 		clusterFactory:                 clusterFactory,
 		helmClientFactory:              helmClientFactory,
 		serviceAccountInstallerFactory: serviceAccountInstallerFactory,
 		helmInstallerFactory:           helmInstallerFactory,
 
+		names: map[string]instanceNames{},
+
 		logger: logger,
 	}
 
@@ -110,7 +129,7 @@ func (broker *PksServiceBroker) Services(ctx context.Context) ([]brokerapi.Servi
 				Name:        plan.Name,
 				Description: plan.Description,
 				Metadata: &brokerapi.ServicePlanMetadata{
-					DisplayName: plan.Name,
+					DisplayName: orDefault(plan.DisplayName, plan.Name),
 					Bullets: func() []string {
 						if plan.Bullets == nil {
 							return []string{
@@ -119,21 +138,40 @@ func (broker *PksServiceBroker) Services(ctx context.Context) ([]brokerapi.Servi
 						}
 						return plan.Bullets
 					}(),
+					Costs: broker.getPlanCosts(plan),
 				},
 				Bindable: brokerapi.BindableValue(*plan.Bindable),
 				Free:     brokerapi.FreeValue(*plan.Free),
+				Schemas:  broker.getPlanSchemas(plan),
+
+				MaintenanceInfo: broker.getMaintenanceInfo(chart, plan.Name),
 			})
 		}
 
+		service := chart.Service
+		var requires []brokerapi.RequiredPermission
+		for _, permission := range service.Requires {
+			requires = append(requires, brokerapi.RequiredPermission(permission))
+		}
+
 		serviceCatalog = append(serviceCatalog, brokerapi.Service{
-			ID:          broker.getServiceID(chart),
-			Name:        broker.getServiceName(chart),
-			Description: chart.Metadata.Description,
-			Bindable:    true,
+			ID:                   broker.getServiceID(chart),
+			Name:                 broker.getServiceName(chart),
+			Description:          chart.Metadata.Description,
+			Bindable:             boolOrDefault(service.Bindable, true),
+			PlanUpdatable:        boolOrDefault(service.PlanUpdateable, len(chart.Plans) > 1),
+			InstancesRetrievable: boolOrDefault(service.InstancesRetrievable, true),
+			BindingsRetrievable:  true,
+			Tags:                 service.Tags,
+			Requires:             requires,
 			Metadata: &brokerapi.ServiceMetadata{
-				DisplayName:      broker.getServiceName(chart),
-				ImageUrl:         chart.Metadata.Icon,
-				DocumentationUrl: chart.Metadata.Home,
+				DisplayName:         orDefault(service.DisplayName, broker.getServiceName(chart)),
+				ImageUrl:            orDefault(service.ImageUrl, chart.Metadata.Icon),
+				LongDescription:     service.LongDescription,
+				ProviderDisplayName: service.ProviderDisplayName,
+				DocumentationUrl:    orDefault(service.DocumentationUrl, chart.Metadata.Home),
+				SupportUrl:          service.SupportUrl,
+				Shareable:           service.Shareable,
 			},
 
 			Plans: plans,
@@ -143,19 +181,25 @@ func (broker *PksServiceBroker) Services(ctx context.Context) ([]brokerapi.Servi
 	return serviceCatalog, nil
 }
 
-func clusterMapKey(instanceID string) string {
-	return instanceID + "-instance-to-cluster"
-}
-
-type clusterConfigState struct {
-	ClusterCredentials config.ClusterCredentials `json:"clusterCredentials"`
-}
-
 func (broker *PksServiceBroker) Provision(ctx context.Context, instanceID string, details brokerapi.ProvisionDetails, asyncAllowed bool) (brokerapi.ProvisionedServiceSpec, error) {
 	if !asyncAllowed {
 		return brokerapi.ProvisionedServiceSpec{}, brokerapi.ErrAsyncRequired
 	}
 
+	token, err := broker.lockInstance(instanceID, provisionOperation)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, err
+	}
+	spec, err := broker.provision(instanceID, details)
+	if err != nil {
+		broker.unlockInstance(instanceID, token)
+		return spec, err
+	}
+	spec.OperationData = withLockToken(spec.OperationData, token)
+	return spec, nil
+}
+
+func (broker *PksServiceBroker) provision(instanceID string, details brokerapi.ProvisionDetails) (brokerapi.ProvisionedServiceSpec, error) {
 	planName := strings.TrimPrefix(details.PlanID, details.ServiceID+"-")
 	charts, err := broker.GetChartsMap()
 	if err != nil {
@@ -166,6 +210,13 @@ func (broker *PksServiceBroker) Provision(ctx context.Context, instanceID string
 		return brokerapi.ProvisionedServiceSpec{}, errors.New(fmt.Sprintf("Chart not found for [%s]", details.ServiceID))
 	}
 
+	if details.MaintenanceInfo != nil {
+		maintenanceInfo := broker.getMaintenanceInfo(chart, planName)
+		if maintenanceInfo == nil || maintenanceInfo.Version != details.MaintenanceInfo.Version {
+			return brokerapi.ProvisionedServiceSpec{}, brokerapi.ErrMaintenanceInfoConflict
+		}
+	}
+
 	var installValues []byte
 	if details.GetRawParameters() != nil {
 		installValues, err = yaml.JSONToYAML(details.GetRawParameters())
@@ -174,6 +225,12 @@ func (broker *PksServiceBroker) Provision(ctx context.Context, instanceID string
 		}
 	}
 
+	// validated even without parameters, as update and migrate validate the stored values against the same schema
+	err = chart.ValidateParameters(planName, installValues)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, broker.toParametersError(err)
+	}
+
 	var cluster k8s.Cluster
 	planHasCluster := chart.Plans[planName].ClusterConfig != nil
 	if planHasCluster {
@@ -196,11 +253,19 @@ func (broker *PksServiceBroker) Provision(ctx context.Context, instanceID string
 		}
 	}
 
-	namespaceName := broker.getNamespace(instanceID)
+	names, err := broker.newInstanceNames(chart, planName, instanceID, details)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, err
+	}
+	err = broker.checkNameCollisions(cluster, myHelmClient, instanceID, names)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, err
+	}
+
 	namespace := api_v1.Namespace{
 		Spec: api_v1.NamespaceSpec{},
 		ObjectMeta: meta_v1.ObjectMeta{
-			Name: namespaceName,
+			Name: names.Namespace,
 			Labels: map[string]string{
 				"serviceID":                    details.ServiceID,
 				"planID":                       details.PlanID,
@@ -209,60 +274,274 @@ func (broker *PksServiceBroker) Provision(ctx context.Context, instanceID string
 				"instanceID":                   instanceID,
 				"app.kubernetes.io/managed-by": "kibosh",
 			},
+			Annotations: map[string]string{
+				maintenanceVersionAnnotation: broker.getMaintenanceVersion(chart, planName),
+			},
 		},
 	}
 
-	_, err = myHelmClient.InstallChart(broker.config.RegistryConfig, namespace, broker.getReleaseName(instanceID), chart, planName, installValues)
+	err = broker.recordInstance(chart, planName, instanceID, names, details)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, err
+	}
+
+	_, err = myHelmClient.InstallChart(broker.config.RegistryConfig, broker.config.NetworkIsolation, namespace, names.ReleaseName, chart, planName, installValues)
 	if err != nil {
+		forgetErr := broker.forgetInstance(instanceID)
+		if forgetErr != nil {
+			broker.logger.Error("Unable to remove instance record for instanceID=", instanceID, " ", forgetErr)
+		}
 		return brokerapi.ProvisionedServiceSpec{}, err
 	}
 
+	var dashboardURL string
+	if chart.Service.Dashboard != nil {
+		dashboardURL, err = broker.getDashboardURL(cluster, chart, instanceID)
+		if err != nil {
+			broker.logger.Error("Unable to find the dashboard for instanceID=", instanceID, " ", err)
+		}
+	}
+
 	return brokerapi.ProvisionedServiceSpec{
 		IsAsync:       true,
-		OperationData: "provision",
+		DashboardURL:  dashboardURL,
+		OperationData: newOperation(provisionOperation, 1).String(),
 	}, nil
 }
 
-func (broker *PksServiceBroker) GetInstance(context context.Context, instanceID string) (brokerapi.GetInstanceDetailsSpec, error) {
-	return brokerapi.GetInstanceDetailsSpec{}, errors.New("this optional operation isn't supported yet")
-}
+func (broker *PksServiceBroker) GetInstance(ctx context.Context, instanceID string) (brokerapi.GetInstanceDetailsSpec, error) {
+	cluster, namespace, err := broker.findInstance(instanceID)
+	if err != nil {
+		return brokerapi.GetInstanceDetailsSpec{}, err
+	}
 
-func (broker *PksServiceBroker) Deprovision(ctx context.Context, instanceID string, details brokerapi.DeprovisionDetails, asyncAllowed bool) (brokerapi.DeprovisionServiceSpec, error) {
-	planID := details.PlanID
-	serviceID := details.ServiceID
-	cluster, err := broker.getCluster(planID, serviceID)
+	serviceID := namespace.Labels["serviceID"]
+	planID := namespace.Labels["planID"]
+	planName := strings.TrimPrefix(planID, serviceID+"-")
+
+	charts, err := broker.GetChartsMap()
 	if err != nil {
-		return brokerapi.DeprovisionServiceSpec{}, err
+		return brokerapi.GetInstanceDetailsSpec{}, err
+	}
+	chart := charts[serviceID]
+	if chart == nil {
+		return brokerapi.GetInstanceDetailsSpec{}, errors.New(fmt.Sprintf("Chart not found for [%s]", serviceID))
 	}
 
 	helmClient := broker.helmClientFactory.HelmClient(cluster)
+	parameters, err := broker.getInstanceParameters(helmClient, chart, planName, instanceID)
+	if err != nil {
+		return brokerapi.GetInstanceDetailsSpec{}, err
+	}
+
+	dashboardURL, err := broker.getDashboardURL(cluster, chart, instanceID)
+	if err != nil {
+		if !my_helm.IsTemplateRuntimeError(err) {
+			return brokerapi.GetInstanceDetailsSpec{}, err
+		}
+		// LastOperation reports templates that never evaluate, until then there's no dashboard yet
+		broker.logger.Info("Dashboard template of instanceID=", instanceID, " doesn't evaluate yet ", err)
+	}
+
+	return brokerapi.GetInstanceDetailsSpec{
+		ServiceID:    serviceID,
+		PlanID:       planID,
+		DashboardURL: dashboardURL,
+		Parameters:   parameters,
+	}, nil
+}
+
+// findInstance looks for the instance namespace in the recorded cluster, or else in the default cluster followed
+// by every plan specific cluster
+func (broker *PksServiceBroker) findInstance(instanceID string) (k8s.Cluster, *api_v1.Namespace, error) {
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	go func() {
-		_, err = helmClient.DeleteRelease(broker.getReleaseName(instanceID))
+	var clusters []k8s.Cluster
+	if instance != nil {
+		cluster, err := broker.getRecordedCluster(instance)
 		if err != nil {
-			broker.logger.Error(
-				"Delete Release failed for planID=", planID, " serviceID=", serviceID, " instanceID=", instanceID, " ", err,
-			)
+			return nil, nil, err
 		}
+		clusters = []k8s.Cluster{cluster}
+	} else {
+		clusters, err = broker.getAllClusters()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	names := recordedInstanceNames(instanceID, instance)
+	for _, cluster := range clusters {
+		namespace, err := cluster.GetNamespace(names.Namespace, nil)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		if namespace != nil {
+			return cluster, namespace, nil
+		}
+	}
+
+	return nil, nil, brokerapi.NewFailureResponse(
+		errors.New(fmt.Sprintf("instance [%s] not found", instanceID)), http.StatusNotFound, "instance-not-found",
+	)
+}
+
+// getAllClusters returns the default cluster, the clusters of the plans and the clusters instances were recorded
+// on, once each however many plans or instances share them
+func (broker *PksServiceBroker) getAllClusters() ([]k8s.Cluster, error) {
+	defaultCluster, err := broker.clusterFactory.DefaultCluster()
+	if err != nil {
+		return nil, err
+	}
+	clusters := []k8s.Cluster{defaultCluster}
+	// clusters are told apart by their kubeconfig, the default cluster's being empty
+	seen := map[string]bool{"": true}
+
+	charts, err := broker.repo.GetCharts()
+	if err != nil {
+		return nil, err
+	}
+	for _, chart := range charts {
+		for _, plan := range chart.Plans {
+			if plan.ClusterConfig == nil {
+				continue
+			}
+			configBytes, err := clientcmd.Write(*plan.ClusterConfig)
+			if err != nil {
+				return nil, err
+			}
+			if seen[string(configBytes)] {
+				continue
+			}
+			seen[string(configBytes)] = true
+
+			cluster, err := broker.clusterFactory.GetClusterFromK8sConfig(plan.ClusterConfig)
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	if broker.instanceStore != nil {
+		instances, err := broker.instanceStore.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances {
+			if seen[string(instance.ClusterConfig)] {
+				continue
+			}
+			seen[string(instance.ClusterConfig)] = true
+
+			cluster, err := broker.getRecordedCluster(instance)
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	return clusters, nil
+}
+
+// getInstanceParameters returns the values of the release that weren't set by the chart or plan
+func (broker *PksServiceBroker) getInstanceParameters(helmClient my_helm.MyHelmClient, chart *my_helm.MyChart, planName string, instanceID string) (map[string]interface{}, error) {
+	names, err := broker.getInstanceNames(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := helmClient.ReleaseContent(names.ReleaseName)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil || content.Release == nil || content.Release.Config == nil {
+		return map[string]interface{}{}, nil
+	}
 
-		err = cluster.DeleteNamespace(broker.getNamespace(instanceID), &meta_v1.DeleteOptions{})
+	releaseChart := content.Release.Chart
+	if releaseChart != nil && releaseChart.Metadata != nil && releaseChart.Metadata.Version != chart.Metadata.Version {
+		// the instance still runs an older version of the chart, so its defaults are what the user overrode
+		olderChart := &my_helm.MyChart{
+			Chart:                 *releaseChart,
+			PrivateRegistryServer: chart.PrivateRegistryServer,
+			Plans:                 chart.Plans,
+		}
+		err = olderChart.LoadChartValues()
 		if err != nil {
-			broker.logger.Error(
-				"Delete Namespace failed for planID=", planID, " serviceID=", serviceID, " instanceID=", instanceID, " ", err,
-			)
+			return nil, err
 		}
-	}()
+		chart = olderChart
+	}
+
+	planValues, err := my_helm.MergeValueBytes(chart.TransformedValues, chart.Plans[planName].Values)
+	if err != nil {
+		return nil, err
+	}
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      names.ReleaseName,
+		Namespace: names.Namespace,
+		IsInstall: true,
+		IsUpgrade: false,
+	}
+	renderedValues, err := helmClient.RenderTemplatedValues(releaseOptions, planValues, chart.Chart)
+	if err != nil {
+		return nil, err
+	}
+
+	return my_helm.DiffValueBytes(renderedValues, []byte(content.Release.Config.Raw))
+}
+
+func (broker *PksServiceBroker) Deprovision(ctx context.Context, instanceID string, details brokerapi.DeprovisionDetails, asyncAllowed bool) (brokerapi.DeprovisionServiceSpec, error) {
+	planID := details.PlanID
+	serviceID := details.ServiceID
+	cluster, err := broker.getInstanceCluster(instanceID, planID, serviceID)
+	if err != nil {
+		return brokerapi.DeprovisionServiceSpec{}, err
+	}
+
+	token, err := broker.lockInstance(instanceID, deprovisionOperation)
+	if err != nil {
+		return brokerapi.DeprovisionServiceSpec{}, err
+	}
+	err = broker.startDeprovision(cluster, instanceID, details)
+	if err != nil {
+		broker.unlockInstance(instanceID, token)
+		return brokerapi.DeprovisionServiceSpec{}, err
+	}
 
 	return brokerapi.DeprovisionServiceSpec{
 		IsAsync:       true,
-		OperationData: "deprovision",
+		OperationData: withLockToken(newOperation(deprovisionOperation, 0).String(), token),
 	}, nil
 }
 
+// Bind holds the binding lock until the binding is done, which for async bindings is once LastBindingOperation
+// reports that they've succeeded or failed
 func (broker *PksServiceBroker) Bind(ctx context.Context, instanceID, bindingID string, details brokerapi.BindDetails, asyncAllowed bool) (brokerapi.Binding, error) {
+	token, err := broker.lockBinding(instanceID, bindingID, bindOperation)
+	if err != nil {
+		return brokerapi.Binding{}, err
+	}
+	binding, err := broker.bind(instanceID, bindingID, details, asyncAllowed)
+	if err != nil || !binding.IsAsync {
+		broker.unlockBinding(instanceID, bindingID, token)
+		return binding, err
+	}
+	binding.OperationData = withLockToken(binding.OperationData, token)
+	return binding, nil
+}
+
+func (broker *PksServiceBroker) bind(instanceID, bindingID string, details brokerapi.BindDetails, asyncAllowed bool) (brokerapi.Binding, error) {
 	planID := details.PlanID
 	serviceID := details.ServiceID
-	cluster, err := broker.getCluster(planID, serviceID)
+	cluster, err := broker.getInstanceCluster(instanceID, planID, serviceID)
 	if err != nil {
 		return brokerapi.Binding{}, err
 	}
@@ -277,32 +556,92 @@ func (broker *PksServiceBroker) Bind(ctx context.Context, instanceID, bindingID
 		return brokerapi.Binding{}, errors.New(fmt.Sprintf("service %s not found ", serviceID))
 	}
 
-	template := chart.BindTemplate
-	credentials, err := broker.getCredentials(cluster, instanceID, template)
+	if hasBindHook(chart) {
+		// the hook's Job is started, and its credentials collected, by LastBindingOperation
+		if !asyncAllowed {
+			return brokerapi.Binding{}, brokerapi.ErrAsyncRequired
+		}
+		err = broker.saveBinding(cluster, instanceID, bindingID, details, true)
+		if err != nil {
+			return brokerapi.Binding{}, err
+		}
+		return brokerapi.Binding{
+			IsAsync:       true,
+			OperationData: bindOperation,
+		}, nil
+	}
+
+	if asyncAllowed {
+		namespace, err := broker.getNamespace(instanceID)
+		if err != nil {
+			return brokerapi.Binding{}, err
+		}
+		message, code, err := broker.helmClientFactory.HelmClient(cluster).ResourceReadiness(namespace, cluster)
+		if err != nil {
+			return brokerapi.Binding{}, err
+		}
+		if code != hapi_release.Status_DEPLOYED {
+			if message != nil {
+				broker.logger.Info(fmt.Sprintf("Instance %s not yet bindable: %s", instanceID, *message))
+			}
+			err = broker.saveBinding(cluster, instanceID, bindingID, details, true)
+			if err != nil {
+				return brokerapi.Binding{}, err
+			}
+			return brokerapi.Binding{
+				IsAsync:       true,
+				OperationData: bindOperation,
+			}, nil
+		}
+	}
+
+	credentials, err := broker.bindCredentials(cluster, chart, instanceID, bindingID, getBindingData(details))
+	if err != nil {
+		return brokerapi.Binding{}, err
+	}
+
+	err = broker.saveBinding(cluster, instanceID, bindingID, details, false)
 	if err != nil {
 		return brokerapi.Binding{}, err
 	}
 
+	return brokerapi.Binding{
+		Credentials: credentials,
+	}, nil
+}
+
+// bindCredentials renders the credentials for a binding, storing them in the credstore when one is configured
+func (broker *PksServiceBroker) bindCredentials(cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string, bindingID string, bindingData map[string]string) (map[string]interface{}, error) {
+	credentials, err := broker.getCredentials(cluster, chart, instanceID, bindingID, bindingData)
+	if err != nil {
+		return nil, err
+	}
+
 	if broker.credstore != nil {
 		credentialName := broker.getCredentialName(broker.getServiceName(chart), bindingID)
 
 		_, err := broker.credstore.Put(credentialName, credentials)
 		if err != nil {
-			return brokerapi.Binding{}, err
+			return nil, err
 		}
 		credentials = map[string]interface{}{
 			"credhub-ref": credentialName,
 		}
 
-		_, err = broker.credstore.AddPermission(credentialName, "mtls-app:"+details.AppGUID, []string{"read"})
+		_, err = broker.credstore.AddPermission(credentialName, "mtls-app:"+bindingData["appGUID"], []string{"read"})
 		if err != nil {
-			return brokerapi.Binding{}, err
+			return nil, err
 		}
 	}
 
-	return brokerapi.Binding{
-		Credentials: credentials,
-	}, nil
+	return credentials, nil
+}
+
+func getAppGUID(details brokerapi.BindDetails) string {
+	if details.AppGUID == "" && details.BindResource != nil {
+		return details.BindResource.AppGuid
+	}
+	return details.AppGUID
 }
 
 func (broker *PksServiceBroker) getCluster(planID, serviceID string) (k8s.Cluster, error) {
@@ -328,19 +667,64 @@ func (broker *PksServiceBroker) getCluster(planID, serviceID string) (k8s.Cluste
 	return broker.clusterFactory.DefaultCluster()
 }
 
-func (broker *PksServiceBroker) getCredentials(cluster k8s.Cluster, instanceID string, bindTemplate string) (map[string]interface{}, error) {
-	servicesAndSecrets, err := cluster.GetSecretsAndServices(broker.getNamespace(instanceID))
+// getCredentials renders the bind template, or returns the secrets and services when there's none. Charts with a
+// bind hook get the secret it wrote for the binding as $.binding, and its data when there's no template.
+func (broker *PksServiceBroker) getCredentials(cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string, bindingID string, bindingData map[string]string) (map[string]interface{}, error) {
+	namespace, err := broker.getNamespace(instanceID)
 	if err != nil {
 		return nil, err
 	}
+	servicesAndSecrets, err := cluster.GetSecretsAndServices(namespace, chart.BindSecretExposure, broker.config.NodeAddressConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// a binding never sees the credentials the bind hook wrote for other bindings
+	secrets := []map[string]interface{}{}
+	for _, secret := range servicesAndSecrets["secrets"] {
+		name, _ := secret["name"].(string)
+		if !strings.HasPrefix(name, bindingSecretPrefix) {
+			secrets = append(secrets, secret)
+		}
+	}
+	if servicesAndSecrets != nil {
+		servicesAndSecrets["secrets"] = secrets
+	}
+
+	templateInput := map[string]interface{}{}
+	for key, value := range servicesAndSecrets {
+		templateInput[key] = value
+	}
+	// the binding's own secret is fetched by name, so hooks don't have to annotate it for opt-in charts
+	var bindingSecret map[string]interface{}
+	if hasBindHook(chart) {
+		secret, err := cluster.GetSecret(namespace, broker.getBindingSecretName(bindingID), meta_v1.GetOptions{})
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				return nil, errBindingSecretNotFound
+			}
+			return nil, err
+		}
+		bindingSecret = k8s.SecretToMap(secret)
+		templateInput["binding"] = bindingSecret
+	}
 
 	var credentialBytes []byte
-	if bindTemplate != "" {
-		renderedTemplate, err := my_helm.RenderJsonnetTemplate(bindTemplate, servicesAndSecrets)
+	if chart.BindTemplate != "" {
+		err = broker.addBindTemplateInputs(templateInput, cluster, chart, instanceID, bindingID, bindingData)
+		if err != nil {
+			return nil, err
+		}
+		renderedTemplate, err := chart.RenderBindTemplate(templateInput)
 		if err != nil {
 			return nil, err
 		}
 		credentialBytes = []byte(renderedTemplate)
+	} else if hasBindHook(chart) {
+		credentialBytes, err = json.Marshal(bindingSecret["data"])
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		credentialBytes, err = json.Marshal(servicesAndSecrets)
 		if err != nil {
@@ -356,52 +740,317 @@ func (broker *PksServiceBroker) getCredentials(cluster k8s.Cluster, instanceID s
 	return bindCredentials, nil
 }
 
+// LastBindingOperation releases the binding lock once the bind or unbind has succeeded or failed
 func (broker *PksServiceBroker) LastBindingOperation(ctx context.Context, instanceID, bindingID string, details brokerapi.PollDetails) (brokerapi.LastOperation, error) {
-	return brokerapi.LastOperation{}, errors.New("this broker does not support async binding")
+	operationData, token := splitLockToken(details.OperationData)
+	details.OperationData = operationData
+	lastOperation, err := broker.lastBindingOperation(instanceID, bindingID, details)
+	if err == nil && isOperationDone(lastOperation.State) {
+		broker.unlockBinding(instanceID, bindingID, token)
+	}
+	return lastOperation, err
 }
 
-func (broker *PksServiceBroker) GetBinding(ctx context.Context, instanceID, bindingID string) (brokerapi.GetBindingSpec, error) {
-	return brokerapi.GetBindingSpec{}, errors.New("this optional operation isn't supported yet")
-}
+func (broker *PksServiceBroker) lastBindingOperation(instanceID, bindingID string, details brokerapi.PollDetails) (brokerapi.LastOperation, error) {
+	cluster, err := broker.getInstanceCluster(instanceID, details.PlanID, details.ServiceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
 
-func (broker *PksServiceBroker) Unbind(ctx context.Context, instanceID, bindingID string, details brokerapi.UnbindDetails, asyncAllowed bool) (brokerapi.UnbindSpec, error) {
 	chartsMap, err := broker.GetChartsMap()
 	if err != nil {
-		return brokerapi.UnbindSpec{}, err
+		return brokerapi.LastOperation{}, err
 	}
-
 	chart, ok := chartsMap[details.ServiceID]
 	if !ok {
-		return brokerapi.UnbindSpec{}, errors.New(fmt.Sprintf("service %s not found ", details.ServiceID))
+		return brokerapi.LastOperation{}, errors.New(fmt.Sprintf("service %s not found ", details.ServiceID))
 	}
 
-	if broker.credstore != nil {
-		credentialName := broker.getCredentialName(broker.getServiceName(chart), bindingID)
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
+	binding, err := cluster.GetConfigMap(namespace, broker.getBindingConfigMapName(bindingID), meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return brokerapi.LastOperation{}, brokerapi.ErrBindingDoesNotExist
+		}
+		return brokerapi.LastOperation{}, err
+	}
+	appGUID := binding.Data["appGUID"]
 
-		err = broker.credstore.DeletePermission(credentialName)
-		if err != nil {
-			broker.logger.Error(fmt.Sprintf("fail to delete permissions on the key %s", credentialName), err)
+	if details.OperationData == unbindOperation && hasUnbindHook(chart) {
+		return broker.unbindState(cluster, chart, instanceID, bindingID, appGUID)
+	}
+	if binding.Data[bindingPendingKey] == "" {
+		// an earlier poll already stored the credentials
+		return brokerapi.LastOperation{
+			State:       brokerapi.Succeeded,
+			Description: "binding ready",
+		}, nil
+	}
+
+	helmClient := broker.helmClientFactory.HelmClient(cluster)
+	message, code, err := helmClient.ResourceReadiness(namespace, cluster)
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
+	if code != hapi_release.Status_DEPLOYED {
+		description := "waiting for instance to be ready"
+		if message != nil && *message != "" {
+			description = *message
 		}
+		return brokerapi.LastOperation{
+			State:       brokerapi.InProgress,
+			Description: description,
+		}, nil
+	}
 
-		err := broker.credstore.Delete(credentialName)
-		if err != nil {
-			return brokerapi.UnbindSpec{}, err
+	if hasBindHook(chart) {
+		lastOperation, done, err := broker.runBindHook(cluster, instanceID, bindingID, appGUID, bindOperation, chart.BindHooks.Bind)
+		if err != nil || !done {
+			return lastOperation, err
 		}
 	}
 
-	return brokerapi.UnbindSpec{
+	_, err = broker.bindCredentials(cluster, chart, instanceID, bindingID, binding.Data)
+	if err != nil {
+		if err == errBindingSecretNotFound {
+			return brokerapi.LastOperation{
+				State:       brokerapi.Failed,
+				Description: fmt.Sprintf("bind hook completed without writing secret %s", broker.getBindingSecretName(bindingID)),
+			}, nil
+		}
+		if my_helm.IsTemplateRuntimeError(err) && time.Since(binding.CreationTimestamp.Time) < bindTemplateTimeout {
+			// the template references things that aren't there yet, or never will be when it's broken
+			return brokerapi.LastOperation{
+				State:       brokerapi.InProgress,
+				Description: fmt.Sprintf("waiting for bind template to render: %v", err),
+			}, nil
+		}
+		return brokerapi.LastOperation{
+			State:       brokerapi.Failed,
+			Description: fmt.Sprintf("bind failed %v", err),
+		}, nil
+	}
+
+	delete(binding.Data, bindingPendingKey)
+	_, err = cluster.UpdateConfigMap(namespace, binding)
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
+
+	return brokerapi.LastOperation{
+		State:       brokerapi.Succeeded,
+		Description: "binding ready",
+	}, nil
+}
+
+// GetBinding renders bindings made before bindings were recorded with what the instance's namespace records
+func (broker *PksServiceBroker) GetBinding(ctx context.Context, instanceID, bindingID string) (brokerapi.GetBindingSpec, error) {
+	cluster, namespace, err := broker.findInstance(instanceID)
+	if err != nil {
+		return brokerapi.GetBindingSpec{}, err
+	}
+
+	var bindingData map[string]string
+	binding, err := cluster.GetConfigMap(namespace.Name, broker.getBindingConfigMapName(bindingID), meta_v1.GetOptions{})
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			return brokerapi.GetBindingSpec{}, err
+		}
+		bindingData = map[string]string{
+			"serviceID": namespace.Labels["serviceID"],
+			"planID":    namespace.Labels["planID"],
+		}
+	} else {
+		if binding.Data[bindingPendingKey] != "" {
+			// the binding only exists once LastBindingOperation has stored its credentials
+			return brokerapi.GetBindingSpec{}, brokerapi.ErrBindingNotFound
+		}
+		bindingData = binding.Data
+	}
+
+	serviceID := bindingData["serviceID"]
+	chartsMap, err := broker.GetChartsMap()
+	if err != nil {
+		return brokerapi.GetBindingSpec{}, err
+	}
+	chart, ok := chartsMap[serviceID]
+	if !ok {
+		return brokerapi.GetBindingSpec{}, errors.New(fmt.Sprintf("service %s not found ", serviceID))
+	}
+
+	if broker.credstore != nil {
+		// the platform only ever saw the reference, so make sure it still resolves before handing it back
+		credentialName := broker.getCredentialName(broker.getServiceName(chart), bindingID)
+		_, err := broker.credstore.Get(credentialName)
+		if err != nil {
+			return brokerapi.GetBindingSpec{}, err
+		}
+
+		return brokerapi.GetBindingSpec{
+			Credentials: map[string]interface{}{
+				"credhub-ref": credentialName,
+			},
+		}, nil
+	}
+
+	credentials, err := broker.getCredentials(cluster, chart, instanceID, bindingID, bindingData)
+	if err != nil {
+		if err == errBindingSecretNotFound {
+			return brokerapi.GetBindingSpec{}, brokerapi.ErrBindingNotFound
+		}
+		return brokerapi.GetBindingSpec{}, err
+	}
+
+	return brokerapi.GetBindingSpec{
+		Credentials: credentials,
+	}, nil
+}
+
+// saveBinding records what is needed to look the binding up again without the platform passing details. Pending
+// bindings are async ones LastBindingOperation hasn't yet stored the credentials of.
+func (broker *PksServiceBroker) saveBinding(cluster k8s.Cluster, instanceID string, bindingID string, details brokerapi.BindDetails, pending bool) error {
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+	data := getBindingData(details)
+	if pending {
+		data[bindingPendingKey] = "true"
+	}
+	_, err = cluster.CreateOrUpdateConfigMap(namespace, &api_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: broker.getBindingConfigMapName(bindingID),
+			Labels: map[string]string{
+				"bindingID":                    bindingID,
+				"app.kubernetes.io/managed-by": "kibosh",
+			},
+		},
+		Data: data,
+	})
+	return err
+}
+
+// Unbind holds the binding lock the way Bind does
+func (broker *PksServiceBroker) Unbind(ctx context.Context, instanceID, bindingID string, details brokerapi.UnbindDetails, asyncAllowed bool) (brokerapi.UnbindSpec, error) {
+	token, err := broker.lockBinding(instanceID, bindingID, unbindOperation)
+	if err != nil {
+		return brokerapi.UnbindSpec{}, err
+	}
+	spec, err := broker.unbind(instanceID, bindingID, details, asyncAllowed)
+	if err != nil || !spec.IsAsync {
+		broker.unlockBinding(instanceID, bindingID, token)
+		return spec, err
+	}
+	spec.OperationData = withLockToken(spec.OperationData, token)
+	return spec, nil
+}
+
+func (broker *PksServiceBroker) unbind(instanceID, bindingID string, details brokerapi.UnbindDetails, asyncAllowed bool) (brokerapi.UnbindSpec, error) {
+	chartsMap, err := broker.GetChartsMap()
+	if err != nil {
+		return brokerapi.UnbindSpec{}, err
+	}
+
+	chart, ok := chartsMap[details.ServiceID]
+	if !ok {
+		return brokerapi.UnbindSpec{}, errors.New(fmt.Sprintf("service %s not found ", details.ServiceID))
+	}
+
+	if broker.credstore != nil {
+		credentialName := broker.getCredentialName(broker.getServiceName(chart), bindingID)
+
+		err = broker.credstore.DeletePermission(credentialName)
+		if err != nil {
+			broker.logger.Error(fmt.Sprintf("fail to delete permissions on the key %s", credentialName), err)
+		}
+
+		err := broker.credstore.Delete(credentialName)
+		if err != nil {
+			return brokerapi.UnbindSpec{}, err
+		}
+	}
+
+	cluster, err := broker.getInstanceCluster(instanceID, details.PlanID, details.ServiceID)
+	if err != nil {
+		return brokerapi.UnbindSpec{}, err
+	}
+
+	var binding *api_v1.ConfigMap
+	if hasUnbindHook(chart) {
+		namespace, err := broker.getNamespace(instanceID)
+		if err != nil {
+			return brokerapi.UnbindSpec{}, err
+		}
+		binding, err = cluster.GetConfigMap(namespace, broker.getBindingConfigMapName(bindingID), meta_v1.GetOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return brokerapi.UnbindSpec{}, err
+		}
+	}
+
+	// bindings made before bindings were recorded never ran the bind hook, so there's nothing for the unbind
+	// hook to revoke
+	if hasUnbindHook(chart) && binding != nil {
+		// LastBindingOperation removes the binding once the hook has revoked its credentials
+		if !asyncAllowed {
+			return brokerapi.UnbindSpec{}, brokerapi.ErrAsyncRequired
+		}
+		_, _, err = broker.runBindHook(cluster, instanceID, bindingID, binding.Data["appGUID"], unbindOperation, chart.BindHooks.Unbind)
+		if err != nil {
+			return brokerapi.UnbindSpec{}, err
+		}
+		return brokerapi.UnbindSpec{
+			IsAsync:       true,
+			OperationData: unbindOperation,
+		}, nil
+	}
+
+	err = broker.removeBinding(cluster, chart, instanceID, bindingID)
+	if err != nil {
+		return brokerapi.UnbindSpec{}, err
+	}
+
+	return brokerapi.UnbindSpec{
 		IsAsync: false,
 	}, nil
 }
 
+// Update holds the instance lock as an update, even when it turns out to be an upgrade
 func (broker *PksServiceBroker) Update(ctx context.Context, instanceID string, details brokerapi.UpdateDetails, asyncAllowed bool) (brokerapi.UpdateServiceSpec, error) {
+	token, err := broker.lockInstance(instanceID, updateOperation)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, err
+	}
+	spec, err := broker.update(instanceID, details)
+	if err != nil {
+		broker.unlockInstance(instanceID, token)
+		return spec, err
+	}
+	spec.OperationData = withLockToken(spec.OperationData, token)
+	return spec, nil
+}
+
+func (broker *PksServiceBroker) update(instanceID string, details brokerapi.UpdateDetails) (brokerapi.UpdateServiceSpec, error) {
 	var updateValues []byte
 	var err error
 
-	if details.GetRawParameters() == nil {
+	if details.PreviousValues.PlanID == "" && details.PlanID != "" {
+		instance, err := broker.getInstanceRecord(instanceID)
+		if err != nil {
+			return brokerapi.UpdateServiceSpec{}, err
+		}
+		if instance != nil {
+			details.PreviousValues.PlanID = instance.PlanID
+		}
+	}
+
+	planChanged := details.PreviousValues.PlanID != "" && details.PreviousValues.PlanID != details.PlanID
+	if details.GetRawParameters() == nil && !planChanged && details.MaintenanceInfo == nil {
 		return brokerapi.UpdateServiceSpec{
 			IsAsync:       true,
-			OperationData: "update",
+			OperationData: newOperation(updateOperation, 0).String(),
 		}, nil
 	}
 
@@ -423,16 +1072,69 @@ func (broker *PksServiceBroker) Update(ctx context.Context, instanceID string, d
 
 	planName := strings.TrimPrefix(details.PlanID, details.ServiceID+"-")
 
+	if details.MaintenanceInfo != nil {
+		maintenanceInfo := broker.getMaintenanceInfo(chart, planName)
+		if maintenanceInfo == nil || maintenanceInfo.Version != details.MaintenanceInfo.Version {
+			return brokerapi.UpdateServiceSpec{}, brokerapi.ErrMaintenanceInfoConflict
+		}
+	}
+
+	if planChanged {
+		revision, err := broker.changePlan(chart, instanceID, details, updateValues)
+		if err != nil {
+			return brokerapi.UpdateServiceSpec{}, err
+		}
+
+		return brokerapi.UpdateServiceSpec{
+			IsAsync:       true,
+			OperationData: newOperation(updateOperation, revision).String(),
+		}, nil
+	}
+
+	if details.MaintenanceInfo != nil {
+		operationData, err := broker.upgradeInstance(chart, instanceID, details, updateValues)
+		if err != nil {
+			return brokerapi.UpdateServiceSpec{}, err
+		}
+		if operationData != "" {
+			return brokerapi.UpdateServiceSpec{
+				IsAsync:       true,
+				OperationData: operationData,
+			}, nil
+		}
+	}
+
+	if details.GetRawParameters() == nil {
+		return brokerapi.UpdateServiceSpec{
+			IsAsync:       true,
+			OperationData: newOperation(updateOperation, 0).String(),
+		}, nil
+	}
+
+	err = chart.ValidateParameters(planName, updateValues)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, broker.toParametersError(err)
+	}
+
 	planID := details.PlanID
 	serviceID := details.ServiceID
-	cluster, err := broker.getCluster(planID, serviceID)
+	cluster, err := broker.getInstanceCluster(instanceID, planID, serviceID)
 	if err != nil {
 		return brokerapi.UpdateServiceSpec{}, err
 	}
 
 	helmClient := broker.helmClientFactory.HelmClient(cluster)
 
-	_, err = helmClient.UpdateChart(chart, broker.getReleaseName(instanceID), planName, updateValues)
+	revision, err := broker.nextRevision(helmClient, instanceID)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, err
+	}
+
+	releaseName, err := broker.getReleaseName(instanceID)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, err
+	}
+	_, err = helmClient.UpdateChart(chart, releaseName, planName, updateValues)
 	if err != nil {
 		broker.logger.Debug(fmt.Sprintf("Update failed on update release= %v", err))
 		return brokerapi.UpdateServiceSpec{}, err
@@ -440,33 +1142,202 @@ func (broker *PksServiceBroker) Update(ctx context.Context, instanceID string, d
 
 	return brokerapi.UpdateServiceSpec{
 		IsAsync:       true,
-		OperationData: "update",
+		OperationData: newOperation(updateOperation, revision).String(),
 	}, nil
 }
 
+// changePlan moves the release to the new plan's values, returning the release revision that does so
+func (broker *PksServiceBroker) changePlan(chart *my_helm.MyChart, instanceID string, details brokerapi.UpdateDetails, updateValues []byte) (int32, error) {
+	previousPlanName := strings.TrimPrefix(details.PreviousValues.PlanID, details.ServiceID+"-")
+	planName := strings.TrimPrefix(details.PlanID, details.ServiceID+"-")
+
+	previousPlan, ok := chart.Plans[previousPlanName]
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("Plan not found for [%s]", details.PreviousValues.PlanID))
+	}
+	plan, ok := chart.Plans[planName]
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("Plan not found for [%s]", details.PlanID))
+	}
+	if !reflect.DeepEqual(previousPlan.ClusterConfig, plan.ClusterConfig) {
+		return 0, brokerapi.NewFailureResponseBuilder(
+			errors.New(fmt.Sprintf("Plans [%s] and [%s] target different clusters, migrating between them is not supported", previousPlanName, planName)),
+			http.StatusUnprocessableEntity, "plan-change-not-supported",
+		).WithErrorKey("PlanChangeNotSupported").Build()
+	}
+
+	cluster, err := broker.getInstanceCluster(instanceID, details.PlanID, details.ServiceID)
+	if err != nil {
+		return 0, err
+	}
+
+	return broker.migrateInstance(cluster, chart, instanceID, previousPlanName, details, updateValues)
+}
+
+// upgradeInstance moves an instance that isn't on the current maintenance version to the current chart,
+// returning the operation data for the upgrade or empty when the instance is already current
+func (broker *PksServiceBroker) upgradeInstance(chart *my_helm.MyChart, instanceID string, details brokerapi.UpdateDetails, updateValues []byte) (string, error) {
+	planName := strings.TrimPrefix(details.PlanID, details.ServiceID+"-")
+
+	cluster, err := broker.getInstanceCluster(instanceID, details.PlanID, details.ServiceID)
+	if err != nil {
+		return "", err
+	}
+
+	namespaceName, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return "", err
+	}
+	namespace, err := cluster.GetNamespace(namespaceName, nil)
+	if err != nil {
+		return "", err
+	}
+	fromVersion := namespace.Annotations[maintenanceVersionAnnotation]
+	toVersion := broker.getMaintenanceVersion(chart, planName)
+	if fromVersion == toVersion {
+		return "", nil
+	}
+
+	revision, err := broker.migrateInstance(cluster, chart, instanceID, planName, details, updateValues)
+	if err != nil {
+		return "", err
+	}
+
+	op := newOperation(upgradeOperation, revision)
+	op.FromVersion = fromVersion
+	op.ToVersion = toVersion
+	return op.String(), nil
+}
+
+// migrateInstance upgrades the release to the chart and the plan in details, keeping the parameters the user
+// previously supplied, and records the new plan and maintenance version as pending on the namespace. It returns the
+// release revision of the upgrade.
+func (broker *PksServiceBroker) migrateInstance(cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string, previousPlanName string, details brokerapi.UpdateDetails, updateValues []byte) (int32, error) {
+	planName := strings.TrimPrefix(details.PlanID, details.ServiceID+"-")
+	helmClient := broker.helmClientFactory.HelmClient(cluster)
+
+	parameters, err := broker.getInstanceParameters(helmClient, chart, previousPlanName, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	userValues, err := yaml.Marshal(parameters)
+	if err != nil {
+		return 0, err
+	}
+	userValues, err = my_helm.MergeValueBytes(userValues, updateValues)
+	if err != nil {
+		return 0, err
+	}
+
+	err = chart.ValidateParameters(planName, userValues)
+	if err != nil {
+		return 0, broker.toParametersError(err)
+	}
+
+	revision, err := broker.nextRevision(helmClient, instanceID)
+	if err != nil {
+		return 0, err
+	}
+
+	names, err := broker.getInstanceNames(instanceID)
+	if err != nil {
+		return 0, err
+	}
+	err = my_helm.ApplyPlanLimits(cluster, names.Namespace, chart.Plans[planName])
+	if err != nil {
+		return 0, err
+	}
+	err = my_helm.ApplyNetworkPolicies(cluster, names.Namespace, chart.Plans[planName], broker.config.NetworkIsolation)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = helmClient.UpgradeChart(chart, names.Namespace, names.ReleaseName, planName, userValues)
+	if err != nil {
+		broker.logger.Debug(fmt.Sprintf("Update failed on upgrade release= %v", err))
+		return 0, err
+	}
+
+	err = broker.startMigration(cluster, instanceID, pendingMigration{
+		Revision:           revision,
+		PlanID:             details.PlanID,
+		PreviousPlanID:     details.ServiceID + "-" + previousPlanName,
+		ChartVersion:       chart.Metadata.Version,
+		MaintenanceVersion: broker.getMaintenanceVersion(chart, planName),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+// LastOperation releases the instance lock once the operation has succeeded or failed
 func (broker *PksServiceBroker) LastOperation(ctx context.Context, instanceID string, details brokerapi.PollDetails) (brokerapi.LastOperation, error) {
+	operationData, token := splitLockToken(details.OperationData)
+	details.OperationData = operationData
+	lastOperation, err := broker.lastOperation(instanceID, details)
+	if err == nil && isOperationDone(lastOperation.State) {
+		broker.unlockInstance(instanceID, token)
+	}
+	return lastOperation, err
+}
+
+func (broker *PksServiceBroker) lastOperation(instanceID string, details brokerapi.PollDetails) (brokerapi.LastOperation, error) {
 	var brokerStatus brokerapi.LastOperationState
 	var description string
 
 	planID := details.PlanID
 	serviceID := details.ServiceID
-	cluster, err := broker.getCluster(planID, serviceID)
+	cluster, err := broker.getInstanceCluster(instanceID, planID, serviceID)
 	if err != nil {
 		return brokerapi.LastOperation{}, err
 	}
 
+	op := parseOperation(details.OperationData)
+	if op.Type == deprovisionOperation {
+		lastOperation, tracked, err := broker.deprovisionState(cluster, instanceID)
+		if err != nil {
+			return brokerapi.LastOperation{}, err
+		}
+		if tracked {
+			return lastOperation, nil
+		}
+	}
+
 	helmClient := broker.helmClientFactory.HelmClient(cluster)
 
-	response, err := helmClient.ReleaseStatus(broker.getReleaseName(instanceID))
+	rollbackable := (op.Type == updateOperation || op.Type == upgradeOperation) && op.Revision > 0
+	if rollbackable {
+		lastOperation, rolledBack, err := broker.rollbackState(helmClient, instanceID, op)
+		if err != nil {
+			return brokerapi.LastOperation{}, err
+		}
+		if rolledBack {
+			return lastOperation, nil
+		}
+	}
+
+	code, started, err := broker.getOperationStatus(helmClient, instanceID, op)
 	if err != nil {
 		//This err potentially should result in 410 / ok response, in the case where the release is no-found
 		//Will require some changes if we want to support release purging or other flows
 		return brokerapi.LastOperation{}, err
 	}
+	if !started {
+		if time.Since(op.StartedAt) > operationStartTimeout {
+			return brokerapi.LastOperation{
+				State:       brokerapi.Failed,
+				Description: fmt.Sprintf("%s failed, release revision %d never started", op.Type, op.Revision),
+			}, nil
+		}
+		return brokerapi.LastOperation{
+			State:       brokerapi.InProgress,
+			Description: fmt.Sprintf("waiting for %s to start", op.Type),
+		}, nil
+	}
 
-	code := response.Info.Status.Code
-	operationData := details.OperationData
-	if operationData == "provision" {
+	if op.Type == provisionOperation {
 		switch code {
 		case hapi_release.Status_DEPLOYED:
 			brokerStatus = brokerapi.Succeeded
@@ -480,7 +1351,7 @@ func (broker *PksServiceBroker) LastOperation(ctx context.Context, instanceID st
 			brokerStatus = brokerapi.Failed
 			description = fmt.Sprintf("provision failed %v", code)
 		}
-	} else if operationData == "deprovision" {
+	} else if op.Type == deprovisionOperation {
 		switch code {
 		case hapi_release.Status_DELETED:
 			brokerStatus = brokerapi.Succeeded
@@ -494,15 +1365,44 @@ func (broker *PksServiceBroker) LastOperation(ctx context.Context, instanceID st
 			brokerStatus = brokerapi.Failed
 			description = fmt.Sprintf("deprovision failed %v", code)
 		}
-	} else if operationData == "update" {
+	} else if op.Type == updateOperation {
 		switch code {
 		case hapi_release.Status_DEPLOYED:
 			brokerStatus = brokerapi.Succeeded
 			description = "updated"
+		case hapi_release.Status_PENDING_UPGRADE:
+			brokerStatus = brokerapi.InProgress
+			description = "update in progress"
 		default:
 			brokerStatus = brokerapi.Failed
 			description = fmt.Sprintf("update failed %v", code)
 		}
+	} else if op.Type == upgradeOperation {
+		transition := fmt.Sprintf("from %s to %s", op.FromVersion, op.ToVersion)
+		if op.FromVersion == "" {
+			transition = fmt.Sprintf("to %s", op.ToVersion)
+		}
+		switch code {
+		case hapi_release.Status_DEPLOYED:
+			brokerStatus = brokerapi.Succeeded
+			description = fmt.Sprintf("upgraded %s", transition)
+		case hapi_release.Status_PENDING_UPGRADE:
+			brokerStatus = brokerapi.InProgress
+			description = fmt.Sprintf("upgrade %s in progress", transition)
+		default:
+			brokerStatus = brokerapi.Failed
+			description = fmt.Sprintf("upgrade %s failed %v", transition, code)
+		}
+	}
+
+	if brokerStatus == brokerapi.Failed && rollbackable {
+		rollbackEnabled, err := broker.rollbackEnabled(cluster, instanceID, serviceID, planID)
+		if err != nil {
+			return brokerapi.LastOperation{}, err
+		}
+		if rollbackEnabled {
+			return broker.rollback(cluster, helmClient, instanceID, op, fmt.Sprintf("failed %v", code))
+		}
 	}
 
 	if brokerStatus != brokerapi.Succeeded {
@@ -513,8 +1413,12 @@ func (broker *PksServiceBroker) LastOperation(ctx context.Context, instanceID st
 	}
 
 	var message *string
-	if operationData != "deprovision" {
-		message, code, err = helmClient.ResourceReadiness(broker.getNamespace(instanceID), cluster)
+	if op.Type != deprovisionOperation {
+		namespace, err := broker.getNamespace(instanceID)
+		if err != nil {
+			return brokerapi.LastOperation{}, err
+		}
+		message, code, err = helmClient.ResourceReadiness(namespace, cluster)
 		if err != nil || code == hapi_release.Status_UNKNOWN {
 			return brokerapi.LastOperation{}, err
 		}
@@ -522,29 +1426,120 @@ func (broker *PksServiceBroker) LastOperation(ctx context.Context, instanceID st
 			message = &description
 		}
 		if code == hapi_release.Status_PENDING_INSTALL {
+			if rollbackable && time.Since(op.StartedAt) > rollbackReadinessTimeout {
+				rollbackEnabled, err := broker.rollbackEnabled(cluster, instanceID, serviceID, planID)
+				if err != nil {
+					return brokerapi.LastOperation{}, err
+				}
+				if rollbackEnabled {
+					return broker.rollback(cluster, helmClient, instanceID, op, fmt.Sprintf("timed out waiting for readiness: %s", *message))
+				}
+			}
 			return brokerapi.LastOperation{
 				State:       brokerapi.InProgress,
 				Description: *message,
 			}, nil
 		}
+
+		waiting, err := broker.waitingOnDashboard(cluster, instanceID, serviceID)
+		if err != nil {
+			if !my_helm.IsTemplateRuntimeError(err) {
+				return brokerapi.LastOperation{}, err
+			}
+			// the template may refer to what's still being assigned, but not for long after the release deployed
+			if time.Since(op.StartedAt) > dashboardTimeout {
+				return brokerapi.LastOperation{
+					State:       brokerapi.Failed,
+					Description: fmt.Sprintf("dashboard template failed: %v", err),
+				}, nil
+			}
+			waiting = true
+		}
+		if waiting {
+			return brokerapi.LastOperation{
+				State:       brokerapi.InProgress,
+				Description: "waiting for the dashboard to be assigned a host",
+			}, nil
+		}
 	} else {
 		message = &description
 	}
 
+	if rollbackable {
+		err = broker.completeMigration(cluster, instanceID, op)
+		if err != nil {
+			return brokerapi.LastOperation{}, err
+		}
+	}
+
 	return brokerapi.LastOperation{
 		State:       brokerapi.Succeeded,
 		Description: *message,
 	}, nil
 }
 
-func (broker *PksServiceBroker) getNamespace(instanceID string) string {
-	return "kibosh-" + instanceID
+func (broker *PksServiceBroker) getPlanSchemas(plan my_helm.Plan) *brokerapi.ServiceSchemas {
+	if plan.Schema == nil {
+		return nil
+	}
+	return &brokerapi.ServiceSchemas{
+		Instance: brokerapi.ServiceInstanceSchema{
+			Create: brokerapi.Schema{Parameters: plan.Schema},
+			Update: brokerapi.Schema{Parameters: plan.Schema},
+		},
+	}
 }
 
-func (broker *PksServiceBroker) getReleaseName(instanceID string) string {
-	hashed := md5.Sum([]byte(instanceID))
-	encoded := base32.StdEncoding.EncodeToString(hashed[:])
-	return fmt.Sprintf("k-%s", strings.ToLower(string(encoded[0:8])))
+func (broker *PksServiceBroker) getMaintenanceInfo(chart *my_helm.MyChart, planName string) *brokerapi.MaintenanceInfo {
+	version := broker.getMaintenanceVersion(chart, planName)
+	if version == "" {
+		return nil
+	}
+	return &brokerapi.MaintenanceInfo{
+		Version:     version,
+		Description: fmt.Sprintf("%s chart version %s", chart.Metadata.Name, chart.Metadata.Version),
+	}
+}
+
+// getMaintenanceVersion is the chart version with a hash of the plan values as semver build metadata
+func (broker *PksServiceBroker) getMaintenanceVersion(chart *my_helm.MyChart, planName string) string {
+	if chart.Metadata == nil || chart.Metadata.Version == "" {
+		return ""
+	}
+	valuesHash := md5.Sum(chart.Plans[planName].Values)
+	return fmt.Sprintf("%s+%x", chart.Metadata.Version, valuesHash[:4])
+}
+
+func (broker *PksServiceBroker) toParametersError(err error) error {
+	if _, ok := err.(*my_helm.ParameterValidationError); ok {
+		return brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-parameters")
+	}
+	return err
+}
+
+func (broker *PksServiceBroker) getPlanCosts(plan my_helm.Plan) []brokerapi.ServicePlanCost {
+	var costs []brokerapi.ServicePlanCost
+	for _, cost := range plan.Costs {
+		costs = append(costs, brokerapi.ServicePlanCost{
+			Amount: cost.Amount,
+			Unit:   cost.Unit,
+		})
+	}
+	return costs
+}
+
+func orDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func boolOrDefault(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
 }
 
 func (broker *PksServiceBroker) getServiceName(chart *my_helm.MyChart) string {
@@ -555,6 +1550,10 @@ func (broker *PksServiceBroker) getServiceID(chart *my_helm.MyChart) string {
 	return uuid.NewSHA1(uuid.NameSpace_OID, []byte(broker.getServiceName(chart))).String()
 }
 
+func (broker *PksServiceBroker) getBindingConfigMapName(bindingID string) string {
+	return bindingConfigMapPrefix + bindingID
+}
+
 func (broker *PksServiceBroker) getCredentialName(serviceName, bindingID string) string {
 	return fmt.Sprintf("/c/%s/%s/%s/secrets-and-services", credhubClientIdentifier, serviceName, bindingID)
 }
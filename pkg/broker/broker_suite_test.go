@@ -16,8 +16,19 @@
 package broker_test
 
 import (
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_config "github.com/cf-platform-eng/kibosh/pkg/config"
+	"github.com/cf-platform-eng/kibosh/pkg/credstore"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore/instancestorefakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/repository/repositoryfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	hapi_chart "k8s.io/helm/pkg/proto/hapi/chart"
 
 	"testing"
 )
@@ -26,3 +37,57 @@ func TestApi(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Broker Suite")
 }
+
+// brokerFixture is what specs of the broker share: a default cluster with its helm client, a repo holding the
+// spacebears 1.0.0 chart with a small plan, and the config. Specs change what they test before calling newBroker.
+type brokerFixture struct {
+	helmClient        *helmfakes.FakeMyHelmClient
+	helmClientFactory *helmfakes.FakeHelmClientFactory
+	cluster           *k8sfakes.FakeCluster
+	clusterFactory    *k8sfakes.FakeClusterFactory
+	chart             *my_helm.MyChart
+	repo              *repositoryfakes.FakeRepository
+	config            *my_config.Config
+}
+
+func newBrokerFixture() *brokerFixture {
+	fixture := &brokerFixture{
+		helmClient:        &helmfakes.FakeMyHelmClient{},
+		helmClientFactory: &helmfakes.FakeHelmClientFactory{},
+		cluster:           &k8sfakes.FakeCluster{},
+		clusterFactory:    &k8sfakes.FakeClusterFactory{},
+		chart: &my_helm.MyChart{
+			Chart: hapi_chart.Chart{
+				Metadata: &hapi_chart.Metadata{
+					Name:    "spacebears",
+					Version: "1.0.0",
+				},
+			},
+			Plans: map[string]my_helm.Plan{
+				"small": {Name: "small"},
+			},
+		},
+		repo: &repositoryfakes.FakeRepository{},
+		config: &my_config.Config{
+			TillerNamespace: "my-kibosh-namespace",
+			RegistryConfig:  &my_config.RegistryConfig{},
+			HelmTLSConfig:   &my_config.HelmTLSConfig{},
+		},
+	}
+	fixture.helmClientFactory.HelmClientReturns(fixture.helmClient)
+	fixture.clusterFactory.DefaultClusterReturns(fixture.cluster, nil)
+	fixture.repo.GetChartsReturns([]*my_helm.MyChart{fixture.chart}, nil)
+	return fixture
+}
+
+// newBroker passes nil for the stores the spec doesn't use
+func (f *brokerFixture) newBroker(credStore credstore.CredStore, instanceStore instancestore.InstanceStore) *PksServiceBroker {
+	return NewPksServiceBroker(f.config, f.clusterFactory, f.helmClientFactory, nil, nil, f.repo, credStore, instanceStore, nil, logrus.New())
+}
+
+// newFakeInstanceStore has no record of any instance
+func newFakeInstanceStore() *instancestorefakes.FakeInstanceStore {
+	fakeInstanceStore := &instancestorefakes.FakeInstanceStore{}
+	fakeInstanceStore.GetReturns(nil, instancestore.ErrInstanceNotFound)
+	return fakeInstanceStore
+}
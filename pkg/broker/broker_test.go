@@ -18,7 +18,10 @@ package broker_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	. "github.com/cf-platform-eng/kibosh/pkg/broker"
 	my_config "github.com/cf-platform-eng/kibosh/pkg/config"
@@ -34,8 +37,12 @@ import (
 	"github.com/pivotal-cf/brokerapi"
 	"github.com/sirupsen/logrus"
 	api_v1 "k8s.io/api/core/v1"
+	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sAPI "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/helm/pkg/chartutil"
 	hapi_chart "k8s.io/helm/pkg/proto/hapi/chart"
 	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
 	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
@@ -151,7 +158,7 @@ var _ = Describe("Broker", func() {
 
 	Context("catalog", func() {
 		It("Provides a catalog with correct service", func() {
-			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, logger)
+			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
 			serviceCatalog, err := serviceBroker.Services(nil)
 			Expect(err).To(BeNil())
 
@@ -172,6 +179,9 @@ var _ = Describe("Broker", func() {
 			Expect(spacebearsService.Name).To(Equal("spacebears"))
 			Expect(spacebearsService.Description).To(Equal("spacebears service and spacebears broker helm chart"))
 			Expect(spacebearsService.Bindable).To(BeTrue())
+			Expect(spacebearsService.InstancesRetrievable).To(BeTrue())
+			Expect(spacebearsService.BindingsRetrievable).To(BeTrue())
+			Expect(spacebearsService.PlanUpdatable).To(BeTrue())
 
 			Expect(mysqlService.ID).To(Equal(mysqlServiceGUID))
 			Expect(mysqlService.Name).To(Equal("mysql"))
@@ -179,7 +189,7 @@ var _ = Describe("Broker", func() {
 		})
 
 		It("Provides a catalog with correct plans", func() {
-			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, logger)
+			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
 			serviceCatalog, err := serviceBroker.Services(nil)
 			Expect(err).To(BeNil())
 
@@ -222,10 +232,110 @@ var _ = Describe("Broker", func() {
 			}
 		})
 
+		It("publishes service metadata from service.yaml", func() {
+			shareable := true
+			planUpdateable := false
+			spacebearsChart.Service = my_helm.Service{
+				DisplayName:         "Space Bears",
+				LongDescription:     "Bears, in space",
+				ProviderDisplayName: "Example Corp",
+				SupportUrl:          "https://support.example.com",
+				Tags:                []string{"bears"},
+				Requires:            []string{"volume_mount"},
+				PlanUpdateable:      &planUpdateable,
+				Shareable:           &shareable,
+			}
+			plan := spacebearsChart.Plans["small"]
+			plan.DisplayName = "Small"
+			plan.Costs = []my_helm.PlanCost{{Amount: map[string]float64{"usd": 9.99}, Unit: "MONTHLY"}}
+			spacebearsChart.Plans["small"] = plan
+
+			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
+			serviceCatalog, err := serviceBroker.Services(nil)
+			Expect(err).To(BeNil())
+
+			var service brokerapi.Service
+			for _, s := range serviceCatalog {
+				if s.Name == "spacebears" {
+					service = s
+				}
+			}
+			Expect(service.Bindable).To(BeTrue())
+			Expect(service.PlanUpdatable).To(BeFalse())
+			Expect(service.Tags).To(Equal([]string{"bears"}))
+			Expect(service.Requires).To(Equal([]brokerapi.RequiredPermission{brokerapi.PermissionVolumeMount}))
+			Expect(service.Metadata.DisplayName).To(Equal("Space Bears"))
+			Expect(service.Metadata.LongDescription).To(Equal("Bears, in space"))
+			Expect(service.Metadata.ProviderDisplayName).To(Equal("Example Corp"))
+			Expect(service.Metadata.SupportUrl).To(Equal("https://support.example.com"))
+			Expect(*service.Metadata.Shareable).To(BeTrue())
+
+			for _, p := range service.Plans {
+				if p.Name == "small" {
+					Expect(p.Metadata.DisplayName).To(Equal("Small"))
+					Expect(p.Metadata.Costs).To(Equal([]brokerapi.ServicePlanCost{
+						{Amount: map[string]float64{"usd": 9.99}, Unit: "MONTHLY"},
+					}))
+				} else {
+					Expect(p.Metadata.DisplayName).To(Equal(p.Name))
+				}
+			}
+		})
+
+		It("publishes plan schema for create and update", func() {
+			schema := map[string]interface{}{"type": "object"}
+			plan := mysqlChart.Plans["small"]
+			plan.Schema = schema
+			mysqlChart.Plans["small"] = plan
+
+			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
+			serviceCatalog, err := serviceBroker.Services(nil)
+			Expect(err).To(BeNil())
+
+			for _, service := range serviceCatalog {
+				for _, plan := range service.Plans {
+					if plan.Name == "tiny" {
+						Expect(plan.Schemas.Instance.Create.Parameters).To(Equal(schema))
+						Expect(plan.Schemas.Instance.Update.Parameters).To(Equal(schema))
+					} else {
+						Expect(plan.Schemas).To(BeNil())
+					}
+				}
+			}
+		})
+
+		It("publishes maintenance info per plan", func() {
+			spacebearsChart.Metadata.Version = "0.2.0"
+			small := spacebearsChart.Plans["small"]
+			small.Values = []byte("count: 1")
+			spacebearsChart.Plans["small"] = small
+			medium := spacebearsChart.Plans["medium"]
+			medium.Values = []byte("count: 2")
+			spacebearsChart.Plans["medium"] = medium
+
+			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
+			serviceCatalog, err := serviceBroker.Services(nil)
+			Expect(err).To(BeNil())
+
+			versions := map[string]string{}
+			for _, service := range serviceCatalog {
+				for _, plan := range service.Plans {
+					if service.Name == "spacebears" {
+						Expect(plan.MaintenanceInfo).NotTo(BeNil())
+						Expect(plan.MaintenanceInfo.Version).To(MatchRegexp(`^0\.2\.0\+[0-9a-f]{8}$`))
+						versions[plan.Name] = plan.MaintenanceInfo.Version
+					} else {
+						Expect(plan.MaintenanceInfo).To(BeNil())
+					}
+				}
+			}
+			Expect(versions["small"]).NotTo(Equal(versions["medium"]))
+		})
+
 		It("Returns error when problem with catalog", func() {
 			fakeRepo.GetChartsReturns(nil, errors.New("issue with catalog"))
 
-			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, logger)
+			serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
 			_, err := serviceBroker.Services(nil)
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(ContainSubstring("issue with catalog"))
@@ -241,7 +351,7 @@ var _ = Describe("Broker", func() {
 				ServiceID: spacebearsServiceGUID,
 			}
 
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 			Expect(fakeClusterFactory.DefaultClusterCallCount()).To(Equal(0))
 			Expect(fakeClusterFactory.GetClusterCallCount()).To(Equal(0))
 
@@ -260,7 +370,50 @@ var _ = Describe("Broker", func() {
 
 			Expect(err).To(BeNil())
 			Expect(resp.IsAsync).To(BeTrue())
-			Expect(resp.OperationData).To(Equal("provision"))
+			Expect(resp.OperationData).To(HavePrefix("provision:1:"))
+		})
+
+		It("rejects parameters not matching plan schema", func() {
+			plan := spacebearsChart.Plans["small"]
+			plan.Schema = map[string]interface{}{
+				"properties": map[string]interface{}{
+					"replicas": map[string]interface{}{"type": "integer"},
+				},
+			}
+			spacebearsChart.Plans["small"] = plan
+			details.PlanID = spacebearsServiceGUID + "-small"
+			details.RawParameters = json.RawMessage(`{"replicas":"three"}`)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			failure, ok := err.(*brokerapi.FailureResponse)
+			Expect(ok).To(BeTrue())
+			Expect(failure.ValidatedStatusCode(nil)).To(Equal(http.StatusBadRequest))
+			Expect(err.Error()).To(ContainSubstring("replicas"))
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("rejects missing parameters required by plan schema", func() {
+			plan := spacebearsChart.Plans["small"]
+			plan.Schema = map[string]interface{}{
+				"required": []interface{}{"replicas"},
+				"properties": map[string]interface{}{
+					"replicas": map[string]interface{}{"type": "integer"},
+				},
+			}
+			spacebearsChart.Plans["small"] = plan
+			details.PlanID = spacebearsServiceGUID + "-small"
+			details.RawParameters = nil
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			failure, ok := err.(*brokerapi.FailureResponse)
+			Expect(ok).To(BeTrue())
+			Expect(failure.ValidatedStatusCode(nil)).To(Equal(http.StatusBadRequest))
+			Expect(err.Error()).To(ContainSubstring("replicas"))
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
 		})
 
 		It("uses the default cluster", func() {
@@ -304,7 +457,7 @@ var _ = Describe("Broker", func() {
 					PlanID:    spacebearsServiceGUID + "-small",
 				}
 
-				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 			})
 
 			It("uses cluster configured in plan to build helm client", func() {
@@ -334,7 +487,7 @@ var _ = Describe("Broker", func() {
 		Context("registry secrets", func() {
 			It("doesn't mess with secrets when not configured", func() {
 				config = &my_config.Config{RegistryConfig: &my_config.RegistryConfig{}, HelmTLSConfig: &my_config.HelmTLSConfig{}}
-				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 
 				_, err := broker.Provision(nil, "my-instance-guid", details, true)
 
@@ -356,7 +509,7 @@ var _ = Describe("Broker", func() {
 				Expect(err).To(BeNil())
 
 				Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(1))
-				_, namespace, releaseName, chart, plan, opts, _ := fakeHelmClient.InstallChartArgsForCall(0)
+				_, _, namespace, releaseName, chart, plan, opts, _ := fakeHelmClient.InstallChartArgsForCall(0)
 				Expect(chart).To(Equal(spacebearsChart))
 				Expect(namespace.Name).To(Equal("kibosh-my-instance-guid"))
 				Expect(releaseName).To(Equal("k-5h5kntfw"))
@@ -364,6 +517,45 @@ var _ = Describe("Broker", func() {
 				Expect(opts).To(BeNil())
 			})
 
+			It("passes the network isolation switch to the install", func() {
+				config.NetworkIsolation = true
+
+				_, err := broker.Provision(nil, "my-instance-guid", brokerapi.ProvisionDetails{
+					ServiceID: spacebearsServiceGUID,
+					PlanID:    spacebearsServiceGUID + "-small",
+				}, true)
+
+				Expect(err).To(BeNil())
+				_, networkIsolation, _, _, _, _, _, _ := fakeHelmClient.InstallChartArgsForCall(0)
+				Expect(networkIsolation).To(BeTrue())
+			})
+
+			It("records the maintenance version on the namespace", func() {
+				spacebearsChart.Metadata.Version = "0.2.0"
+
+				_, err := broker.Provision(nil, "my-instance-guid", brokerapi.ProvisionDetails{
+					ServiceID: spacebearsServiceGUID,
+					PlanID:    spacebearsServiceGUID + "-small",
+				}, true)
+
+				Expect(err).To(BeNil())
+				_, _, namespace, _, _, _, _, _ := fakeHelmClient.InstallChartArgsForCall(0)
+				Expect(namespace.Annotations["maintenanceVersion"]).To(HavePrefix("0.2.0+"))
+			})
+
+			It("rejects maintenance info not in the catalog", func() {
+				spacebearsChart.Metadata.Version = "0.2.0"
+
+				_, err := broker.Provision(nil, "my-instance-guid", brokerapi.ProvisionDetails{
+					ServiceID:       spacebearsServiceGUID,
+					PlanID:          spacebearsServiceGUID + "-small",
+					MaintenanceInfo: &brokerapi.MaintenanceInfo{Version: "0.1.0"},
+				}, true)
+
+				Expect(err).To(Equal(brokerapi.ErrMaintenanceInfoConflict))
+				Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+			})
+
 			It("returns error on helm chart creation failure", func() {
 				errorMessage := "no helm for you"
 				fakeHelmClient.InstallChartReturns(nil, errors.New(errorMessage))
@@ -383,7 +575,7 @@ var _ = Describe("Broker", func() {
 				Expect(err).To(BeNil())
 
 				Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(1))
-				_, _, _, chart, _, _, _ := fakeHelmClient.InstallChartArgsForCall(0)
+				_, _, _, _, chart, _, _, _ := fakeHelmClient.InstallChartArgsForCall(0)
 				Expect(chart).To(Equal(mysqlChart))
 			})
 
@@ -400,7 +592,7 @@ var _ = Describe("Broker", func() {
 				Expect(err).To(BeNil())
 
 				Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(1))
-				_, namespace, releaseName, chart, plan, opts, _ := fakeHelmClient.InstallChartArgsForCall(0)
+				_, _, namespace, releaseName, chart, plan, opts, _ := fakeHelmClient.InstallChartArgsForCall(0)
 				Expect(chart).To(Equal(spacebearsChart))
 				Expect(namespace.Name).To(Equal("kibosh-my-instance-guid"))
 				Expect(releaseName).To(Equal("k-5h5kntfw"))
@@ -410,11 +602,110 @@ var _ = Describe("Broker", func() {
 		})
 	})
 
+	Context("get instance", func() {
+		var broker *PksServiceBroker
+
+		BeforeEach(func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+
+			spacebearsChart.TransformedValues = []byte("foo: bar\nsize: 1\n")
+			plan := spacebearsChart.Plans["small"]
+			plan.Values = []byte("size: 2\n")
+			spacebearsChart.Plans["small"] = plan
+
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name: "kibosh-my-instance-guid",
+					Labels: map[string]string{
+						"serviceID": spacebearsServiceGUID,
+						"planID":    spacebearsServiceGUID + "-small",
+					},
+				},
+			}, nil)
+			fakeCluster.ListIngressesReturns(&v1_beta1.IngressList{}, nil)
+			fakeHelmClient.RenderTemplatedValuesStub = func(options chartutil.ReleaseOptions, values []byte, chart hapi_chart.Chart) ([]byte, error) {
+				return values, nil
+			}
+			fakeHelmClient.ReleaseContentReturns(&hapi_services.GetReleaseContentResponse{
+				Release: &hapi_release.Release{
+					Config: &hapi_chart.Config{Raw: "foo: bar\nsize: 2\nuser: value\n"},
+				},
+			}, nil)
+		})
+
+		It("returns service and plan from namespace labels", func() {
+			instance, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).To(BeNil())
+			Expect(instance.ServiceID).To(Equal(spacebearsServiceGUID))
+			Expect(instance.PlanID).To(Equal(spacebearsServiceGUID + "-small"))
+
+			name, _ := fakeCluster.GetNamespaceArgsForCall(0)
+			Expect(name).To(Equal("kibosh-my-instance-guid"))
+		})
+
+		It("returns only user supplied parameters", func() {
+			instance, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).To(BeNil())
+			Expect(instance.Parameters).To(Equal(map[string]interface{}{
+				"user": "value",
+			}))
+
+			releaseName, _ := fakeHelmClient.ReleaseContentArgsForCall(0)
+			Expect(releaseName).To(Equal("k-5h5kntfw"))
+		})
+
+		It("returns dashboard url from ingress", func() {
+			spacebearsChart.Service.Dashboard = &my_helm.Dashboard{Ingress: "dashboard"}
+			fakeCluster.ListIngressesReturns(&v1_beta1.IngressList{
+				Items: []v1_beta1.Ingress{
+					{
+						ObjectMeta: meta_v1.ObjectMeta{Name: "dashboard"},
+						Spec: v1_beta1.IngressSpec{
+							TLS: []v1_beta1.IngressTLS{
+								{Hosts: []string{"dashboard.example.com"}},
+							},
+							Rules: []v1_beta1.IngressRule{
+								{Host: "dashboard.example.com"},
+							},
+						},
+					},
+				},
+			}, nil)
+
+			instance, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).To(BeNil())
+			Expect(instance.DashboardURL).To(Equal("https://dashboard.example.com"))
+		})
+
+		It("returns not found when namespace doesn't exist", func() {
+			fakeCluster.GetNamespaceReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("namespaces"), "kibosh-my-instance-guid"))
+
+			_, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).NotTo(BeNil())
+			failure, ok := err.(*brokerapi.FailureResponse)
+			Expect(ok).To(BeTrue())
+			Expect(failure.ValidatedStatusCode(nil)).To(Equal(404))
+		})
+
+		It("elevates error from helm", func() {
+			fakeHelmClient.ReleaseContentReturns(nil, errors.New("no tiller"))
+
+			_, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("no tiller"))
+		})
+	})
+
 	Context("last operation", func() {
 		var broker *PksServiceBroker
 
 		BeforeEach(func() {
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 
 			serviceList := api_v1.ServiceList{
 				Items: []api_v1.Service{
@@ -585,9 +876,7 @@ var _ = Describe("Broker", func() {
 			Expect(resp.State).To(Equal(brokerapi.Failed))
 		})
 
-		It("no error returned when service list is empty", func() {
-			fakeCluster.ListServicesReturns(&api_v1.ServiceList{}, nil)
-
+		It("reports version transition of upgrade", func() {
 			fakeHelmClient.ReleaseStatusReturns(&hapi_services.GetReleaseStatusResponse{
 				Info: &hapi_release.Info{
 					Status: &hapi_release.Status{
@@ -595,135 +884,510 @@ var _ = Describe("Broker", func() {
 					},
 				},
 			}, nil)
+			fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
 
-			_, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "provision"})
+			resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "upgrade:0.1.0+aaaaaaaa:0.2.0+bbbbbbbb"})
 
 			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+			Expect(resp.Description).To(Equal("upgraded from 0.1.0+aaaaaaaa to 0.2.0+bbbbbbbb"))
 		})
 
-		It("targets the plan cluster when present", func() {
+		It("returns upgrade in progress", func() {
 			fakeHelmClient.ReleaseStatusReturns(&hapi_services.GetReleaseStatusResponse{
 				Info: &hapi_release.Info{
 					Status: &hapi_release.Status{
-						Code: hapi_release.Status_DEPLOYED,
+						Code: hapi_release.Status_PENDING_UPGRADE,
 					},
 				},
 			}, nil)
 
-			k8sConfig := &k8sAPI.Config{
-				Clusters:       map[string]*k8sAPI.Cluster{"cluster2": {}},
-				CurrentContext: "context2",
-				Contexts:       map[string]*k8sAPI.Context{"context2": {}},
-				AuthInfos:      map[string]*k8sAPI.AuthInfo{"auth2": {}},
-			}
+			resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "upgrade::0.2.0+bbbbbbbb"})
 
-			plan := spacebearsChart.Plans["small"]
-			plan.ClusterConfig = k8sConfig
-			spacebearsChart.Plans["small"] = plan
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+			Expect(resp.Description).To(Equal("upgrade to 0.2.0+bbbbbbbb in progress"))
+		})
 
-			fakeClusterFactory.GetClusterFromK8sConfigReturns(&fakeCluster, nil)
+		Context("operation revision", func() {
+			var history *hapi_services.GetHistoryResponse
 
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			BeforeEach(func() {
+				history = &hapi_services.GetHistoryResponse{
+					Releases: []*hapi_release.Release{
+						{
+							Version: 2,
+							Info:    &hapi_release.Info{Status: &hapi_release.Status{Code: hapi_release.Status_DEPLOYED}},
+						},
+					},
+				}
+				fakeHelmClient.ReleaseHistoryReturns(history, nil)
+				fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+			})
 
-			details := brokerapi.PollDetails{
-				OperationData: "provision",
-				ServiceID:     spacebearsServiceGUID,
-				PlanID:        spacebearsServiceGUID + "-small",
-			}
+			It("is in progress until tiller reaches the revision", func() {
+				operationData := fmt.Sprintf("update:3:%d", time.Now().Unix())
 
-			_, err := broker.LastOperation(nil, "my-instance-guid", details)
+				resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
 
-			Expect(err).To(BeNil())
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.InProgress))
+				Expect(fakeHelmClient.ReleaseStatusCallCount()).To(Equal(0))
+				releaseName, _ := fakeHelmClient.ReleaseHistoryArgsForCall(0)
+				Expect(releaseName).To(Equal("k-5h5kntfw"))
+			})
 
-			Expect(fakeClusterFactory.DefaultClusterCallCount()).To(Equal(0))
-			Expect(fakeClusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(1))
-			c := fakeClusterFactory.GetClusterFromK8sConfigArgsForCall(0)
-			Expect(c.CurrentContext).To(Equal("context2"))
-		})
-	})
+			It("fails when tiller never reaches the revision", func() {
+				operationData := fmt.Sprintf("update:3:%d", time.Now().Add(-time.Hour).Unix())
 
-	Context("bind", func() {
-		var broker *PksServiceBroker
+				resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
 
-		BeforeEach(func() {
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
-		})
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Failed))
+				Expect(resp.Description).To(ContainSubstring("revision 3"))
+			})
 
-		It("bind returns cluster secrets", func() {
-			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
-				"secrets":  {{"password": "foo"}},
-				"services": {{"myservice": "service-stuff"}},
-			}, nil)
+			It("reports the status of the revision", func() {
+				history.Releases = append([]*hapi_release.Release{{
+					Version: 3,
+					Info:    &hapi_release.Info{Status: &hapi_release.Status{Code: hapi_release.Status_FAILED}},
+				}}, history.Releases...)
+				operationData := fmt.Sprintf("update:3:%d", time.Now().Unix())
 
-			binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{ServiceID: mysqlServiceID}, false)
+				resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
 
-			Expect(err).To(BeNil())
-			Expect(binding).NotTo(BeNil())
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Failed))
+			})
 
-			creds := binding.Credentials
-			secrets := creds.(map[string]interface{})["secrets"]
-			secretsJson, err := json.Marshal(secrets)
-			Expect(string(secretsJson)).To(Equal(`[{"password":"foo"}]`))
-		})
+			It("succeeds when the revision has since been superseded", func() {
+				history.Releases[0].Info.Status.Code = hapi_release.Status_SUPERSEDED
+				operationData := fmt.Sprintf("upgrade:2:%d:0.1.0+aaaaaaaa:0.2.0+bbbbbbbb", time.Now().Unix())
 
-		It("when plan has a specific cluster, fetch binding from that", func() {
-			k8sConfig := &k8sAPI.Config{
-				Clusters:       map[string]*k8sAPI.Cluster{"cluster2": {}},
-				CurrentContext: "context2",
-				Contexts:       map[string]*k8sAPI.Context{"context2": {}},
-				AuthInfos:      map[string]*k8sAPI.AuthInfo{"auth2": {}},
-			}
+				resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
 
-			plan := spacebearsChart.Plans["small"]
-			plan.ClusterConfig = k8sConfig
-			spacebearsChart.Plans["small"] = plan
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Succeeded))
+				Expect(resp.Description).To(Equal("upgraded from 0.1.0+aaaaaaaa to 0.2.0+bbbbbbbb"))
+			})
 
-			fakeClusterFactory.GetClusterFromK8sConfigReturns(&fakeCluster, nil)
+			It("elevates error from helm history", func() {
+				fakeHelmClient.ReleaseHistoryReturns(nil, errors.New("tiller unavailable"))
 
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+				_, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "provision:1:0"})
 
-			binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{
-				ServiceID: spacebearsServiceGUID,
-				PlanID:    spacebearsServiceGUID + "-small",
-			}, false)
+				Expect(err).NotTo(BeNil())
+			})
 
-			Expect(err).To(BeNil())
-			Expect(binding).NotTo(BeNil())
+			Context("migration", func() {
+				BeforeEach(func() {
+					fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+						ObjectMeta: meta_v1.ObjectMeta{
+							Name:   "kibosh-my-instance-guid",
+							Labels: map[string]string{"planID": spacebearsServiceGUID + "-small"},
+							Annotations: map[string]string{
+								"maintenanceVersion":          "0.1.0+aaaaaaaa",
+								"kibosh.io/pending-migration": `{"revision":2,"planID":"` + spacebearsServiceGUID + `-medium","chartVersion":"0.2.0","maintenanceVersion":"0.2.0+bbbbbbbb"}`,
+							},
+						},
+					}, nil)
+				})
 
-			Expect(fakeClusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(1))
-			c := fakeClusterFactory.GetClusterFromK8sConfigArgsForCall(0)
-			Expect(c.CurrentContext).To(Equal("context2"))
+				It("records the new plan and maintenance version once the revision is deployed", func() {
+					operationData := fmt.Sprintf("upgrade:2:%d:0.1.0+aaaaaaaa:0.2.0+bbbbbbbb", time.Now().Unix())
+
+					resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
+
+					Expect(err).To(BeNil())
+					Expect(resp.State).To(Equal(brokerapi.Succeeded))
+					Expect(fakeCluster.UpdateNamespaceCallCount()).To(Equal(1))
+					namespace := fakeCluster.UpdateNamespaceArgsForCall(0)
+					Expect(namespace.Labels["planID"]).To(Equal(spacebearsServiceGUID + "-medium"))
+					Expect(namespace.Annotations["maintenanceVersion"]).To(Equal("0.2.0+bbbbbbbb"))
+					Expect(namespace.Annotations).NotTo(HaveKey("kibosh.io/pending-migration"))
+				})
+
+				It("keeps the previous plan and maintenance version when the revision failed", func() {
+					history.Releases[0].Info.Status.Code = hapi_release.Status_FAILED
+					operationData := fmt.Sprintf("upgrade:2:%d:0.1.0+aaaaaaaa:0.2.0+bbbbbbbb", time.Now().Unix())
+
+					resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
+
+					Expect(err).To(BeNil())
+					Expect(resp.State).To(Equal(brokerapi.Failed))
+					Expect(fakeCluster.UpdateNamespaceCallCount()).To(Equal(0))
+				})
+
+				It("leaves migrations made by other revisions pending", func() {
+					history.Releases[0].Version = 3
+					operationData := fmt.Sprintf("update:3:%d", time.Now().Unix())
+
+					resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: operationData})
+
+					Expect(err).To(BeNil())
+					Expect(resp.State).To(Equal(brokerapi.Succeeded))
+					Expect(fakeCluster.UpdateNamespaceCallCount()).To(Equal(0))
+				})
+			})
 		})
 
-		Context("bind templates", func() {
-			It("transforms successfully when template is present", func() {
-				mysqlChart.BindTemplate = `{hostname: $.services[0].status.loadBalancer.ingress[0].ip}`
+		Context("rollback", func() {
+			var history *hapi_services.GetHistoryResponse
+			var pollDetails brokerapi.PollDetails
 
-				secretsAndServices := map[string][]map[string]interface{}{
-					"services": {
+			BeforeEach(func() {
+				plan := spacebearsChart.Plans["small"]
+				plan.RollbackOnFailure = true
+				spacebearsChart.Plans["small"] = plan
+
+				history = &hapi_services.GetHistoryResponse{
+					Releases: []*hapi_release.Release{
 						{
-							"status": map[string]interface{}{
-								"loadBalancer": map[string]interface{}{
-									"ingress": []map[string]interface{}{
-										{
-											"ip": "127.0.0.1",
-										},
-									},
-								},
-							},
+							Version: 3,
+							Info:    &hapi_release.Info{Status: &hapi_release.Status{Code: hapi_release.Status_FAILED}},
+						},
+						{
+							Version: 2,
+							Info:    &hapi_release.Info{Status: &hapi_release.Status{Code: hapi_release.Status_DEPLOYED}},
+						},
+						{
+							Version: 1,
+							Info:    &hapi_release.Info{Status: &hapi_release.Status{Code: hapi_release.Status_SUPERSEDED}},
 						},
 					},
 				}
+				fakeHelmClient.ReleaseHistoryReturns(history, nil)
+				pollDetails = brokerapi.PollDetails{
+					ServiceID:     spacebearsServiceGUID,
+					PlanID:        spacebearsServiceGUID + "-small",
+					OperationData: fmt.Sprintf("update:3:%d", time.Now().Unix()),
+				}
+			})
 
-				fakeCluster.GetSecretsAndServicesReturns(secretsAndServices, nil)
-				binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{
-					ServiceID: mysqlServiceID,
-				}, false)
+			It("rolls back a failed update to the last deployed revision", func() {
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
 
 				Expect(err).To(BeNil())
-				Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(1))
+				Expect(resp.State).To(Equal(brokerapi.InProgress))
+				Expect(resp.Description).To(ContainSubstring("rolling back to revision 2"))
+				Expect(fakeHelmClient.RollbackReleaseCallCount()).To(Equal(1))
+				releaseName, opts := fakeHelmClient.RollbackReleaseArgsForCall(0)
+				Expect(releaseName).To(Equal("k-5h5kntfw"))
+				Expect(opts).To(HaveLen(2))
+			})
 
-				Expect(binding).NotTo(BeNil())
+			It("doesn't roll back unless the plan opted in", func() {
+				plan := spacebearsChart.Plans["small"]
+				plan.RollbackOnFailure = false
+				spacebearsChart.Plans["small"] = plan
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Failed))
+				Expect(fakeHelmClient.RollbackReleaseCallCount()).To(Equal(0))
+			})
+
+			It("rolls back when the update doesn't become ready", func() {
+				history.Releases[0].Info.Status.Code = hapi_release.Status_DEPLOYED
+				message := "waiting on pods"
+				fakeHelmClient.ResourceReadinessReturns(&message, hapi_release.Status_PENDING_INSTALL, nil)
+				pollDetails.OperationData = fmt.Sprintf("update:3:%d", time.Now().Add(-time.Hour).Unix())
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.InProgress))
+				Expect(resp.Description).To(ContainSubstring("timed out waiting for readiness"))
+				Expect(fakeHelmClient.RollbackReleaseCallCount()).To(Equal(1))
+			})
+
+			It("decides on rolling back by the plan the instance was on before the update", func() {
+				pollDetails.PlanID = spacebearsServiceGUID + "-medium"
+				fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name: "kibosh-my-instance-guid",
+						Labels: map[string]string{
+							"serviceID": spacebearsServiceGUID,
+							"planID":    spacebearsServiceGUID + "-small",
+						},
+					},
+				}, nil)
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.InProgress))
+				Expect(fakeHelmClient.RollbackReleaseCallCount()).To(Equal(1))
+			})
+
+			It("restores the previous plan's limits when rolling back a plan change", func() {
+				plan := spacebearsChart.Plans["small"]
+				plan.ResourceQuota = &api_v1.ResourceQuotaSpec{
+					Hard: api_v1.ResourceList{api_v1.ResourcePods: resource.MustParse("5")},
+				}
+				spacebearsChart.Plans["small"] = plan
+				fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name: "kibosh-my-instance-guid",
+						Labels: map[string]string{
+							"serviceID": spacebearsServiceGUID,
+							"planID":    spacebearsServiceGUID + "-small",
+						},
+						Annotations: map[string]string{
+							"maintenanceVersion":          "0.1.0+aaaaaaaa",
+							"kibosh.io/pending-migration": `{"revision":3,"planID":"` + spacebearsServiceGUID + `-medium","previousPlanID":"` + spacebearsServiceGUID + `-small"}`,
+						},
+					},
+				}, nil)
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.InProgress))
+				Expect(fakeCluster.CreateOrUpdateResourceQuotaCallCount()).To(Equal(1))
+				_, quota := fakeCluster.CreateOrUpdateResourceQuotaArgsForCall(0)
+				Expect(quota.Spec).To(Equal(*plan.ResourceQuota))
+
+				Expect(fakeCluster.UpdateNamespaceCallCount()).To(Equal(1))
+				namespace := fakeCluster.UpdateNamespaceArgsForCall(0)
+				Expect(namespace.Labels["planID"]).To(Equal(spacebearsServiceGUID + "-small"))
+				Expect(namespace.Annotations["maintenanceVersion"]).To(Equal("0.1.0+aaaaaaaa"))
+				Expect(namespace.Annotations).NotTo(HaveKey("kibosh.io/pending-migration"))
+			})
+
+			It("reports failure when rolling back fails", func() {
+				fakeHelmClient.RollbackReleaseReturns(nil, errors.New("tiller unavailable"))
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Failed))
+				Expect(resp.Description).To(ContainSubstring("tiller unavailable"))
+			})
+
+			It("reports the update failed once rolled back", func() {
+				history.Releases[0].Info.Status.Code = hapi_release.Status_SUPERSEDED
+				history.Releases = append([]*hapi_release.Release{{
+					Version: 4,
+					Info: &hapi_release.Info{
+						Status:      &hapi_release.Status{Code: hapi_release.Status_DEPLOYED},
+						Description: "Rollback to 2 after revision 3 failed FAILED",
+					},
+				}}, history.Releases...)
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Failed))
+				Expect(resp.Description).To(Equal("update failed and the instance was rolled back to revision 2"))
+				Expect(fakeHelmClient.RollbackReleaseCallCount()).To(Equal(0))
+			})
+		})
+
+		It("no error returned when service list is empty", func() {
+			fakeCluster.ListServicesReturns(&api_v1.ServiceList{}, nil)
+
+			fakeHelmClient.ReleaseStatusReturns(&hapi_services.GetReleaseStatusResponse{
+				Info: &hapi_release.Info{
+					Status: &hapi_release.Status{
+						Code: hapi_release.Status_DEPLOYED,
+					},
+				},
+			}, nil)
+
+			_, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "provision"})
+
+			Expect(err).To(BeNil())
+		})
+
+		It("targets the plan cluster when present", func() {
+			fakeHelmClient.ReleaseStatusReturns(&hapi_services.GetReleaseStatusResponse{
+				Info: &hapi_release.Info{
+					Status: &hapi_release.Status{
+						Code: hapi_release.Status_DEPLOYED,
+					},
+				},
+			}, nil)
+
+			k8sConfig := &k8sAPI.Config{
+				Clusters:       map[string]*k8sAPI.Cluster{"cluster2": {}},
+				CurrentContext: "context2",
+				Contexts:       map[string]*k8sAPI.Context{"context2": {}},
+				AuthInfos:      map[string]*k8sAPI.AuthInfo{"auth2": {}},
+			}
+
+			plan := spacebearsChart.Plans["small"]
+			plan.ClusterConfig = k8sConfig
+			spacebearsChart.Plans["small"] = plan
+
+			fakeClusterFactory.GetClusterFromK8sConfigReturns(&fakeCluster, nil)
+
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+
+			details := brokerapi.PollDetails{
+				OperationData: "provision",
+				ServiceID:     spacebearsServiceGUID,
+				PlanID:        spacebearsServiceGUID + "-small",
+			}
+
+			_, err := broker.LastOperation(nil, "my-instance-guid", details)
+
+			Expect(err).To(BeNil())
+
+			Expect(fakeClusterFactory.DefaultClusterCallCount()).To(Equal(0))
+			Expect(fakeClusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(1))
+			c := fakeClusterFactory.GetClusterFromK8sConfigArgsForCall(0)
+			Expect(c.CurrentContext).To(Equal("context2"))
+		})
+	})
+
+	Context("bind", func() {
+		var broker *PksServiceBroker
+
+		BeforeEach(func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+		})
+
+		It("bind returns cluster secrets", func() {
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"secrets":  {{"password": "foo"}},
+				"services": {{"myservice": "service-stuff"}},
+			}, nil)
+
+			binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{ServiceID: mysqlServiceID}, false)
+
+			Expect(err).To(BeNil())
+			Expect(binding).NotTo(BeNil())
+
+			creds := binding.Credentials
+			secrets := creds.(map[string]interface{})["secrets"]
+			secretsJson, err := json.Marshal(secrets)
+			Expect(string(secretsJson)).To(Equal(`[{"password":"foo"}]`))
+		})
+
+		It("records binding details", func() {
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{}, nil)
+
+			_, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{
+				ServiceID: mysqlServiceID,
+				PlanID:    mysqlServiceID + "-tiny",
+				AppGUID:   "my-app-guid",
+			}, false)
+
+			Expect(err).To(BeNil())
+			Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(1))
+			namespace, configMap := fakeCluster.CreateOrUpdateConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh-my-instance-id"))
+			Expect(configMap.Name).To(Equal("kibosh-binding-my-binding-id"))
+			Expect(configMap.Data).To(Equal(map[string]string{
+				"serviceID": mysqlServiceID,
+				"planID":    mysqlServiceID + "-tiny",
+				"appGUID":   "my-app-guid",
+			}))
+		})
+
+		Context("async", func() {
+			It("returns async binding when instance isn't ready", func() {
+				message := "service not ready"
+				fakeHelmClient.ResourceReadinessReturns(&message, hapi_release.Status_PENDING_INSTALL, nil)
+
+				binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{
+					ServiceID: mysqlServiceID,
+					AppGUID:   "my-app-guid",
+				}, true)
+
+				Expect(err).To(BeNil())
+				Expect(binding.IsAsync).To(BeTrue())
+				Expect(binding.Credentials).To(BeNil())
+				Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(0))
+				Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(1))
+
+				namespace, _ := fakeHelmClient.ResourceReadinessArgsForCall(0)
+				Expect(namespace).To(Equal("kibosh-my-instance-id"))
+			})
+
+			It("binds synchronously when instance is ready", func() {
+				fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+				fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+					"secrets": {{"password": "foo"}},
+				}, nil)
+
+				binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{ServiceID: mysqlServiceID}, true)
+
+				Expect(err).To(BeNil())
+				Expect(binding.IsAsync).To(BeFalse())
+				Expect(binding.Credentials).NotTo(BeNil())
+			})
+
+			It("elevates readiness error", func() {
+				fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_UNKNOWN, errors.New("no connection"))
+
+				_, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{ServiceID: mysqlServiceID}, true)
+
+				Expect(err).NotTo(BeNil())
+				Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(0))
+			})
+		})
+
+		It("when plan has a specific cluster, fetch binding from that", func() {
+			k8sConfig := &k8sAPI.Config{
+				Clusters:       map[string]*k8sAPI.Cluster{"cluster2": {}},
+				CurrentContext: "context2",
+				Contexts:       map[string]*k8sAPI.Context{"context2": {}},
+				AuthInfos:      map[string]*k8sAPI.AuthInfo{"auth2": {}},
+			}
+
+			plan := spacebearsChart.Plans["small"]
+			plan.ClusterConfig = k8sConfig
+			spacebearsChart.Plans["small"] = plan
+
+			fakeClusterFactory.GetClusterFromK8sConfigReturns(&fakeCluster, nil)
+
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+
+			binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{
+				ServiceID: spacebearsServiceGUID,
+				PlanID:    spacebearsServiceGUID + "-small",
+			}, false)
+
+			Expect(err).To(BeNil())
+			Expect(binding).NotTo(BeNil())
+
+			Expect(fakeClusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(1))
+			c := fakeClusterFactory.GetClusterFromK8sConfigArgsForCall(0)
+			Expect(c.CurrentContext).To(Equal("context2"))
+		})
+
+		Context("bind templates", func() {
+			It("transforms successfully when template is present", func() {
+				mysqlChart.BindTemplate = `{hostname: $.services[0].status.loadBalancer.ingress[0].ip}`
+
+				secretsAndServices := map[string][]map[string]interface{}{
+					"services": {
+						{
+							"status": map[string]interface{}{
+								"loadBalancer": map[string]interface{}{
+									"ingress": []map[string]interface{}{
+										{
+											"ip": "127.0.0.1",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				fakeCluster.GetSecretsAndServicesReturns(secretsAndServices, nil)
+				binding, err := broker.Bind(nil, "my-instance-id", "my-binding-id", brokerapi.BindDetails{
+					ServiceID: mysqlServiceID,
+				}, false)
+
+				Expect(err).To(BeNil())
+				Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(1))
+
+				Expect(binding).NotTo(BeNil())
 
 				creds := binding.Credentials
 				credsJson, err := json.Marshal(creds.(map[string]interface{}))
@@ -776,7 +1440,7 @@ var _ = Describe("Broker", func() {
 
 		Context("credstore", func() {
 			BeforeEach(func() {
-				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, logger)
+				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, nil, logger)
 			})
 
 			It("bind returns reference to k8s secrets and services", func() {
@@ -884,11 +1548,236 @@ var _ = Describe("Broker", func() {
 		})
 	})
 
+	Context("get binding", func() {
+		var broker *PksServiceBroker
+
+		BeforeEach(func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:   "kibosh-my-instance-id",
+					Labels: map[string]string{"serviceID": mysqlServiceID, "planID": mysqlServiceID + "-tiny"},
+				},
+			}, nil)
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{
+					"serviceID": mysqlServiceID,
+					"appGUID":   "my-app-guid",
+				},
+			}, nil)
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"secrets":  {{"password": "foo"}},
+				"services": {{"myservice": "service-stuff"}},
+			}, nil)
+		})
+
+		It("re-renders credentials for the binding", func() {
+			mysqlChart.BindTemplate = `{password: $.secrets[0].password}`
+
+			binding, err := broker.GetBinding(nil, "my-instance-id", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(binding.Credentials).To(Equal(map[string]interface{}{"password": "foo"}))
+
+			namespace, name, _ := fakeCluster.GetConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh-my-instance-id"))
+			Expect(name).To(Equal("kibosh-binding-my-binding-id"))
+		})
+
+		It("renders bindings made before bindings were recorded for the instance's service", func() {
+			mysqlChart.BindTemplate = `{password: $.secrets[0].password}`
+			fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-binding-my-binding-id"))
+
+			binding, err := broker.GetBinding(nil, "my-instance-id", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(binding.Credentials).To(Equal(map[string]interface{}{"password": "foo"}))
+		})
+
+		It("returns not found while an async binding is in progress", func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"serviceID": mysqlServiceID, "pending": "true"},
+			}, nil)
+
+			_, err := broker.GetBinding(nil, "my-instance-id", "my-binding-id")
+
+			Expect(err).To(Equal(brokerapi.ErrBindingNotFound))
+			Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(0))
+		})
+
+		Context("credstore", func() {
+			BeforeEach(func() {
+				broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, nil, logger)
+			})
+
+			It("reads the stored credential", func() {
+				binding, err := broker.GetBinding(nil, "my-instance-id", "my-binding-id")
+
+				Expect(err).To(BeNil())
+				Expect(binding.Credentials).To(Equal(map[string]interface{}{
+					"credhub-ref": "/c/kibosh/mysql/my-binding-id/secrets-and-services",
+				}))
+
+				Expect(fakeCredStore.GetCallCount()).To(Equal(1))
+				Expect(fakeCredStore.GetArgsForCall(0)).To(Equal("/c/kibosh/mysql/my-binding-id/secrets-and-services"))
+				Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(0))
+			})
+
+			It("elevates credstore errors", func() {
+				fakeCredStore.GetReturns(nil, errors.New("credential not found"))
+
+				_, err := broker.GetBinding(nil, "my-instance-id", "my-binding-id")
+
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("credential not found"))
+			})
+		})
+	})
+
+	Context("last binding operation", func() {
+		var broker *PksServiceBroker
+		var pollDetails brokerapi.PollDetails
+
+		BeforeEach(func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+			pollDetails = brokerapi.PollDetails{
+				ServiceID:     mysqlServiceID,
+				PlanID:        mysqlServiceID + "-tiny",
+				OperationData: "bind",
+			}
+
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{CreationTimestamp: meta_v1.Now()},
+				Data: map[string]string{
+					"serviceID": mysqlServiceID,
+					"appGUID":   "my-app-guid",
+					"pending":   "true",
+				},
+			}, nil)
+			fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+		})
+
+		It("returns in progress while instance isn't ready", func() {
+			message := "service not ready"
+			fakeHelmClient.ResourceReadinessReturns(&message, hapi_release.Status_PENDING_INSTALL, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+			Expect(resp.Description).To(Equal("service not ready"))
+			Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(0))
+		})
+
+		It("returns in progress while template can't render", func() {
+			mysqlChart.BindTemplate = `{hostname: $.services[0].status.loadBalancer.ingress[0].ip}`
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"services": {{"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}}}},
+			}, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+		})
+
+		It("returns failed when template still can't render long after binding", func() {
+			mysqlChart.BindTemplate = `{hostname: $.services[0].status.loadBalancer.ingress[0].ip}`
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"services": {{"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}}}},
+			}, nil)
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{CreationTimestamp: meta_v1.NewTime(time.Now().Add(-time.Hour))},
+				Data:       map[string]string{"serviceID": mysqlServiceID, "pending": "true"},
+			}, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Failed))
+		})
+
+		It("returns failed with invalid template", func() {
+			mysqlChart.BindTemplate = `asdf`
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{}, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Failed))
+		})
+
+		It("returns succeeded once credentials render", func() {
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"secrets": {{"password": "foo"}},
+			}, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+			Expect(fakeCluster.UpdateConfigMapCallCount()).To(Equal(1))
+			_, binding := fakeCluster.UpdateConfigMapArgsForCall(0)
+			Expect(binding.Data).NotTo(HaveKey("pending"))
+			Expect(binding.Data["appGUID"]).To(Equal("my-app-guid"))
+		})
+
+		It("doesn't store credentials again once the binding is done", func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, nil, logger)
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"serviceID": mysqlServiceID, "appGUID": "my-app-guid"},
+			}, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+			Expect(fakeCredStore.PutCallCount()).To(Equal(0))
+			Expect(fakeCredStore.AddPermissionCallCount()).To(Equal(0))
+			Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(0))
+		})
+
+		It("stores credentials once rendered", func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, nil, logger)
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"secrets": {{"password": "foo"}},
+			}, nil)
+
+			resp, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+			Expect(fakeCredStore.PutCallCount()).To(Equal(1))
+			name, _ := fakeCredStore.PutArgsForCall(0)
+			Expect(name).To(Equal("/c/kibosh/mysql/my-binding-id/secrets-and-services"))
+
+			_, actor, _ := fakeCredStore.AddPermissionArgsForCall(0)
+			Expect(actor).To(Equal("mtls-app:my-app-guid"))
+		})
+
+		It("returns gone for unknown binding", func() {
+			fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-binding-my-binding-id"))
+
+			_, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).To(Equal(brokerapi.ErrBindingDoesNotExist))
+		})
+
+		It("elevates readiness error", func() {
+			fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_UNKNOWN, errors.New("no connection"))
+
+			_, err := broker.LastBindingOperation(nil, "my-instance-id", "my-binding-id", pollDetails)
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
 	Context("delete / deprovision", func() {
 		var broker *PksServiceBroker
 
 		BeforeEach(func() {
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 		})
 
 		It("correctly calls deletion", func() {
@@ -899,7 +1788,7 @@ var _ = Describe("Broker", func() {
 			response, err := broker.Deprovision(nil, "my-instance-guid", details, true)
 			Expect(err).To(BeNil())
 			Expect(response.IsAsync).To(BeTrue())
-			Expect(response.OperationData).To(Equal("deprovision"))
+			Expect(response.OperationData).To(HavePrefix("deprovision:0:"))
 
 			Eventually(func() int {
 				return fakeCluster.DeleteNamespaceCallCount()
@@ -945,7 +1834,7 @@ var _ = Describe("Broker", func() {
 
 			fakeClusterFactory.GetClusterFromK8sConfigReturns(&fakeCluster, nil)
 
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 
 			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
 
@@ -975,7 +1864,7 @@ var _ = Describe("Broker", func() {
 		var broker *PksServiceBroker
 
 		BeforeEach(func() {
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 		})
 
 		It("requires async", func() {
@@ -983,7 +1872,7 @@ var _ = Describe("Broker", func() {
 
 			Expect(err).To(BeNil())
 			Expect(resp.IsAsync).To(BeTrue())
-			Expect(resp.OperationData).To(Equal("update"))
+			Expect(resp.OperationData).To(HavePrefix("update:"))
 		})
 
 		It("responds correctly", func() {
@@ -1001,7 +1890,7 @@ var _ = Describe("Broker", func() {
 
 			Expect(err).To(BeNil())
 			Expect(resp.IsAsync).To(BeTrue())
-			Expect(resp.OperationData).To(Equal("update"))
+			Expect(resp.OperationData).To(HavePrefix("update:1:"))
 			Expect(fakeHelmClient.UpdateChartCallCount()).To(Equal(1))
 
 			Expect(chart).To(Equal(spacebearsChart))
@@ -1012,6 +1901,271 @@ var _ = Describe("Broker", func() {
 			Expect(fakeClusterFactory.GetClusterCallCount()).To(Equal(0))
 		})
 
+		It("expects the revision after the current one", func() {
+			fakeHelmClient.ReleaseHistoryReturns(&hapi_services.GetHistoryResponse{
+				Releases: []*hapi_release.Release{{Version: 4}},
+			}, nil)
+			details := brokerapi.UpdateDetails{
+				PlanID:        "my-plan-id",
+				ServiceID:     spacebearsServiceGUID,
+				RawParameters: json.RawMessage(`{"foo":"bar"}`),
+			}
+
+			resp, err := broker.Update(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(resp.OperationData).To(HavePrefix("update:5:"))
+		})
+
+		Context("maintenance info", func() {
+			var details brokerapi.UpdateDetails
+			var currentVersion string
+
+			BeforeEach(func() {
+				spacebearsChart.Metadata.Version = "0.2.0"
+				spacebearsChart.TransformedValues = []byte("count: 2\n")
+
+				serviceBroker := NewPksServiceBroker(config, nil, nil, nil, nil, fakeRepo, nil, nil, nil, logger)
+				serviceCatalog, err := serviceBroker.Services(nil)
+				Expect(err).To(BeNil())
+				for _, service := range serviceCatalog {
+					for _, plan := range service.Plans {
+						if plan.ID == spacebearsServiceGUID+"-small" {
+							currentVersion = plan.MaintenanceInfo.Version
+						}
+					}
+				}
+
+				details = brokerapi.UpdateDetails{
+					ServiceID: spacebearsServiceGUID,
+					PlanID:    spacebearsServiceGUID + "-small",
+					PreviousValues: brokerapi.PreviousValues{
+						PlanID: spacebearsServiceGUID + "-small",
+					},
+					MaintenanceInfo: &brokerapi.MaintenanceInfo{Version: currentVersion},
+				}
+
+				fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:        "kibosh-my-instance-guid",
+						Annotations: map[string]string{"maintenanceVersion": "0.1.0+aaaaaaaa"},
+					},
+				}, nil)
+				fakeHelmClient.ReleaseContentReturns(&hapi_services.GetReleaseContentResponse{
+					Release: &hapi_release.Release{
+						Chart: &hapi_chart.Chart{
+							Metadata: &hapi_chart.Metadata{Name: "spacebears", Version: "0.1.0"},
+							Values:   &hapi_chart.Config{Raw: "count: 1\n"},
+						},
+						Config: &hapi_chart.Config{Raw: "count: 1\nfoo: bar\n"},
+					},
+				}, nil)
+				fakeHelmClient.RenderTemplatedValuesStub = func(options chartutil.ReleaseOptions, values []byte, chart hapi_chart.Chart) ([]byte, error) {
+					return values, nil
+				}
+			})
+
+			It("upgrades instance to the current chart keeping user parameters", func() {
+				resp, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				Expect(resp.IsAsync).To(BeTrue())
+				Expect(resp.OperationData).To(HaveSuffix(":0.1.0+aaaaaaaa:" + currentVersion))
+
+				Expect(fakeHelmClient.UpgradeChartCallCount()).To(Equal(1))
+				chart, _, releaseName, plan, values := fakeHelmClient.UpgradeChartArgsForCall(0)
+				Expect(chart).To(Equal(spacebearsChart))
+				Expect(releaseName).To(Equal("k-5h5kntfw"))
+				Expect(plan).To(Equal("small"))
+				Expect(string(values)).To(Equal("foo: bar\n"))
+			})
+
+			It("records the new maintenance version as pending until the upgrade succeeds", func() {
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				namespace := fakeCluster.UpdateNamespaceArgsForCall(0)
+				Expect(namespace.Annotations["maintenanceVersion"]).To(Equal("0.1.0+aaaaaaaa"))
+				Expect(namespace.Annotations["kibosh.io/pending-migration"]).To(ContainSubstring(`"maintenanceVersion":"` + currentVersion + `"`))
+			})
+
+			It("does nothing when instance is current", func() {
+				fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:        "kibosh-my-instance-guid",
+						Annotations: map[string]string{"maintenanceVersion": currentVersion},
+					},
+				}, nil)
+
+				resp, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				Expect(resp.OperationData).To(HavePrefix("update:0:"))
+				Expect(fakeHelmClient.UpgradeChartCallCount()).To(Equal(0))
+				Expect(fakeHelmClient.UpdateChartCallCount()).To(Equal(0))
+			})
+
+			It("rejects maintenance info not in the catalog", func() {
+				details.MaintenanceInfo = &brokerapi.MaintenanceInfo{Version: "0.1.0+aaaaaaaa"}
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(Equal(brokerapi.ErrMaintenanceInfoConflict))
+				Expect(fakeHelmClient.UpgradeChartCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("plan change", func() {
+			var details brokerapi.UpdateDetails
+
+			BeforeEach(func() {
+				details = brokerapi.UpdateDetails{
+					ServiceID: spacebearsServiceGUID,
+					PlanID:    spacebearsServiceGUID + "-medium",
+					PreviousValues: brokerapi.PreviousValues{
+						PlanID: spacebearsServiceGUID + "-small",
+					},
+				}
+
+				fakeHelmClient.ReleaseContentReturns(&hapi_services.GetReleaseContentResponse{
+					Release: &hapi_release.Release{
+						Config: &hapi_chart.Config{Raw: "count: 1\nfoo: bar\n"},
+					},
+				}, nil)
+				fakeHelmClient.RenderTemplatedValuesReturns([]byte("count: 1\n"), nil)
+				fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:   "kibosh-my-instance-guid",
+						Labels: map[string]string{"planID": spacebearsServiceGUID + "-small"},
+					},
+				}, nil)
+			})
+
+			It("upgrades to the new plan keeping user parameters", func() {
+				resp, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				Expect(resp.IsAsync).To(BeTrue())
+				Expect(fakeHelmClient.UpdateChartCallCount()).To(Equal(0))
+				Expect(fakeHelmClient.UpgradeChartCallCount()).To(Equal(1))
+
+				chart, namespace, releaseName, plan, values := fakeHelmClient.UpgradeChartArgsForCall(0)
+				Expect(chart).To(Equal(spacebearsChart))
+				Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+				Expect(releaseName).To(Equal("k-5h5kntfw"))
+				Expect(plan).To(Equal("medium"))
+				Expect(strings.TrimSpace(string(values))).To(Equal("foo: bar"))
+			})
+
+			It("merges new parameters with existing ones", func() {
+				details.RawParameters = json.RawMessage(`{"baz":"qux"}`)
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				_, _, _, _, values := fakeHelmClient.UpgradeChartArgsForCall(0)
+				Expect(string(values)).To(Equal("baz: qux\nfoo: bar\n"))
+			})
+
+			It("records the new plan as pending until the upgrade succeeds", func() {
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				Expect(fakeCluster.UpdateNamespaceCallCount()).To(Equal(1))
+				namespace := fakeCluster.UpdateNamespaceArgsForCall(0)
+				Expect(namespace.Labels["planID"]).To(Equal(spacebearsServiceGUID + "-small"))
+				Expect(namespace.Annotations["kibosh.io/pending-migration"]).To(ContainSubstring(`"planID":"` + spacebearsServiceGUID + `-medium"`))
+			})
+
+			It("applies the new plan's resource quota before upgrading", func() {
+				plan := spacebearsChart.Plans["medium"]
+				plan.ResourceQuota = &api_v1.ResourceQuotaSpec{
+					Hard: api_v1.ResourceList{api_v1.ResourcePods: resource.MustParse("10")},
+				}
+				spacebearsChart.Plans["medium"] = plan
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				Expect(fakeCluster.CreateOrUpdateResourceQuotaCallCount()).To(Equal(1))
+				namespace, quota := fakeCluster.CreateOrUpdateResourceQuotaArgsForCall(0)
+				Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+				Expect(quota.Spec).To(Equal(*plan.ResourceQuota))
+				Expect(fakeCluster.DeleteLimitRangeCallCount()).To(Equal(1))
+			})
+
+			It("applies the new plan's network policies", func() {
+				plan := spacebearsChart.Plans["medium"]
+				plan.NetworkIsolation = true
+				spacebearsChart.Plans["medium"] = plan
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).To(BeNil())
+				Expect(fakeCluster.CreateOrUpdateNetworkPolicyCallCount()).To(Equal(2))
+				namespace, policy := fakeCluster.CreateOrUpdateNetworkPolicyArgsForCall(0)
+				Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+				Expect(policy.Name).To(Equal("kibosh-deny-ingress"))
+			})
+
+			It("doesn't upgrade when the plan's limits can't be applied", func() {
+				plan := spacebearsChart.Plans["medium"]
+				plan.LimitRange = &api_v1.LimitRangeSpec{}
+				spacebearsChart.Plans["medium"] = plan
+				fakeCluster.CreateOrUpdateLimitRangeReturns(nil, errors.New("forbidden"))
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).NotTo(BeNil())
+				Expect(fakeHelmClient.UpgradeChartCallCount()).To(Equal(0))
+			})
+
+			It("refuses to move between clusters", func() {
+				plan := spacebearsChart.Plans["medium"]
+				plan.ClusterConfig = &k8sAPI.Config{CurrentContext: "context2"}
+				spacebearsChart.Plans["medium"] = plan
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).NotTo(BeNil())
+				failure, ok := err.(*brokerapi.FailureResponse)
+				Expect(ok).To(BeTrue())
+				Expect(failure.ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+				Expect(fakeHelmClient.UpgradeChartCallCount()).To(Equal(0))
+			})
+
+			It("elevates upgrade error", func() {
+				fakeHelmClient.UpgradeChartReturns(nil, errors.New("tiller unavailable"))
+
+				_, err := broker.Update(nil, "my-instance-guid", details, true)
+
+				Expect(err).NotTo(BeNil())
+				Expect(fakeCluster.UpdateNamespaceCallCount()).To(Equal(0))
+			})
+		})
+
+		It("rejects parameters not matching plan schema", func() {
+			plan := spacebearsChart.Plans["small"]
+			plan.Schema = map[string]interface{}{
+				"properties": map[string]interface{}{
+					"replicas": map[string]interface{}{"type": "integer"},
+				},
+			}
+			spacebearsChart.Plans["small"] = plan
+
+			_, err := broker.Update(nil, "my-instance-guid", brokerapi.UpdateDetails{
+				ServiceID:     spacebearsServiceGUID,
+				PlanID:        spacebearsServiceGUID + "-small",
+				RawParameters: json.RawMessage(`{"replicas":"three"}`),
+			}, true)
+
+			Expect(err).NotTo(BeNil())
+			failure, ok := err.(*brokerapi.FailureResponse)
+			Expect(ok).To(BeTrue())
+			Expect(failure.ValidatedStatusCode(nil)).To(Equal(http.StatusBadRequest))
+			Expect(fakeHelmClient.UpdateChartCallCount()).To(Equal(0))
+		})
+
 		It("targets the plan specific cluster", func() {
 			details := brokerapi.UpdateDetails{
 				ServiceID:     spacebearsServiceGUID,
@@ -1032,7 +2186,7 @@ var _ = Describe("Broker", func() {
 
 			fakeClusterFactory.GetClusterFromK8sConfigReturns(&fakeCluster, nil)
 
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 
 			_, err := broker.Update(nil, "my-instance-guid", details, true)
 
@@ -1049,7 +2203,7 @@ var _ = Describe("Broker", func() {
 		var broker *PksServiceBroker
 
 		It("happy path without credhub", func() {
-			broker = NewPksServiceBroker(config, nil, nil, nil, fakeInstallerFactory, fakeRepo, nil, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, nil, nil, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
 			response, err := broker.Unbind(nil, "my-instance-id", "my-binding-id", brokerapi.UnbindDetails{
 				ServiceID: mysqlServiceID,
 			}, false)
@@ -1059,7 +2213,7 @@ var _ = Describe("Broker", func() {
 		})
 
 		It("cleanups credhub", func() {
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, nil, logger)
 
 			_, err := broker.Unbind(nil, "my-instance-id", "my-binding-id", brokerapi.UnbindDetails{
 				ServiceID: mysqlServiceID,
@@ -1076,8 +2230,32 @@ var _ = Describe("Broker", func() {
 
 		})
 
+		It("removes the binding record", func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+
+			_, err := broker.Unbind(nil, "my-instance-id", "my-binding-id", brokerapi.UnbindDetails{
+				ServiceID: mysqlServiceID,
+			}, false)
+			Expect(err).To(BeNil())
+
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(1))
+			namespace, name, _ := fakeCluster.DeleteConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh-my-instance-id"))
+			Expect(name).To(Equal("kibosh-binding-my-binding-id"))
+		})
+
+		It("ignores missing binding record", func() {
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, nil, nil, nil, logger)
+			fakeCluster.DeleteConfigMapReturns(k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-binding-my-binding-id"))
+
+			_, err := broker.Unbind(nil, "my-instance-id", "my-binding-id", brokerapi.UnbindDetails{
+				ServiceID: mysqlServiceID,
+			}, false)
+			Expect(err).To(BeNil())
+		})
+
 		It("surfaces error failing to cleanup", func() {
-			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, logger)
+			broker = NewPksServiceBroker(config, &fakeClusterFactory, &fakeHelmClientFactory, &fakeServiceAccountInstallerFactory, fakeInstallerFactory, fakeRepo, fakeCredStore, nil, nil, logger)
 
 			fakeCredStore.DeleteReturns(errors.New("the tubes are down"))
 
@@ -0,0 +1,149 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"encoding/json"
+	"time"
+
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dashboardTimeout is how long after the operation started a dashboard template failing to evaluate is waited on
+const dashboardTimeout = 30 * time.Minute
+
+// getDashboardURL returns the URL of the dashboard the chart nominates, and is empty for charts that don't
+// nominate one. It's empty until the ingress has been assigned a host.
+func (broker *PksServiceBroker) getDashboardURL(cluster k8s.Cluster, chart *my_helm.MyChart, instanceID string) (string, error) {
+	dashboard := chart.Service.Dashboard
+	if dashboard == nil {
+		return "", nil
+	}
+	if dashboard.Template != "" {
+		return broker.renderDashboardURL(cluster, instanceID, dashboard.Template, chart.BindSecretExposure)
+	}
+
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return "", err
+	}
+	ingresses, err := cluster.ListIngresses(namespace, meta_v1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	for _, ingress := range ingresses.Items {
+		if ingress.Name != dashboard.Ingress {
+			continue
+		}
+		url := getIngressURL(ingress)
+		if url != "" {
+			return url, nil
+		}
+	}
+
+	return "", nil
+}
+
+func getIngressURL(ingress v1_beta1.Ingress) string {
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+		for _, tls := range ingress.Spec.TLS {
+			for _, host := range tls.Hosts {
+				if host == rule.Host {
+					return "https://" + rule.Host
+				}
+			}
+		}
+		return "http://" + rule.Host
+	}
+
+	for _, loadBalancer := range ingress.Status.LoadBalancer.Ingress {
+		if loadBalancer.Hostname != "" {
+			return "http://" + loadBalancer.Hostname
+		}
+		if loadBalancer.IP != "" {
+			return "http://" + loadBalancer.IP
+		}
+	}
+
+	return ""
+}
+
+// renderDashboardURL evaluates the template against the secrets, services and ingresses of the instance, seeing
+// the same secrets bind templates do. Runtime errors are returned as they are, for callers to tell whether what
+// the template refers to may just not exist yet.
+func (broker *PksServiceBroker) renderDashboardURL(cluster k8s.Cluster, instanceID string, template string, secretExposure string) (string, error) {
+	namespace, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return "", err
+	}
+	inputs, err := cluster.GetSecretsAndServices(namespace, secretExposure, broker.config.NodeAddressConfig)
+	if err != nil {
+		return "", err
+	}
+	ingresses, err := cluster.GetIngresses(namespace)
+	if err != nil {
+		return "", err
+	}
+	if ingresses == nil {
+		ingresses = []map[string]interface{}{}
+	}
+	inputs["ingresses"] = ingresses
+
+	rendered, err := my_helm.RenderJsonnetTemplate(template, inputs)
+	if err != nil {
+		return "", err
+	}
+
+	var url string
+	err = json.Unmarshal([]byte(rendered), &url)
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
+
+// waitingOnDashboard is true while the dashboard the chart nominates has no URL
+func (broker *PksServiceBroker) waitingOnDashboard(cluster k8s.Cluster, instanceID string, serviceID string) (bool, error) {
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil {
+		return false, err
+	}
+	if instance != nil {
+		serviceID = instance.ServiceID
+	}
+
+	charts, err := broker.GetChartsMap()
+	if err != nil {
+		return false, err
+	}
+	chart := charts[serviceID]
+	if chart == nil || chart.Service.Dashboard == nil {
+		return false, nil
+	}
+
+	url, err := broker.getDashboardURL(cluster, chart, instanceID)
+	if err != nil {
+		return false, err
+	}
+	return url == "", nil
+}
@@ -0,0 +1,245 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	api_v1 "k8s.io/api/core/v1"
+	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var _ = Describe("dashboard", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+	var fixture *brokerFixture
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var chart *my_helm.MyChart
+	var broker *PksServiceBroker
+
+	ingress := func(name string, host string) v1_beta1.Ingress {
+		return v1_beta1.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name},
+			Spec: v1_beta1.IngressSpec{
+				Rules: []v1_beta1.IngressRule{{Host: host}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeCluster = fixture.cluster
+		chart = fixture.chart
+		chart.Service.Dashboard = &my_helm.Dashboard{Ingress: "ui"}
+
+		fakeCluster.ListIngressesReturns(&v1_beta1.IngressList{
+			Items: []v1_beta1.Ingress{
+				ingress("api", "api.example.com"),
+				ingress("ui", "ui.example.com"),
+			},
+		}, nil)
+
+		broker = fixture.newBroker(nil, nil)
+	})
+
+	Context("provision", func() {
+		var details brokerapi.ProvisionDetails
+
+		BeforeEach(func() {
+			details = brokerapi.ProvisionDetails{
+				ServiceID: serviceID,
+				PlanID:    serviceID + "-small",
+			}
+		})
+
+		It("returns the url of the nominated ingress", func() {
+			resp, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(resp.DashboardURL).To(Equal("http://ui.example.com"))
+			namespace, _ := fakeCluster.ListIngressesArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		})
+
+		It("falls back to the load balancer of the ingress", func() {
+			ui := ingress("ui", "")
+			ui.Status.LoadBalancer.Ingress = []api_v1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			fakeCluster.ListIngressesReturns(&v1_beta1.IngressList{Items: []v1_beta1.Ingress{ui}}, nil)
+
+			resp, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(resp.DashboardURL).To(Equal("http://10.0.0.1"))
+		})
+
+		It("doesn't fail the provision when the dashboard can't be found", func() {
+			fakeCluster.ListIngressesReturns(nil, errors.New("ingresses unavailable"))
+
+			resp, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(resp.IsAsync).To(BeTrue())
+			Expect(resp.DashboardURL).To(BeEmpty())
+		})
+
+		It("doesn't look for a dashboard the chart doesn't nominate", func() {
+			chart.Service.Dashboard = nil
+
+			resp, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(resp.DashboardURL).To(BeEmpty())
+			Expect(fakeCluster.ListIngressesCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("get instance", func() {
+		BeforeEach(func() {
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:   "kibosh-my-instance-guid",
+					Labels: map[string]string{"serviceID": serviceID, "planID": serviceID + "-small"},
+				},
+			}, nil)
+			fakeHelmClient.ReleaseContentReturns(&hapi_services.GetReleaseContentResponse{}, nil)
+		})
+
+		It("renders the dashboard template", func() {
+			chart.Service.Dashboard = &my_helm.Dashboard{
+				Template: `"https://" + $.ingresses[0].spec.rules[0].host + "/admin"`,
+			}
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+				"secrets":  {},
+				"services": {},
+			}, nil)
+			fakeCluster.GetIngressesReturns([]map[string]interface{}{
+				{"name": "ui", "spec": map[string]interface{}{
+					"rules": []interface{}{map[string]interface{}{"host": "ui.example.com"}},
+				}},
+			}, nil)
+
+			instance, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).To(BeNil())
+			Expect(instance.DashboardURL).To(Equal("https://ui.example.com/admin"))
+		})
+
+		It("is empty for charts without a dashboard", func() {
+			chart.Service.Dashboard = nil
+
+			instance, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).To(BeNil())
+			Expect(instance.DashboardURL).To(BeEmpty())
+			Expect(fakeCluster.ListIngressesCallCount()).To(Equal(0))
+		})
+
+		It("is empty while the template's inputs don't exist", func() {
+			chart.Service.Dashboard = &my_helm.Dashboard{
+				Template: `"https://" + $.ingresses[0].spec.rules[0].host`,
+			}
+			fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{}, nil)
+
+			instance, err := broker.GetInstance(nil, "my-instance-guid")
+
+			Expect(err).To(BeNil())
+			Expect(instance.DashboardURL).To(BeEmpty())
+		})
+	})
+
+	Context("last operation", func() {
+		var pollDetails brokerapi.PollDetails
+
+		BeforeEach(func() {
+			fakeHelmClient.ReleaseHistoryReturns(&hapi_services.GetHistoryResponse{
+				Releases: []*hapi_release.Release{{
+					Version: 1,
+					Info:    &hapi_release.Info{Status: &hapi_release.Status{Code: hapi_release.Status_DEPLOYED}},
+				}},
+			}, nil)
+			fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+			pollDetails = brokerapi.PollDetails{
+				ServiceID:     serviceID,
+				PlanID:        serviceID + "-small",
+				OperationData: fmt.Sprintf("provision:1:%d", time.Now().Unix()),
+			}
+		})
+
+		It("succeeds once the dashboard has a host", func() {
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+		})
+
+		It("is in progress until the dashboard has a host", func() {
+			fakeCluster.ListIngressesReturns(&v1_beta1.IngressList{
+				Items: []v1_beta1.Ingress{
+					ingress("api", "api.example.com"),
+					ingress("ui", ""),
+				},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+			Expect(resp.Description).To(ContainSubstring("dashboard"))
+		})
+
+		Context("dashboard template", func() {
+			BeforeEach(func() {
+				chart.Service.Dashboard = &my_helm.Dashboard{
+					Template: `"https://" + $.ingresses[0].spec.rules[0].host`,
+				}
+				fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{}, nil)
+			})
+
+			It("is in progress while the template doesn't evaluate", func() {
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.InProgress))
+				Expect(resp.Description).To(ContainSubstring("dashboard"))
+			})
+
+			It("fails when the template still doesn't evaluate long after the operation started", func() {
+				pollDetails.OperationData = fmt.Sprintf("provision:1:%d", time.Now().Add(-time.Hour).Unix())
+
+				resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+				Expect(err).To(BeNil())
+				Expect(resp.State).To(Equal(brokerapi.Failed))
+				Expect(resp.Description).To(ContainSubstring("dashboard template failed"))
+			})
+		})
+	})
+})
@@ -0,0 +1,224 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pivotal-cf/brokerapi"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	storage_errors "k8s.io/helm/pkg/storage/errors"
+)
+
+const deprovisionConfigMapPrefix = "kibosh-deprovision-"
+const deprovisionTimeout = 15 * time.Minute
+
+// startDeprovision persists the deprovision so it can be tracked by LastOperation and resumed after a restart
+func (broker *PksServiceBroker) startDeprovision(cluster k8s.Cluster, instanceID string, details brokerapi.DeprovisionDetails) error {
+	_, err := cluster.CreateOrUpdateConfigMap(broker.config.TillerNamespace, &api_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: broker.getDeprovisionConfigMapName(instanceID),
+			Labels: map[string]string{
+				"operation":                    "deprovision",
+				"app.kubernetes.io/managed-by": "kibosh",
+			},
+		},
+		Data: map[string]string{
+			"instanceID": instanceID,
+			"serviceID":  details.ServiceID,
+			"planID":     details.PlanID,
+			"startedAt":  time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	go broker.deleteInstance(cluster, instanceID)
+
+	return nil
+}
+
+// deleteInstance removes the release and namespace, recording any failure on the deprovision record
+func (broker *PksServiceBroker) deleteInstance(cluster k8s.Cluster, instanceID string) {
+	names, err := broker.getInstanceNames(instanceID)
+	if err != nil {
+		broker.logger.Error("Unable to read the names of instanceID=", instanceID, " ", err)
+		broker.failDeprovision(cluster, instanceID, fmt.Sprintf("reading instance names failed: %v", err))
+		return
+	}
+
+	helmClient := broker.helmClientFactory.HelmClient(cluster)
+	_, err = helmClient.DeleteRelease(names.ReleaseName)
+	if err != nil && !isReleaseGone(err, names.ReleaseName) {
+		broker.logger.Error("Delete Release failed for instanceID=", instanceID, " ", err)
+		broker.failDeprovision(cluster, instanceID, fmt.Sprintf("deleting release failed: %v", err))
+		return
+	}
+
+	err = cluster.DeleteNamespace(names.Namespace, &meta_v1.DeleteOptions{})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		broker.logger.Error("Delete Namespace failed for instanceID=", instanceID, " ", err)
+		broker.failDeprovision(cluster, instanceID, fmt.Sprintf("deleting namespace failed: %v", err))
+	}
+}
+
+func (broker *PksServiceBroker) failDeprovision(cluster k8s.Cluster, instanceID string, message string) {
+	record, err := cluster.GetConfigMap(broker.config.TillerNamespace, broker.getDeprovisionConfigMapName(instanceID), meta_v1.GetOptions{})
+	if err != nil || record == nil {
+		broker.logger.Error("Unable to read deprovision record for instanceID=", instanceID, " ", err)
+		return
+	}
+
+	if record.Data == nil {
+		record.Data = map[string]string{}
+	}
+	record.Data["error"] = message
+	_, err = cluster.CreateOrUpdateConfigMap(broker.config.TillerNamespace, record)
+	if err != nil {
+		broker.logger.Error("Unable to update deprovision record for instanceID=", instanceID, " ", err)
+	}
+}
+
+// deprovisionState reports progress from the deprovision record, and is false when there's no record to go on
+func (broker *PksServiceBroker) deprovisionState(cluster k8s.Cluster, instanceID string) (brokerapi.LastOperation, bool, error) {
+	recordName := broker.getDeprovisionConfigMapName(instanceID)
+	record, err := cluster.GetConfigMap(broker.config.TillerNamespace, recordName, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return brokerapi.LastOperation{}, false, nil
+		}
+		return brokerapi.LastOperation{}, false, err
+	}
+	if record == nil {
+		return brokerapi.LastOperation{}, false, nil
+	}
+
+	if record.Data["error"] != "" {
+		return brokerapi.LastOperation{
+			State:       brokerapi.Failed,
+			Description: record.Data["error"],
+		}, true, nil
+	}
+
+	namespaceName, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, false, err
+	}
+	namespace, err := cluster.GetNamespace(namespaceName, nil)
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			return brokerapi.LastOperation{}, false, err
+		}
+
+		err = cluster.DeleteConfigMap(broker.config.TillerNamespace, recordName, &meta_v1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return brokerapi.LastOperation{}, false, err
+		}
+		err = broker.forgetInstance(instanceID)
+		if err != nil {
+			return brokerapi.LastOperation{}, false, err
+		}
+		return brokerapi.LastOperation{
+			State:       brokerapi.Succeeded,
+			Description: "gone",
+		}, true, nil
+	}
+
+	if namespace.DeletionTimestamp != nil && time.Since(namespace.DeletionTimestamp.Time) > deprovisionTimeout {
+		return brokerapi.LastOperation{
+			State:       brokerapi.Failed,
+			Description: broker.getStuckNamespaceMessage(namespace),
+		}, true, nil
+	}
+
+	return brokerapi.LastOperation{
+		State:       brokerapi.InProgress,
+		Description: "delete in progress",
+	}, true, nil
+}
+
+func (broker *PksServiceBroker) getStuckNamespaceMessage(namespace *api_v1.Namespace) string {
+	finalizers := []string{}
+	for _, finalizer := range namespace.Spec.Finalizers {
+		finalizers = append(finalizers, string(finalizer))
+	}
+	finalizers = append(finalizers, namespace.Finalizers...)
+
+	message := fmt.Sprintf(
+		"namespace %s still terminating after %v, waiting on finalizers [%s]",
+		namespace.Name, deprovisionTimeout, strings.Join(finalizers, ", "),
+	)
+	for _, condition := range namespace.Status.Conditions {
+		if condition.Status == api_v1.ConditionTrue && condition.Message != "" {
+			message = message + ": " + condition.Message
+		}
+	}
+	return message
+}
+
+// ResumeDeprovisions restarts deletes that were still pending when the broker last stopped
+func (broker *PksServiceBroker) ResumeDeprovisions() error {
+	clusters, err := broker.getAllClusters()
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		records, err := cluster.ListConfigMaps(broker.config.TillerNamespace, meta_v1.ListOptions{
+			LabelSelector: "operation=deprovision,app.kubernetes.io/managed-by=kibosh",
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records.Items {
+			if record.Data["error"] != "" {
+				continue
+			}
+			instanceID := record.Data["instanceID"]
+			broker.logger.Info(fmt.Sprintf("Resuming deprovision of instance %s", instanceID))
+			go broker.deleteInstance(cluster, instanceID)
+		}
+	}
+
+	return nil
+}
+
+func (broker *PksServiceBroker) getDeprovisionConfigMapName(instanceID string) string {
+	return deprovisionConfigMapPrefix + instanceID
+}
+
+// isReleaseGone is true for the errors tiller answers deleting a release it has no record of, or has deleted
+// already with. Tiller sends them with the Unknown status code, so they're told apart by their description.
+func isReleaseGone(err error, releaseName string) bool {
+	status, ok := grpc_status.FromError(err)
+	if !ok {
+		return false
+	}
+	if status.Code() == codes.NotFound {
+		return true
+	}
+	return status.Code() == codes.Unknown && (status.Message() == storage_errors.ErrReleaseNotFound(releaseName).Error() ||
+		status.Message() == fmt.Sprintf("the release named %q is already deleted", releaseName))
+}
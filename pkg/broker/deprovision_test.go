@@ -0,0 +1,299 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/brokerapi"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	k8sAPI "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var _ = Describe("deprovision", func() {
+	var fixture *brokerFixture
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var broker *PksServiceBroker
+	var details brokerapi.DeprovisionDetails
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeCluster = fixture.cluster
+		fixture.repo.GetChartsReturns([]*my_helm.MyChart{}, nil)
+		broker = fixture.newBroker(nil, nil)
+
+		details = brokerapi.DeprovisionDetails{
+			PlanID:    "my-plan-id",
+			ServiceID: "my-service-id",
+		}
+	})
+
+	Context("start", func() {
+		It("records the deprovision before deleting", func() {
+			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(1))
+			namespace, record := fakeCluster.CreateOrUpdateConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("my-kibosh-namespace"))
+			Expect(record.Name).To(Equal("kibosh-deprovision-my-instance-guid"))
+			Expect(record.Labels["operation"]).To(Equal("deprovision"))
+			Expect(record.Data["instanceID"]).To(Equal("my-instance-guid"))
+			Expect(record.Data["planID"]).To(Equal("my-plan-id"))
+
+			Eventually(func() int {
+				return fakeCluster.DeleteNamespaceCallCount()
+			}).Should(Equal(1))
+		})
+
+		It("returns error when unable to record", func() {
+			fakeCluster.CreateOrUpdateConfigMapReturns(nil, errors.New("forbidden"))
+
+			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Consistently(func() int {
+				return fakeHelmClient.DeleteReleaseCallCount()
+			}).Should(Equal(0))
+		})
+
+		It("records release deletion failure", func() {
+			fakeHelmClient.DeleteReleaseReturns(nil, errors.New("tiller unavailable"))
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-deprovision-my-instance-guid"},
+				Data:       map[string]string{"instanceID": "my-instance-guid"},
+			}, nil)
+
+			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Eventually(func() int {
+				return fakeCluster.CreateOrUpdateConfigMapCallCount()
+			}).Should(Equal(2))
+			_, record := fakeCluster.CreateOrUpdateConfigMapArgsForCall(1)
+			Expect(record.Data["error"]).To(ContainSubstring("tiller unavailable"))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+		})
+
+		It("deletes namespace when release is already gone", func() {
+			fakeHelmClient.DeleteReleaseReturns(nil, grpc_status.Error(codes.Unknown, `release: "k-5h5kntfw" not found`))
+
+			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Eventually(func() int {
+				return fakeCluster.DeleteNamespaceCallCount()
+			}).Should(Equal(1))
+		})
+
+		It("deletes namespace when release was already deleted", func() {
+			fakeHelmClient.DeleteReleaseReturns(nil, grpc_status.Error(codes.Unknown, `the release named "k-5h5kntfw" is already deleted`))
+
+			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Eventually(func() int {
+				return fakeCluster.DeleteNamespaceCallCount()
+			}).Should(Equal(1))
+		})
+
+		It("records failure of errors that merely mention not found", func() {
+			fakeHelmClient.DeleteReleaseReturns(nil, errors.New(`configmap "tiller-state" not found`))
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-deprovision-my-instance-guid"},
+				Data:       map[string]string{"instanceID": "my-instance-guid"},
+			}, nil)
+
+			_, err := broker.Deprovision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Eventually(func() int {
+				return fakeCluster.CreateOrUpdateConfigMapCallCount()
+			}).Should(Equal(2))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("last operation", func() {
+		var pollDetails brokerapi.PollDetails
+
+		BeforeEach(func() {
+			pollDetails = brokerapi.PollDetails{OperationData: "deprovision"}
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-deprovision-my-instance-guid"},
+				Data:       map[string]string{"instanceID": "my-instance-guid"},
+			}, nil)
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-my-instance-guid"},
+			}, nil)
+		})
+
+		It("is in progress until the namespace is gone", func() {
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+			Expect(fakeHelmClient.ReleaseStatusCallCount()).To(Equal(0))
+		})
+
+		It("succeeds and removes the record once the namespace is gone", func() {
+			fakeCluster.GetNamespaceReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("namespaces"), "kibosh-my-instance-guid"))
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(1))
+			namespace, name, _ := fakeCluster.DeleteConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("my-kibosh-namespace"))
+			Expect(name).To(Equal("kibosh-deprovision-my-instance-guid"))
+		})
+
+		It("fails with recorded error", func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"error": "deleting release failed: tiller unavailable"},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Failed))
+			Expect(resp.Description).To(ContainSubstring("tiller unavailable"))
+		})
+
+		It("fails when the namespace is stuck on finalizers", func() {
+			deletedAt := meta_v1.NewTime(time.Now().Add(-time.Hour))
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:              "kibosh-my-instance-guid",
+					DeletionTimestamp: &deletedAt,
+				},
+				Spec: api_v1.NamespaceSpec{
+					Finalizers: []api_v1.FinalizerName{"kubernetes"},
+				},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Failed))
+			Expect(resp.Description).To(ContainSubstring("[kubernetes]"))
+		})
+
+		It("is in progress while namespace terminates", func() {
+			deletedAt := meta_v1.NewTime(time.Now())
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:              "kibosh-my-instance-guid",
+					DeletionTimestamp: &deletedAt,
+				},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+		})
+	})
+
+	Context("resume", func() {
+		It("restarts pending deletes", func() {
+			fakeCluster.ListConfigMapsReturns(&api_v1.ConfigMapList{
+				Items: []api_v1.ConfigMap{
+					{Data: map[string]string{"instanceID": "my-instance-guid"}},
+					{Data: map[string]string{"instanceID": "failed-instance-guid", "error": "stuck"}},
+				},
+			}, nil)
+
+			err := broker.ResumeDeprovisions()
+
+			Expect(err).To(BeNil())
+			namespace, listOptions := fakeCluster.ListConfigMapsArgsForCall(0)
+			Expect(namespace).To(Equal("my-kibosh-namespace"))
+			Expect(listOptions.LabelSelector).To(ContainSubstring("operation=deprovision"))
+
+			Eventually(func() int {
+				return fakeCluster.DeleteNamespaceCallCount()
+			}).Should(Equal(1))
+			namespaceName, _ := fakeCluster.DeleteNamespaceArgsForCall(0)
+			Expect(namespaceName).To(Equal("kibosh-my-instance-guid"))
+			Consistently(func() int {
+				return fakeCluster.DeleteNamespaceCallCount()
+			}).Should(Equal(1))
+		})
+
+		It("lists each cluster once, including those only instances were recorded on", func() {
+			sharedConfig := &k8sAPI.Config{
+				Clusters:       map[string]*k8sAPI.Cluster{"shared": {Server: "https://shared.example.com"}},
+				CurrentContext: "shared",
+			}
+			recordedConfig := &k8sAPI.Config{
+				Clusters:       map[string]*k8sAPI.Cluster{"retired": {Server: "https://retired.example.com"}},
+				CurrentContext: "retired",
+			}
+			sharedConfigBytes, err := clientcmd.Write(*sharedConfig)
+			Expect(err).To(BeNil())
+			recordedConfigBytes, err := clientcmd.Write(*recordedConfig)
+			Expect(err).To(BeNil())
+			fixture.repo.GetChartsReturns([]*my_helm.MyChart{{
+				Plans: map[string]my_helm.Plan{
+					"small": {Name: "small", ClusterConfig: sharedConfig},
+					"large": {Name: "large", ClusterConfig: sharedConfig},
+				},
+			}}, nil)
+			fakeInstanceStore := newFakeInstanceStore()
+			fakeInstanceStore.ListReturns([]*instancestore.Instance{
+				{InstanceID: "default-instance-guid"},
+				{InstanceID: "shared-instance-guid", ClusterConfig: sharedConfigBytes},
+				{InstanceID: "retired-instance-guid", ClusterConfig: recordedConfigBytes},
+			}, nil)
+			fixture.clusterFactory.GetClusterFromK8sConfigReturns(fakeCluster, nil)
+			broker = fixture.newBroker(nil, fakeInstanceStore)
+			fakeCluster.ListConfigMapsReturns(&api_v1.ConfigMapList{}, nil)
+
+			err = broker.ResumeDeprovisions()
+
+			Expect(err).To(BeNil())
+			Expect(fixture.clusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(2))
+			Expect(fixture.clusterFactory.GetClusterFromK8sConfigArgsForCall(0)).To(Equal(sharedConfig))
+			Expect(fixture.clusterFactory.GetClusterFromK8sConfigArgsForCall(1).CurrentContext).To(Equal("retired"))
+			Expect(fakeCluster.ListConfigMapsCallCount()).To(Equal(3))
+		})
+
+		It("returns error when unable to list", func() {
+			fakeCluster.ListConfigMapsReturns(nil, errors.New("forbidden"))
+
+			err := broker.ResumeDeprovisions()
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,111 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pivotal-cf/brokerapi"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// recordInstance saves where and how the instance gets deployed, so later operations don't depend on the catalog
+func (broker *PksServiceBroker) recordInstance(chart *my_helm.MyChart, planName string, instanceID string, names instanceNames, details brokerapi.ProvisionDetails) error {
+	if broker.instanceStore == nil {
+		return nil
+	}
+
+	instance := &instancestore.Instance{
+		InstanceID:   instanceID,
+		ServiceID:    details.ServiceID,
+		PlanID:       details.PlanID,
+		ChartName:    chart.Metadata.Name,
+		ChartVersion: chart.Metadata.Version,
+		ReleaseName:  names.ReleaseName,
+		Namespace:    names.Namespace,
+	}
+	clusterConfig := chart.Plans[planName].ClusterConfig
+	if clusterConfig != nil {
+		configBytes, err := clientcmd.Write(*clusterConfig)
+		if err != nil {
+			return err
+		}
+		instance.ClusterConfig = configBytes
+	}
+
+	return broker.instanceStore.Save(instance)
+}
+
+// getInstanceRecord returns nil when there's no registry, or the instance predates it
+func (broker *PksServiceBroker) getInstanceRecord(instanceID string) (*instancestore.Instance, error) {
+	if broker.instanceStore == nil {
+		return nil, nil
+	}
+
+	instance, err := broker.instanceStore.Get(instanceID)
+	if err != nil {
+		if err == instancestore.ErrInstanceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return instance, nil
+}
+
+// getInstanceCluster finds the cluster the instance was provisioned to, falling back to the plan's current cluster
+func (broker *PksServiceBroker) getInstanceCluster(instanceID, planID, serviceID string) (k8s.Cluster, error) {
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return broker.getCluster(planID, serviceID)
+	}
+	return broker.getRecordedCluster(instance)
+}
+
+func (broker *PksServiceBroker) getRecordedCluster(instance *instancestore.Instance) (k8s.Cluster, error) {
+	if len(instance.ClusterConfig) == 0 {
+		return broker.clusterFactory.DefaultCluster()
+	}
+
+	clusterConfig, err := clientcmd.Load(instance.ClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return broker.clusterFactory.GetClusterFromK8sConfig(clusterConfig)
+}
+
+// updateInstanceRecord keeps the plan and chart version of the record in step with the release
+func (broker *PksServiceBroker) updateInstanceRecord(instanceID string, planID string, chartVersion string) error {
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil || instance == nil {
+		return err
+	}
+
+	instance.PlanID = planID
+	instance.ChartVersion = chartVersion
+	return broker.instanceStore.Save(instance)
+}
+
+func (broker *PksServiceBroker) forgetInstance(instanceID string) error {
+	broker.forgetInstanceNames(instanceID)
+	if broker.instanceStore == nil {
+		return nil
+	}
+	return broker.instanceStore.Delete(instanceID)
+}
@@ -0,0 +1,199 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"errors"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore/instancestorefakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	k8sAPI "k8s.io/client-go/tools/clientcmd/api"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var _ = Describe("instance registry", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var fakeClusterFactory *k8sfakes.FakeClusterFactory
+	var fakeInstanceStore *instancestorefakes.FakeInstanceStore
+	var broker *PksServiceBroker
+	var remoteConfig *k8sAPI.Config
+
+	BeforeEach(func() {
+		fixture := newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeCluster = fixture.cluster
+		fakeClusterFactory = fixture.clusterFactory
+		fakeClusterFactory.GetClusterFromK8sConfigReturns(fakeCluster, nil)
+
+		remoteConfig = &k8sAPI.Config{
+			Clusters: map[string]*k8sAPI.Cluster{
+				"remote": {Server: "https://remote.example.com"},
+			},
+			CurrentContext: "remote",
+		}
+		fixture.chart.Plans["remote"] = my_helm.Plan{
+			Name:          "remote",
+			ClusterConfig: remoteConfig,
+		}
+		fakeInstanceStore = newFakeInstanceStore()
+
+		broker = fixture.newBroker(nil, fakeInstanceStore)
+	})
+
+	Context("provision", func() {
+		var details brokerapi.ProvisionDetails
+
+		BeforeEach(func() {
+			details = brokerapi.ProvisionDetails{
+				ServiceID: serviceID,
+				PlanID:    serviceID + "-small",
+			}
+		})
+
+		It("records the instance", func() {
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(fakeInstanceStore.SaveCallCount()).To(Equal(1))
+			Expect(fakeInstanceStore.SaveArgsForCall(0)).To(Equal(&instancestore.Instance{
+				InstanceID:   "my-instance-guid",
+				ServiceID:    serviceID,
+				PlanID:       serviceID + "-small",
+				ChartName:    "spacebears",
+				ChartVersion: "1.0.0",
+				ReleaseName:  "k-5h5kntfw",
+				Namespace:    "kibosh-my-instance-guid",
+			}))
+		})
+
+		It("returns error when unable to record", func() {
+			fakeInstanceStore.SaveReturns(errors.New("forbidden"))
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("removes the record when install fails", func() {
+			fakeHelmClient.InstallChartReturns(nil, errors.New("tiller unavailable"))
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(fakeInstanceStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeInstanceStore.DeleteArgsForCall(0)).To(Equal("my-instance-guid"))
+		})
+	})
+
+	Context("later operations", func() {
+		var pollDetails brokerapi.PollDetails
+
+		BeforeEach(func() {
+			pollDetails = brokerapi.PollDetails{
+				ServiceID:     serviceID,
+				PlanID:        serviceID + "-small",
+				OperationData: "update",
+			}
+			fakeHelmClient.ReleaseStatusReturns(&hapi_services.GetReleaseStatusResponse{
+				Info: &hapi_release.Info{
+					Status: &hapi_release.Status{Code: hapi_release.Status_DEPLOYED},
+				},
+			}, nil)
+			fakeHelmClient.ResourceReadinessReturns(nil, hapi_release.Status_DEPLOYED, nil)
+		})
+
+		It("use the recorded cluster", func() {
+			clusterConfig, err := clientcmd.Write(*remoteConfig)
+			Expect(err).To(BeNil())
+			fakeInstanceStore.GetReturns(&instancestore.Instance{
+				InstanceID:    "my-instance-guid",
+				PlanID:        serviceID + "-remote",
+				ClusterConfig: clusterConfig,
+			}, nil)
+
+			_, err = broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(fakeClusterFactory.DefaultClusterCallCount()).To(Equal(0))
+			Expect(fakeClusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(1))
+			Expect(fakeClusterFactory.GetClusterFromK8sConfigArgsForCall(0).Clusters["remote"].Server).To(Equal("https://remote.example.com"))
+		})
+
+		It("fall back to the plan cluster for unrecorded instances", func() {
+			_, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(fakeClusterFactory.DefaultClusterCallCount()).To(Equal(1))
+			Expect(fakeClusterFactory.GetClusterFromK8sConfigCallCount()).To(Equal(0))
+		})
+
+		It("returns error when unable to read the registry", func() {
+			fakeInstanceStore.GetReturns(nil, errors.New("forbidden"))
+
+			_, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("uses the recorded plan when the platform doesn't send the previous one", func() {
+			fakeInstanceStore.GetReturns(&instancestore.Instance{
+				InstanceID: "my-instance-guid",
+				PlanID:     serviceID + "-remote",
+			}, nil)
+
+			_, err := broker.Update(nil, "my-instance-guid", brokerapi.UpdateDetails{
+				ServiceID: serviceID,
+				PlanID:    serviceID + "-small",
+			}, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("different clusters"))
+		})
+
+		It("forgets the instance once deprovisioned", func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-deprovision-my-instance-guid"},
+				Data:       map[string]string{"instanceID": "my-instance-guid"},
+			}, nil)
+			fakeCluster.GetNamespaceReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("namespaces"), "kibosh-my-instance-guid"))
+			pollDetails.OperationData = "deprovision"
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Succeeded))
+			Expect(fakeInstanceStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeInstanceStore.DeleteArgsForCall(0)).To(Equal("my-instance-guid"))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/pivotal-cf/brokerapi"
+)
+
+const defaultOperationLockTimeout = 2 * time.Hour
+
+// lockTokenSeparator separates the lock token from the rest of the OperationData handed to the platform
+const lockTokenSeparator = "@"
+
+// lockInstance holds the instance for the operation in the registry, so other replicas and restarts respect it,
+// returning the OSBAPI ConcurrencyError while another operation is in progress. The token it returns releases
+// this lock, and only this lock, once the operation is done.
+func (broker *PksServiceBroker) lockInstance(instanceID string, operationType string) (string, error) {
+	if broker.instanceStore == nil {
+		return "", nil
+	}
+
+	token, err := broker.instanceStore.Lock(instanceID, operationType, broker.operationLockTimeout())
+	if err == instancestore.ErrInstanceLocked {
+		return "", brokerapi.ErrConcurrentInstanceAccess
+	}
+	return token, err
+}
+
+// lockBinding holds just the binding for a bind or unbind, so bindings of other apps go ahead. Binding locks
+// conflict with the instance lock, so updates, deprovisions and rotations wait for pending bindings and the
+// other way around.
+func (broker *PksServiceBroker) lockBinding(instanceID string, bindingID string, operationType string) (string, error) {
+	if broker.instanceStore == nil {
+		return "", nil
+	}
+
+	token, err := broker.instanceStore.LockBinding(instanceID, bindingID, operationType, broker.operationLockTimeout())
+	if err == instancestore.ErrInstanceLocked {
+		return "", brokerapi.ErrConcurrentInstanceAccess
+	}
+	return token, err
+}
+
+func (broker *PksServiceBroker) unlockBinding(instanceID string, bindingID string, token string) {
+	if broker.instanceStore == nil || token == "" {
+		return
+	}
+
+	err := broker.instanceStore.UnlockBinding(instanceID, bindingID, token)
+	if err != nil {
+		broker.logger.Error("Unable to release the lock of instanceID=", instanceID, " bindingID=", bindingID, " ", err)
+	}
+}
+
+func (broker *PksServiceBroker) operationLockTimeout() time.Duration {
+	if broker.config.OperationLockTimeout == 0 {
+		return defaultOperationLockTimeout
+	}
+	return broker.config.OperationLockTimeout
+}
+
+// isOperationDone is whether the operation's lock can be released, which unknown operations, reported
+// without a state, never are
+func isOperationDone(state brokerapi.LastOperationState) bool {
+	return state == brokerapi.Succeeded || state == brokerapi.Failed
+}
+
+// unlockInstance only logs failures, since the lock expires anyway. There's nothing to release without a
+// token, e.g. for OperationData of earlier versions, whose locks are left to expire.
+func (broker *PksServiceBroker) unlockInstance(instanceID string, token string) {
+	if broker.instanceStore == nil || token == "" {
+		return
+	}
+
+	err := broker.instanceStore.Unlock(instanceID, token)
+	if err != nil {
+		broker.logger.Error("Unable to release the lock of instanceID=", instanceID, " ", err)
+	}
+}
+
+// withLockToken adds the lock token to the OperationData, so whichever replica LastOperation is polled on
+// releases the lock the operation took
+func withLockToken(operationData string, token string) string {
+	if token == "" {
+		return operationData
+	}
+	return operationData + lockTokenSeparator + token
+}
+
+// splitLockToken returns the OperationData without the lock token, and the token, which is empty when the
+// OperationData has none
+func splitLockToken(operationData string) (string, string) {
+	i := strings.LastIndex(operationData, lockTokenSeparator)
+	if i < 0 {
+		return operationData, ""
+	}
+	return operationData[:i], operationData[i+len(lockTokenSeparator):]
+}
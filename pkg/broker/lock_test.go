@@ -0,0 +1,260 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"errors"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore/instancestorefakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var _ = Describe("operation locks", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var fakeInstanceStore *instancestorefakes.FakeInstanceStore
+	var broker *PksServiceBroker
+
+	BeforeEach(func() {
+		fixture := newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeCluster = fixture.cluster
+		fakeInstanceStore = newFakeInstanceStore()
+		broker = fixture.newBroker(nil, fakeInstanceStore)
+	})
+
+	Context("provision", func() {
+		details := brokerapi.ProvisionDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}
+
+		It("locks the instance", func() {
+			fakeInstanceStore.LockReturns("my-lock-token", nil)
+
+			resp, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			instanceID, operation, timeout := fakeInstanceStore.LockArgsForCall(0)
+			Expect(instanceID).To(Equal("my-instance-guid"))
+			Expect(operation).To(Equal("provision"))
+			Expect(timeout).To(BeNumerically(">", 0))
+			Expect(fakeInstanceStore.UnlockCallCount()).To(Equal(0))
+			Expect(resp.OperationData).To(HaveSuffix("@my-lock-token"))
+		})
+
+		It("returns concurrency error while another operation is in progress", func() {
+			fakeInstanceStore.LockReturns("", instancestore.ErrInstanceLocked)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(Equal(brokerapi.ErrConcurrentInstanceAccess))
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("releases the lock when the install fails", func() {
+			fakeInstanceStore.LockReturns("my-lock-token", nil)
+			fakeHelmClient.InstallChartReturns(nil, errors.New("tiller unavailable"))
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(fakeInstanceStore.UnlockCallCount()).To(Equal(1))
+			instanceID, token := fakeInstanceStore.UnlockArgsForCall(0)
+			Expect(instanceID).To(Equal("my-instance-guid"))
+			Expect(token).To(Equal("my-lock-token"))
+		})
+	})
+
+	It("returns concurrency error on update while another operation is in progress", func() {
+		fakeInstanceStore.LockReturns("", instancestore.ErrInstanceLocked)
+
+		_, err := broker.Update(nil, "my-instance-guid", brokerapi.UpdateDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}, true)
+
+		Expect(err).To(Equal(brokerapi.ErrConcurrentInstanceAccess))
+		Expect(fakeHelmClient.UpdateChartCallCount()).To(Equal(0))
+	})
+
+	It("returns concurrency error on deprovision while another operation is in progress", func() {
+		fakeInstanceStore.LockReturns("", instancestore.ErrInstanceLocked)
+
+		_, err := broker.Deprovision(nil, "my-instance-guid", brokerapi.DeprovisionDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}, true)
+
+		Expect(err).To(Equal(brokerapi.ErrConcurrentInstanceAccess))
+		Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(0))
+	})
+
+	It("locks just the binding on bind", func() {
+		fakeInstanceStore.LockBindingReturns("", instancestore.ErrInstanceLocked)
+
+		_, err := broker.Bind(nil, "my-instance-guid", "my-binding-guid", brokerapi.BindDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}, true)
+
+		Expect(err).To(Equal(brokerapi.ErrConcurrentInstanceAccess))
+		instanceID, bindingID, operation, _ := fakeInstanceStore.LockBindingArgsForCall(0)
+		Expect(instanceID).To(Equal("my-instance-guid"))
+		Expect(bindingID).To(Equal("my-binding-guid"))
+		Expect(operation).To(Equal("bind"))
+		Expect(fakeInstanceStore.LockCallCount()).To(Equal(0))
+		Expect(fakeCluster.CreateOrUpdateConfigMapCallCount()).To(Equal(0))
+	})
+
+	It("locks just the binding on unbind", func() {
+		fakeInstanceStore.LockBindingReturns("", instancestore.ErrInstanceLocked)
+
+		_, err := broker.Unbind(nil, "my-instance-guid", "my-binding-guid", brokerapi.UnbindDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}, true)
+
+		Expect(err).To(Equal(brokerapi.ErrConcurrentInstanceAccess))
+		_, bindingID, operation, _ := fakeInstanceStore.LockBindingArgsForCall(0)
+		Expect(bindingID).To(Equal("my-binding-guid"))
+		Expect(operation).To(Equal("unbind"))
+		Expect(fakeInstanceStore.LockCallCount()).To(Equal(0))
+		Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(0))
+	})
+
+	It("returns concurrency error on rotate while another operation is in progress", func() {
+		fakeInstanceStore.LockReturns("", instancestore.ErrInstanceLocked)
+
+		_, _, err := broker.RotateCredentials("my-instance-guid", "my-binding-guid")
+
+		Expect(err).To(Equal(brokerapi.ErrConcurrentInstanceAccess))
+		Expect(fakeCluster.GetConfigMapCallCount()).To(Equal(0))
+	})
+
+	It("releases the lock of a synchronous unbind", func() {
+		fakeInstanceStore.LockBindingReturns("my-lock-token", nil)
+		fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-my-instance-guid"},
+		}, nil)
+
+		_, err := broker.Unbind(nil, "my-instance-guid", "my-binding-guid", brokerapi.UnbindDetails{
+			ServiceID: serviceID,
+			PlanID:    serviceID + "-small",
+		}, true)
+
+		Expect(err).To(BeNil())
+		Expect(fakeInstanceStore.UnlockBindingCallCount()).To(Equal(1))
+		_, bindingID, token := fakeInstanceStore.UnlockBindingArgsForCall(0)
+		Expect(bindingID).To(Equal("my-binding-guid"))
+		Expect(token).To(Equal("my-lock-token"))
+	})
+
+	It("releases the binding lock once the binding has finished", func() {
+		fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+			Data: map[string]string{"appGUID": "my-app-guid"},
+		}, nil)
+
+		resp, err := broker.LastBindingOperation(nil, "my-instance-guid", "my-binding-guid", brokerapi.PollDetails{
+			ServiceID:     serviceID,
+			PlanID:        serviceID + "-small",
+			OperationData: "bind@my-lock-token",
+		})
+
+		Expect(err).To(BeNil())
+		Expect(resp.State).To(Equal(brokerapi.Succeeded))
+		Expect(fakeInstanceStore.UnlockCallCount()).To(Equal(0))
+		instanceID, bindingID, token := fakeInstanceStore.UnlockBindingArgsForCall(0)
+		Expect(instanceID).To(Equal("my-instance-guid"))
+		Expect(bindingID).To(Equal("my-binding-guid"))
+		Expect(token).To(Equal("my-lock-token"))
+	})
+
+	Context("last operation", func() {
+		pollDetails := brokerapi.PollDetails{OperationData: "deprovision:0:1560000000@my-lock-token"}
+
+		BeforeEach(func() {
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-my-instance-guid"},
+			}, nil)
+		})
+
+		It("keeps the lock while the operation is in progress", func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"instanceID": "my-instance-guid"},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.InProgress))
+			Expect(fakeInstanceStore.UnlockCallCount()).To(Equal(0))
+		})
+
+		It("releases the lock once the operation has finished", func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"error": "deleting release failed: tiller unavailable"},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", pollDetails)
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Failed))
+			instanceID, token := fakeInstanceStore.UnlockArgsForCall(0)
+			Expect(instanceID).To(Equal("my-instance-guid"))
+			Expect(token).To(Equal("my-lock-token"))
+		})
+
+		It("leaves locks to expire for operations without a token", func() {
+			fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+				Data: map[string]string{"error": "deleting release failed: tiller unavailable"},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "deprovision"})
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(Equal(brokerapi.Failed))
+			Expect(fakeInstanceStore.UnlockCallCount()).To(Equal(0))
+		})
+
+		It("keeps the lock for unknown operations", func() {
+			fakeHelmClient.ReleaseStatusReturns(&hapi_services.GetReleaseStatusResponse{
+				Info: &hapi_release.Info{
+					Status: &hapi_release.Status{Code: hapi_release.Status_DEPLOYED},
+				},
+			}, nil)
+
+			resp, err := broker.LastOperation(nil, "my-instance-guid", brokerapi.PollDetails{OperationData: "bogus@my-lock-token"})
+
+			Expect(err).To(BeNil())
+			Expect(resp.State).To(BeEmpty())
+			Expect(fakeInstanceStore.UnlockCallCount()).To(Equal(0))
+		})
+	})
+})
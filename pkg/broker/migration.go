@@ -0,0 +1,158 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pkg/errors"
+	api_v1 "k8s.io/api/core/v1"
+)
+
+// pendingMigrationAnnotation holds the plan and maintenance version a migration moves the instance to. They only
+// replace the namespace's planID label and maintenance version once LastOperation sees the release deployed, so a
+// failed migration can be retried.
+const pendingMigrationAnnotation = "kibosh.io/pending-migration"
+
+type pendingMigration struct {
+	Revision           int32  `json:"revision"`
+	PlanID             string `json:"planID"`
+	PreviousPlanID     string `json:"previousPlanID"`
+	ChartVersion       string `json:"chartVersion"`
+	MaintenanceVersion string `json:"maintenanceVersion"`
+}
+
+func getPendingMigration(namespace *api_v1.Namespace) (*pendingMigration, error) {
+	if namespace == nil || namespace.Annotations[pendingMigrationAnnotation] == "" {
+		return nil, nil
+	}
+
+	migration := &pendingMigration{}
+	err := json.Unmarshal([]byte(namespace.Annotations[pendingMigrationAnnotation]), migration)
+	if err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
+
+// startMigration records the migration the release revision makes on the instance's namespace
+func (broker *PksServiceBroker) startMigration(cluster k8s.Cluster, instanceID string, migration pendingMigration) error {
+	namespaceName, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+	namespace, err := cluster.GetNamespace(namespaceName, nil)
+	if err != nil {
+		return err
+	}
+
+	migrationBytes, err := json.Marshal(migration)
+	if err != nil {
+		return err
+	}
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	namespace.Annotations[pendingMigrationAnnotation] = string(migrationBytes)
+	_, err = cluster.UpdateNamespace(namespace)
+	return err
+}
+
+// completeMigration moves the plan and maintenance version of the migration the operation made onto the namespace
+// and the instance record. Operations that didn't migrate the instance leave them as they are.
+func (broker *PksServiceBroker) completeMigration(cluster k8s.Cluster, instanceID string, op operation) error {
+	namespaceName, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+	namespace, err := cluster.GetNamespace(namespaceName, nil)
+	if err != nil {
+		return err
+	}
+	migration, err := getPendingMigration(namespace)
+	if err != nil {
+		return err
+	}
+	if migration == nil || migration.Revision != op.Revision {
+		return nil
+	}
+
+	if namespace.Labels == nil {
+		namespace.Labels = map[string]string{}
+	}
+	namespace.Labels["planID"] = migration.PlanID
+	namespace.Annotations[maintenanceVersionAnnotation] = migration.MaintenanceVersion
+	delete(namespace.Annotations, pendingMigrationAnnotation)
+	_, err = cluster.UpdateNamespace(namespace)
+	if err != nil {
+		return err
+	}
+
+	return broker.updateInstanceRecord(instanceID, migration.PlanID, migration.ChartVersion)
+}
+
+// abandonMigration puts back the previous plan's limits and network policies when the operation's migration is
+// rolled back. The namespace's planID label, maintenance version and the instance record still have the previous
+// plan, as they're only replaced once the migration completes.
+func (broker *PksServiceBroker) abandonMigration(cluster k8s.Cluster, instanceID string, op operation) error {
+	namespaceName, err := broker.getNamespace(instanceID)
+	if err != nil {
+		return err
+	}
+	namespace, err := cluster.GetNamespace(namespaceName, nil)
+	if err != nil {
+		return err
+	}
+	migration, err := getPendingMigration(namespace)
+	if err != nil {
+		return err
+	}
+	if migration == nil || migration.Revision != op.Revision {
+		return nil
+	}
+
+	serviceID := namespace.Labels["serviceID"]
+	charts, err := broker.GetChartsMap()
+	if err != nil {
+		return err
+	}
+	chart := charts[serviceID]
+	if chart == nil {
+		return errors.New(fmt.Sprintf("Chart not found for [%s]", serviceID))
+	}
+	previousPlanName := strings.TrimPrefix(migration.PreviousPlanID, serviceID+"-")
+	previousPlan, ok := chart.Plans[previousPlanName]
+	if !ok {
+		return errors.New(fmt.Sprintf("Plan not found for [%s]", migration.PreviousPlanID))
+	}
+
+	err = my_helm.ApplyPlanLimits(cluster, namespaceName, previousPlan)
+	if err != nil {
+		return err
+	}
+	err = my_helm.ApplyNetworkPolicies(cluster, namespaceName, previousPlan, broker.config.NetworkIsolation)
+	if err != nil {
+		return err
+	}
+
+	delete(namespace.Annotations, pendingMigrationAnnotation)
+	_, err = cluster.UpdateNamespace(namespace)
+	return err
+}
@@ -0,0 +1,273 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cf-platform-eng/kibosh/pkg/config"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/pkg/errors"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/helm/pkg/helm"
+)
+
+// helm 2 keeps releases in ConfigMaps named after the release and version, which caps release names
+const releaseNameMaxLength = 53
+
+// instanceNames are what the namespace and release of an instance are called
+type instanceNames struct {
+	Namespace   string
+	ReleaseName string
+}
+
+// namingInput is what the naming templates are rendered with
+type namingInput struct {
+	InstanceID       string
+	ShortID          string
+	ServiceID        string
+	ServiceName      string
+	PlanID           string
+	Plan             string
+	OrganizationGUID string
+	SpaceGUID        string
+	OrganizationName string
+	SpaceName        string
+	InstanceName     string
+	Context          map[string]interface{}
+}
+
+func defaultInstanceNames(instanceID string) instanceNames {
+	hashed := md5.Sum([]byte(instanceID))
+	encoded := base32.StdEncoding.EncodeToString(hashed[:])
+	return instanceNames{
+		Namespace:   "kibosh-" + instanceID,
+		ReleaseName: fmt.Sprintf("k-%s", strings.ToLower(string(encoded[0:8]))),
+	}
+}
+
+// recordedInstanceNames returns the names in the instance's record, falling back to the default names that
+// instances provisioned before naming was configurable have
+func recordedInstanceNames(instanceID string, instance *instancestore.Instance) instanceNames {
+	names := defaultInstanceNames(instanceID)
+	if instance == nil {
+		return names
+	}
+
+	if instance.Namespace != "" {
+		names.Namespace = instance.Namespace
+	}
+	if instance.ReleaseName != "" {
+		names.ReleaseName = instance.ReleaseName
+	}
+	return names
+}
+
+// getInstanceNames returns the names of the instance. Names never change once an instance is provisioned, so
+// those read from the registry are kept rather than read again for every call an operation makes.
+func (broker *PksServiceBroker) getInstanceNames(instanceID string) (instanceNames, error) {
+	broker.namesMutex.Lock()
+	names, ok := broker.names[instanceID]
+	broker.namesMutex.Unlock()
+	if ok {
+		return names, nil
+	}
+
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil {
+		return instanceNames{}, err
+	}
+	names = recordedInstanceNames(instanceID, instance)
+	if instance != nil {
+		// instances without a record may still be being provisioned by another broker, so aren't kept
+		broker.namesMutex.Lock()
+		broker.names[instanceID] = names
+		broker.namesMutex.Unlock()
+	}
+	return names, nil
+}
+
+// forgetInstanceNames drops the kept names once the instance is gone
+func (broker *PksServiceBroker) forgetInstanceNames(instanceID string) {
+	broker.namesMutex.Lock()
+	delete(broker.names, instanceID)
+	broker.namesMutex.Unlock()
+}
+
+func (broker *PksServiceBroker) getNamespace(instanceID string) (string, error) {
+	names, err := broker.getInstanceNames(instanceID)
+	return names.Namespace, err
+}
+
+func (broker *PksServiceBroker) getReleaseName(instanceID string) (string, error) {
+	names, err := broker.getInstanceNames(instanceID)
+	return names.ReleaseName, err
+}
+
+// newInstanceNames renders the configured naming templates for an instance being provisioned
+func (broker *PksServiceBroker) newInstanceNames(chart *my_helm.MyChart, planName string, instanceID string, details brokerapi.ProvisionDetails) (instanceNames, error) {
+	names := defaultInstanceNames(instanceID)
+	namingConfig := broker.config.NamingConfig
+	if namingConfig == nil || (namingConfig.NamespaceTemplate == "" && namingConfig.ReleaseNameTemplate == "") {
+		return names, nil
+	}
+	if broker.instanceStore == nil {
+		return instanceNames{}, errors.New("naming templates need the instance registry to record the names")
+	}
+
+	input, err := getNamingInput(broker.getServiceName(chart), planName, instanceID, details)
+	if err != nil {
+		return instanceNames{}, err
+	}
+
+	if namingConfig.NamespaceTemplate != "" {
+		names.Namespace, err = renderName(namingConfig.NamespaceTemplate, input)
+		if err != nil {
+			return instanceNames{}, errors.Wrap(err, "unable to render the namespace")
+		}
+	}
+	if namingConfig.ReleaseNameTemplate != "" {
+		names.ReleaseName, err = renderName(namingConfig.ReleaseNameTemplate, input)
+		if err != nil {
+			return instanceNames{}, errors.Wrap(err, "unable to render the release name")
+		}
+	}
+
+	invalid := validation.IsDNS1123Label(names.Namespace)
+	if len(invalid) > 0 {
+		return instanceNames{}, errors.New(fmt.Sprintf("namespace %s isn't valid: %s", names.Namespace, strings.Join(invalid, ", ")))
+	}
+	invalid = validation.IsDNS1123Label(names.ReleaseName)
+	if len(names.ReleaseName) > releaseNameMaxLength {
+		invalid = append(invalid, validation.MaxLenError(releaseNameMaxLength))
+	}
+	if len(invalid) > 0 {
+		return instanceNames{}, errors.New(fmt.Sprintf("release name %s isn't valid: %s", names.ReleaseName, strings.Join(invalid, ", ")))
+	}
+
+	return names, nil
+}
+
+func getNamingInput(serviceName string, planName string, instanceID string, details brokerapi.ProvisionDetails) (namingInput, error) {
+	input := namingInput{
+		InstanceID:       instanceID,
+		ShortID:          strings.Replace(instanceID, "-", "", -1),
+		ServiceID:        details.ServiceID,
+		ServiceName:      serviceName,
+		PlanID:           details.PlanID,
+		Plan:             planName,
+		OrganizationGUID: details.OrganizationGUID,
+		SpaceGUID:        details.SpaceGUID,
+		Context:          map[string]interface{}{},
+	}
+	if len(input.ShortID) > 8 {
+		input.ShortID = input.ShortID[:8]
+	}
+
+	if len(details.RawContext) > 0 {
+		err := json.Unmarshal(details.RawContext, &input.Context)
+		if err != nil {
+			return namingInput{}, err
+		}
+	}
+	contextString := func(key string) string {
+		value, _ := input.Context[key].(string)
+		return value
+	}
+	input.OrganizationName = contextString("organization_name")
+	input.SpaceName = contextString("space_name")
+	input.InstanceName = contextString("instance_name")
+	if input.OrganizationGUID == "" {
+		input.OrganizationGUID = contextString("organization_guid")
+	}
+	if input.SpaceGUID == "" {
+		input.SpaceGUID = contextString("space_guid")
+	}
+
+	return input, nil
+}
+
+func renderName(text string, input namingInput) (string, error) {
+	nameTemplate, err := config.ParseNamingTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var name bytes.Buffer
+	err = nameTemplate.Execute(&name, input)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(name.String()), nil
+}
+
+// checkNameCollisions makes sure neither name is taken by another instance, recorded or already in the cluster.
+// Tiller keeps the history of deleted releases too, and refuses to install over those just the same.
+func (broker *PksServiceBroker) checkNameCollisions(cluster k8s.Cluster, helmClient my_helm.MyHelmClient, instanceID string, names instanceNames) error {
+	if broker.instanceStore != nil {
+		instances, err := broker.instanceStore.List()
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			if instance.InstanceID == instanceID {
+				continue
+			}
+			if instance.Namespace == names.Namespace {
+				return errors.New(fmt.Sprintf("namespace %s is already used by instance %s", names.Namespace, instance.InstanceID))
+			}
+			if instance.ReleaseName == names.ReleaseName {
+				return errors.New(fmt.Sprintf("release name %s is already used by instance %s", names.ReleaseName, instance.InstanceID))
+			}
+		}
+	}
+
+	namespace, err := cluster.GetNamespace(names.Namespace, nil)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	ownNamespace := false
+	if err == nil && namespace != nil {
+		if namespace.Labels["instanceID"] != instanceID {
+			return errors.New(fmt.Sprintf("namespace %s already exists", names.Namespace))
+		}
+		ownNamespace = true
+	}
+
+	history, err := helmClient.ReleaseHistory(names.ReleaseName, helm.WithMaxHistory(1))
+	if err != nil {
+		if isReleaseGone(err, names.ReleaseName) {
+			return nil
+		}
+		return err
+	}
+	for _, release := range history.GetReleases() {
+		// a release in the instance's own namespace is left from an earlier attempt at provisioning it
+		if !ownNamespace || release.Namespace != names.Namespace {
+			return errors.New(fmt.Sprintf("release %s already exists", names.ReleaseName))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"errors"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	my_config "github.com/cf-platform-eng/kibosh/pkg/config"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore/instancestorefakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	api_v1 "k8s.io/api/core/v1"
+	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var _ = Describe("instance naming", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+	var fixture *brokerFixture
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var fakeInstanceStore *instancestorefakes.FakeInstanceStore
+	var broker *PksServiceBroker
+	var details brokerapi.ProvisionDetails
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeCluster = fixture.cluster
+		fixture.config.NamingConfig = &my_config.NamingConfig{
+			NamespaceTemplate:   "svc-{{ .OrganizationName }}-{{ .ShortID }}",
+			ReleaseNameTemplate: "{{ .ServiceName }}-{{ .ShortID }}",
+		}
+		fakeInstanceStore = newFakeInstanceStore()
+		broker = fixture.newBroker(nil, fakeInstanceStore)
+
+		details = brokerapi.ProvisionDetails{
+			ServiceID:  serviceID,
+			PlanID:     serviceID + "-small",
+			RawContext: []byte(`{"platform": "cloudfoundry", "organization_name": "my-org"}`),
+		}
+	})
+
+	Context("provision", func() {
+		It("installs into the rendered names and records them", func() {
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(1))
+			_, _, namespace, releaseName, _, _, _, _ := fakeHelmClient.InstallChartArgsForCall(0)
+			Expect(namespace.Name).To(Equal("svc-my-org-myinstan"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("instanceID", "my-instance-guid"))
+			Expect(releaseName).To(Equal("spacebears-myinstan"))
+
+			instance := fakeInstanceStore.SaveArgsForCall(0)
+			Expect(instance.Namespace).To(Equal("svc-my-org-myinstan"))
+			Expect(instance.ReleaseName).To(Equal("spacebears-myinstan"))
+		})
+
+		It("returns error when a name isn't DNS-1123", func() {
+			details.RawContext = []byte(`{"organization_name": "My_Org"}`)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("namespace svc-My_Org-myinstan isn't valid"))
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("returns error when the template refers to a missing context field", func() {
+			fixture.config.NamingConfig.NamespaceTemplate = "svc-{{ .Context.foundation }}"
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("returns error when another instance has the names", func() {
+			fakeInstanceStore.ListReturns([]*instancestore.Instance{
+				{InstanceID: "my-instance-guid", Namespace: "svc-my-org-myinstan", ReleaseName: "spacebears-myinstan"},
+				{InstanceID: "my-instance-guid-2", Namespace: "svc-my-org-myinstan", ReleaseName: "spacebears-myinstan"},
+			}, nil)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("already used by instance my-instance-guid-2"))
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("returns error when the namespace already exists for something else", func() {
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "svc-my-org-myinstan"},
+			}, nil)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("namespace svc-my-org-myinstan already exists"))
+		})
+
+		It("returns error when tiller already has a release with the name", func() {
+			fakeHelmClient.ReleaseHistoryReturns(&hapi_services.GetHistoryResponse{
+				Releases: []*hapi_release.Release{
+					{Name: "spacebears-myinstan", Namespace: "kibosh-other-instance"},
+				},
+			}, nil)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("release spacebears-myinstan already exists"))
+			releaseName, _ := fakeHelmClient.ReleaseHistoryArgsForCall(0)
+			Expect(releaseName).To(Equal("spacebears-myinstan"))
+			Expect(fakeInstanceStore.SaveCallCount()).To(Equal(0))
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("installs over a release left in the instance's own namespace", func() {
+			fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:   "svc-my-org-myinstan",
+					Labels: map[string]string{"instanceID": "my-instance-guid"},
+				},
+			}, nil)
+			fakeHelmClient.ReleaseHistoryReturns(&hapi_services.GetHistoryResponse{
+				Releases: []*hapi_release.Release{
+					{Name: "spacebears-myinstan", Namespace: "svc-my-org-myinstan"},
+				},
+			}, nil)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(1))
+		})
+
+		It("installs when tiller has no release with the name", func() {
+			fakeHelmClient.ReleaseHistoryReturns(nil, grpc_status.Error(codes.Unknown, `release: "spacebears-myinstan" not found`))
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).To(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(1))
+		})
+
+		It("returns error when tiller can't be asked for the release", func() {
+			fakeHelmClient.ReleaseHistoryReturns(nil, errors.New("tiller unavailable"))
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+
+		It("needs the instance registry to record the names", func() {
+			broker = fixture.newBroker(nil, nil)
+
+			_, err := broker.Provision(nil, "my-instance-guid", details, true)
+
+			Expect(err).NotTo(BeNil())
+			Expect(fakeHelmClient.InstallChartCallCount()).To(Equal(0))
+		})
+	})
+
+	It("finds instances by their recorded names", func() {
+		fakeInstanceStore.GetReturns(&instancestore.Instance{
+			InstanceID:  "my-instance-guid",
+			ServiceID:   serviceID,
+			PlanID:      serviceID + "-small",
+			Namespace:   "svc-my-org-myinstan",
+			ReleaseName: "spacebears-myinstan",
+		}, nil)
+		fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "svc-my-org-myinstan",
+				Labels: map[string]string{"serviceID": serviceID, "planID": serviceID + "-small"},
+			},
+		}, nil)
+		fakeCluster.ListIngressesReturns(&v1_beta1.IngressList{}, nil)
+
+		_, err := broker.GetInstance(nil, "my-instance-guid")
+
+		Expect(err).To(BeNil())
+		namespaceName, _ := fakeCluster.GetNamespaceArgsForCall(0)
+		Expect(namespaceName).To(Equal("svc-my-org-myinstan"))
+		releaseName, _ := fakeHelmClient.ReleaseContentArgsForCall(0)
+		Expect(releaseName).To(Equal("spacebears-myinstan"))
+		Expect(fakeInstanceStore.GetCallCount()).To(Equal(2))
+	})
+
+	It("returns error when unable to read the recorded names", func() {
+		fakeInstanceStore.GetReturnsOnCall(0, &instancestore.Instance{
+			InstanceID:  "my-instance-guid",
+			ServiceID:   serviceID,
+			PlanID:      serviceID + "-small",
+			Namespace:   "svc-my-org-myinstan",
+			ReleaseName: "spacebears-myinstan",
+		}, nil)
+		fakeInstanceStore.GetReturns(nil, errors.New("forbidden"))
+		fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "svc-my-org-myinstan",
+				Labels: map[string]string{"serviceID": serviceID, "planID": serviceID + "-small"},
+			},
+		}, nil)
+
+		_, err := broker.GetInstance(nil, "my-instance-guid")
+
+		Expect(err).NotTo(BeNil())
+		Expect(fakeHelmClient.ReleaseContentCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,138 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"k8s.io/helm/pkg/helm"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const provisionOperation = "provision"
+const deprovisionOperation = "deprovision"
+const updateOperation = "update"
+const upgradeOperation = "upgrade"
+
+const releaseHistoryMax = 256
+const operationStartTimeout = 10 * time.Minute
+
+// operation is what OperationData carries, so LastOperation can report on the release revision the operation
+// created instead of whichever revision happens to be current
+type operation struct {
+	Type      string
+	Revision  int32
+	StartedAt time.Time
+
+	// FromVersion and ToVersion are the maintenance versions of an upgrade
+	FromVersion string
+	ToVersion   string
+}
+
+func newOperation(operationType string, revision int32) operation {
+	return operation{
+		Type:      operationType,
+		Revision:  revision,
+		StartedAt: time.Now().UTC(),
+	}
+}
+
+// String encodes the operation as type:revision:startedAt, followed by :from:to for upgrades
+func (o operation) String() string {
+	token := fmt.Sprintf("%s:%d:%d", o.Type, o.Revision, o.StartedAt.Unix())
+	if o.Type == upgradeOperation {
+		token = fmt.Sprintf("%s:%s:%s", token, o.FromVersion, o.ToVersion)
+	}
+	return token
+}
+
+// parseOperation also accepts the bare operation names handed out by earlier versions, which have no revision
+func parseOperation(operationData string) operation {
+	parts := strings.Split(operationData, ":")
+	op := operation{Type: parts[0]}
+	parts = parts[1:]
+
+	if len(parts) >= 2 {
+		revision, revisionErr := strconv.ParseInt(parts[0], 10, 32)
+		startedAt, startedAtErr := strconv.ParseInt(parts[1], 10, 64)
+		if revisionErr == nil && startedAtErr == nil {
+			op.Revision = int32(revision)
+			op.StartedAt = time.Unix(startedAt, 0).UTC()
+			parts = parts[2:]
+		}
+	}
+
+	if op.Type == upgradeOperation && len(parts) > 0 {
+		op.FromVersion = parts[0]
+		op.ToVersion = parts[len(parts)-1]
+	}
+
+	return op
+}
+
+// nextRevision is the release revision the next install or upgrade of the instance will create
+func (broker *PksServiceBroker) nextRevision(helmClient my_helm.MyHelmClient, instanceID string) (int32, error) {
+	releaseName, err := broker.getReleaseName(instanceID)
+	if err != nil {
+		return 0, err
+	}
+	history, err := helmClient.ReleaseHistory(releaseName, helm.WithMaxHistory(1))
+	if err != nil {
+		return 0, err
+	}
+	if len(history.GetReleases()) == 0 {
+		return 1, nil
+	}
+	return history.GetReleases()[0].Version + 1, nil
+}
+
+// getOperationStatus returns the status of the revision the operation created, and false when tiller hasn't
+// got to that revision yet. Operations without a revision get the status of the current release.
+func (broker *PksServiceBroker) getOperationStatus(helmClient my_helm.MyHelmClient, instanceID string, op operation) (hapi_release.Status_Code, bool, error) {
+	releaseName, err := broker.getReleaseName(instanceID)
+	if err != nil {
+		return hapi_release.Status_UNKNOWN, false, err
+	}
+	if op.Revision == 0 {
+		response, err := helmClient.ReleaseStatus(releaseName)
+		if err != nil {
+			return hapi_release.Status_UNKNOWN, false, err
+		}
+		return response.Info.Status.Code, true, nil
+	}
+
+	history, err := helmClient.ReleaseHistory(releaseName, helm.WithMaxHistory(releaseHistoryMax))
+	if err != nil {
+		return hapi_release.Status_UNKNOWN, false, err
+	}
+	for _, release := range history.GetReleases() {
+		if release.Version != op.Revision {
+			continue
+		}
+		code := release.GetInfo().GetStatus().GetCode()
+		if code == hapi_release.Status_SUPERSEDED {
+			// the revision deployed, and a later operation has since replaced it
+			return hapi_release.Status_DEPLOYED, true, nil
+		}
+		return code, true, nil
+	}
+
+	return hapi_release.Status_UNKNOWN, false, nil
+}
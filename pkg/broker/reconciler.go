@@ -0,0 +1,382 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cf-platform-eng/kibosh/pkg/cf"
+	"github.com/cf-platform-eng/kibosh/pkg/config"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/cloudfoundry-community/go-cfclient"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/helm/pkg/helm"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+var releaseNamePattern = regexp.MustCompile(`^k-[a-z2-7]{8}$`)
+
+// releases deleted by deprovision are kept by tiller, so aren't orphans
+var orphanReleaseStatuses = []hapi_release.Status_Code{
+	hapi_release.Status_UNKNOWN,
+	hapi_release.Status_DEPLOYED,
+	hapi_release.Status_SUPERSEDED,
+	hapi_release.Status_FAILED,
+	hapi_release.Status_DELETING,
+	hapi_release.Status_PENDING_INSTALL,
+	hapi_release.Status_PENDING_UPGRADE,
+	hapi_release.Status_PENDING_ROLLBACK,
+}
+
+// Orphan is a kibosh namespace or release that doesn't belong to any instance the platform knows about
+type Orphan struct {
+	InstanceID string    `json:"instanceID"`
+	Cluster    string    `json:"cluster"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Release    string    `json:"release,omitempty"`
+	FirstSeen  time.Time `json:"firstSeen"`
+	// Confirmed is set when the CF API confirmed the instance no longer exists, or for a release whose namespace
+	// is gone. Without a record, other candidates may be instances provisioned before the registry.
+	Confirmed bool `json:"confirmed"`
+
+	cluster   k8s.Cluster
+	serviceID string
+	planID    string
+}
+
+type OrphanReconciler struct {
+	broker   *PksServiceBroker
+	cfClient cf.Client
+	conf     *config.ReconcilerConfig
+	logger   *logrus.Logger
+
+	mutex   sync.Mutex
+	orphans map[string]*Orphan
+}
+
+// NewOrphanReconciler cross-references what's deployed with the instance registry, and the CF API when cfClient is set
+func NewOrphanReconciler(broker *PksServiceBroker, cfClient cf.Client, conf *config.ReconcilerConfig, logger *logrus.Logger) *OrphanReconciler {
+	return &OrphanReconciler{
+		broker:   broker,
+		cfClient: cfClient,
+		conf:     conf,
+		logger:   logger,
+		orphans:  map[string]*Orphan{},
+	}
+}
+
+// Run reconciles on start, so instances missing from the registry are backfilled, then every interval, and never returns
+func (r *OrphanReconciler) Run() {
+	ticker := time.NewTicker(r.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := r.Reconcile()
+		if err != nil {
+			r.logger.Error("Unable to reconcile orphaned instances ", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Reconcile finds the current orphans, backfilling the registry with instances the CF API confirms exist, and purging
+// confirmed orphans once they've been seen longer than the grace period
+func (r *OrphanReconciler) Reconcile() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	orphans, unrecorded, err := r.detect()
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range unrecorded {
+		err := r.backfill(candidate)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Unable to backfill the registry with instance %s ", candidate.InstanceID), err)
+		}
+	}
+
+	for key, orphan := range orphans {
+		if _, ok := r.orphans[key]; !ok {
+			r.logger.Info(fmt.Sprintf("Found orphaned instance %s in cluster %s", orphan.InstanceID, orphan.Cluster))
+		}
+	}
+
+	if r.conf.PurgeOrphans {
+		for key, orphan := range orphans {
+			if !orphan.Confirmed || time.Since(orphan.FirstSeen) < r.conf.GracePeriod {
+				continue
+			}
+			err := r.purge(orphan)
+			if err != nil {
+				r.logger.Error(fmt.Sprintf("Unable to purge orphaned instance %s ", orphan.InstanceID), err)
+				continue
+			}
+			delete(orphans, key)
+		}
+	}
+
+	r.orphans = orphans
+	return nil
+}
+
+// Detect finds the current orphans without backfilling or purging anything
+func (r *OrphanReconciler) Detect() ([]*Orphan, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	orphans, _, err := r.detect()
+	if err != nil {
+		return nil, err
+	}
+	return sortOrphans(orphans), nil
+}
+
+// Orphans returns the orphans found by the last reconcile
+func (r *OrphanReconciler) Orphans() []*Orphan {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return sortOrphans(r.orphans)
+}
+
+// OrphansHandler reports the current orphans, leaving backfilling and purging to Run
+func (r *OrphanReconciler) OrphansHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		orphans, err := r.Detect()
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"orphans": orphans,
+		})
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+// detect returns the orphans, keeping when they were first seen from the last reconcile, and the instances the
+// CF API knows of that have no record yet
+func (r *OrphanReconciler) detect() (map[string]*Orphan, []*Orphan, error) {
+	if r.broker.instanceStore == nil && r.cfClient == nil {
+		return nil, nil, errors.New("reconciling needs either the instance registry or the CF API")
+	}
+
+	candidates, err := r.findCandidates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orphans := map[string]*Orphan{}
+	unrecorded := []*Orphan{}
+	for key, candidate := range candidates {
+		instance, err := r.broker.getInstanceRecord(candidate.InstanceID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if instance != nil {
+			continue
+		}
+
+		if r.cfClient != nil {
+			_, err := r.cfClient.GetServiceInstanceByGuid(candidate.InstanceID)
+			if err == nil {
+				if r.broker.instanceStore != nil && candidate.Namespace != "" {
+					unrecorded = append(unrecorded, candidate)
+				}
+				continue
+			}
+			if !cfclient.IsServiceInstanceNotFoundError(err) {
+				return nil, nil, err
+			}
+			candidate.Confirmed = true
+		}
+		if candidate.Namespace == "" {
+			// the release of an instance whose namespace is gone doesn't serve anything
+			candidate.Confirmed = true
+		}
+
+		if previous, ok := r.orphans[key]; ok {
+			candidate.FirstSeen = previous.FirstSeen
+		}
+		orphans[key] = candidate
+	}
+
+	return orphans, unrecorded, nil
+}
+
+func sortOrphans(orphanMap map[string]*Orphan) []*Orphan {
+	orphans := []*Orphan{}
+	for _, orphan := range orphanMap {
+		orphans = append(orphans, orphan)
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Cluster != orphans[j].Cluster {
+			return orphans[i].Cluster < orphans[j].Cluster
+		}
+		return orphans[i].InstanceID < orphans[j].InstanceID
+	})
+	return orphans
+}
+
+// findCandidates collects kibosh namespaces and releases per instance in each cluster
+func (r *OrphanReconciler) findCandidates() (map[string]*Orphan, error) {
+	clusters, err := r.broker.getAllClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := map[string]*Orphan{}
+	seenClusters := map[string]bool{}
+	now := time.Now().UTC()
+	for _, cluster := range clusters {
+		host := ""
+		if cluster.GetClientConfig() != nil {
+			host = cluster.GetClientConfig().Host
+		}
+		if seenClusters[host] {
+			continue
+		}
+		seenClusters[host] = true
+
+		candidate := func(instanceID string) *Orphan {
+			key := host + "/" + instanceID
+			if candidates[key] == nil {
+				candidates[key] = &Orphan{
+					InstanceID: instanceID,
+					Cluster:    host,
+					FirstSeen:  now,
+					cluster:    cluster,
+				}
+			}
+			return candidates[key]
+		}
+
+		namespaces, err := cluster.GetNamespaces()
+		if err != nil {
+			return nil, err
+		}
+		namespaceInstances := map[string]string{}
+		for _, namespace := range namespaces.Items {
+			instanceID := namespace.Labels["instanceID"]
+			if namespace.Labels["app.kubernetes.io/managed-by"] != "kibosh" || instanceID == "" || namespace.DeletionTimestamp != nil {
+				continue
+			}
+			orphan := candidate(instanceID)
+			orphan.Namespace = namespace.Name
+			orphan.serviceID = namespace.Labels["serviceID"]
+			orphan.planID = namespace.Labels["planID"]
+			namespaceInstances[namespace.Name] = instanceID
+		}
+
+		helmClient := r.broker.helmClientFactory.HelmClient(cluster)
+		releases, err := helmClient.ListReleases(helm.ReleaseListStatuses(orphanReleaseStatuses))
+		if err != nil {
+			return nil, err
+		}
+		// releases with configured names are only recognised while their namespace is around
+		for _, release := range releases.GetReleases() {
+			instanceID, ok := namespaceInstances[release.Namespace]
+			if !ok {
+				if !releaseNamePattern.MatchString(release.Name) || !strings.HasPrefix(release.Namespace, "kibosh-") {
+					continue
+				}
+				instanceID = strings.TrimPrefix(release.Namespace, "kibosh-")
+			}
+			candidate(instanceID).Release = release.Name
+		}
+	}
+
+	return candidates, nil
+}
+
+// backfill records an instance from its namespace labels, for instances provisioned before the registry that the
+// CF API confirms exist
+func (r *OrphanReconciler) backfill(candidate *Orphan) error {
+	charts, err := r.broker.GetChartsMap()
+	if err != nil {
+		return err
+	}
+	chart := charts[candidate.serviceID]
+	if chart == nil {
+		return errors.New(fmt.Sprintf("Chart not found for [%s]", candidate.serviceID))
+	}
+	planName := strings.TrimPrefix(candidate.planID, candidate.serviceID+"-")
+	if _, ok := chart.Plans[planName]; !ok {
+		return errors.New(fmt.Sprintf("Plan not found for [%s]", candidate.planID))
+	}
+
+	names := instanceNames{
+		Namespace:   candidate.Namespace,
+		ReleaseName: candidate.Release,
+	}
+	if names.ReleaseName == "" {
+		names.ReleaseName = defaultInstanceNames(candidate.InstanceID).ReleaseName
+	}
+	details := brokerapi.ProvisionDetails{
+		ServiceID: candidate.serviceID,
+		PlanID:    candidate.planID,
+	}
+	err = r.broker.recordInstance(chart, planName, candidate.InstanceID, names, details)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info(fmt.Sprintf("Backfilled the registry with instance %s in cluster %s", candidate.InstanceID, candidate.Cluster))
+	return nil
+}
+
+func (r *OrphanReconciler) purge(orphan *Orphan) error {
+	r.logger.Info(fmt.Sprintf("Purging orphaned instance %s in cluster %s", orphan.InstanceID, orphan.Cluster))
+
+	if orphan.Release != "" {
+		helmClient := r.broker.helmClientFactory.HelmClient(orphan.cluster)
+		_, err := helmClient.DeleteRelease(orphan.Release, helm.DeletePurge(true))
+		if err != nil && !isReleaseGone(err, orphan.Release) {
+			return err
+		}
+	}
+
+	if orphan.Namespace != "" {
+		err := orphan.cluster.DeleteNamespace(orphan.Namespace, &meta_v1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
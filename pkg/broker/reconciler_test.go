@@ -0,0 +1,383 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	"github.com/cf-platform-eng/kibosh/pkg/cf/cffakes"
+	my_config "github.com/cf-platform-eng/kibosh/pkg/config"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/helm/helmfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore/instancestorefakes"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	"github.com/cf-platform-eng/kibosh/pkg/repository/repositoryfakes"
+	"github.com/cloudfoundry-community/go-cfclient"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/sirupsen/logrus"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	hapi_chart "k8s.io/helm/pkg/proto/hapi/chart"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+	hapi_services "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var _ = Describe("orphan reconciler", func() {
+	var fixture *brokerFixture
+	var fakeHelmClient *helmfakes.FakeMyHelmClient
+	var fakeCluster *k8sfakes.FakeCluster
+	var fakeRepo *repositoryfakes.FakeRepository
+	var fakeInstanceStore *instancestorefakes.FakeInstanceStore
+	var fakeCFClient *cffakes.FakeClient
+	var broker *PksServiceBroker
+	var reconcilerConfig *my_config.ReconcilerConfig
+	var reconciler *OrphanReconciler
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeHelmClient = fixture.helmClient
+		fakeCluster = fixture.cluster
+		fakeCluster.GetClientConfigReturns(&rest.Config{Host: "https://k8s.example.com"})
+		fakeRepo = fixture.repo
+		fakeRepo.GetChartsReturns([]*my_helm.MyChart{}, nil)
+		fakeInstanceStore = newFakeInstanceStore()
+		fakeCFClient = &cffakes.FakeClient{}
+		fakeCFClient.GetServiceInstanceByGuidReturns(cfclient.ServiceInstance{}, cfclient.CloudFoundryError{Code: 60004})
+
+		fakeCluster.GetNamespacesReturns(&api_v1.NamespaceList{
+			Items: []api_v1.Namespace{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name: "kibosh-my-instance-guid",
+						Labels: map[string]string{
+							"instanceID":                   "my-instance-guid",
+							"app.kubernetes.io/managed-by": "kibosh",
+						},
+					},
+				},
+				{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "kube-system"},
+				},
+			},
+		}, nil)
+		fakeHelmClient.ListReleasesReturns(&hapi_services.ListReleasesResponse{
+			Releases: []*hapi_release.Release{
+				{Name: "k-5h5kntfw", Namespace: "kibosh-my-instance-guid"},
+				{Name: "kibosh-operator", Namespace: "kibosh-operator"},
+			},
+		}, nil)
+
+		broker = fixture.newBroker(nil, fakeInstanceStore)
+		reconcilerConfig = &my_config.ReconcilerConfig{
+			GracePeriod: time.Hour,
+		}
+		reconciler = NewOrphanReconciler(broker, fakeCFClient, reconcilerConfig, logrus.New())
+	})
+
+	It("reports namespace and release of unknown instance", func() {
+		err := reconciler.Reconcile()
+
+		Expect(err).To(BeNil())
+		orphans := reconciler.Orphans()
+		Expect(orphans).To(HaveLen(1))
+		Expect(orphans[0].InstanceID).To(Equal("my-instance-guid"))
+		Expect(orphans[0].Cluster).To(Equal("https://k8s.example.com"))
+		Expect(orphans[0].Namespace).To(Equal("kibosh-my-instance-guid"))
+		Expect(orphans[0].Release).To(Equal("k-5h5kntfw"))
+		Expect(orphans[0].Confirmed).To(BeTrue())
+
+		Expect(fakeInstanceStore.GetArgsForCall(0)).To(Equal("my-instance-guid"))
+		Expect(fakeCFClient.GetServiceInstanceByGuidArgsForCall(0)).To(Equal("my-instance-guid"))
+	})
+
+	It("finds releases with configured names by their namespace", func() {
+		fakeCluster.GetNamespacesReturns(&api_v1.NamespaceList{
+			Items: []api_v1.Namespace{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name: "svc-my-org-myinstan",
+						Labels: map[string]string{
+							"instanceID":                   "my-instance-guid",
+							"app.kubernetes.io/managed-by": "kibosh",
+						},
+					},
+				},
+			},
+		}, nil)
+		fakeHelmClient.ListReleasesReturns(&hapi_services.ListReleasesResponse{
+			Releases: []*hapi_release.Release{
+				{Name: "spacebears-myinstan", Namespace: "svc-my-org-myinstan"},
+			},
+		}, nil)
+
+		err := reconciler.Reconcile()
+
+		Expect(err).To(BeNil())
+		orphans := reconciler.Orphans()
+		Expect(orphans).To(HaveLen(1))
+		Expect(orphans[0].Namespace).To(Equal("svc-my-org-myinstan"))
+		Expect(orphans[0].Release).To(Equal("spacebears-myinstan"))
+	})
+
+	It("ignores instances in the registry", func() {
+		fakeInstanceStore.GetReturns(&instancestore.Instance{InstanceID: "my-instance-guid"}, nil)
+
+		err := reconciler.Reconcile()
+
+		Expect(err).To(BeNil())
+		Expect(reconciler.Orphans()).To(BeEmpty())
+		Expect(fakeCFClient.GetServiceInstanceByGuidCallCount()).To(Equal(0))
+	})
+
+	It("ignores instances known to cf", func() {
+		fakeCFClient.GetServiceInstanceByGuidReturns(cfclient.ServiceInstance{Guid: "my-instance-guid"}, nil)
+
+		err := reconciler.Reconcile()
+
+		Expect(err).To(BeNil())
+		Expect(reconciler.Orphans()).To(BeEmpty())
+	})
+
+	It("returns error when unable to ask cf", func() {
+		fakeCFClient.GetServiceInstanceByGuidReturns(cfclient.ServiceInstance{}, errors.New("cf unavailable"))
+
+		err := reconciler.Reconcile()
+
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("requires a registry or cf to reconcile against", func() {
+		broker = fixture.newBroker(nil, nil)
+		reconciler = NewOrphanReconciler(broker, nil, reconcilerConfig, logrus.New())
+
+		err := reconciler.Reconcile()
+
+		Expect(err).NotTo(BeNil())
+		Expect(fakeCluster.GetNamespacesCallCount()).To(Equal(0))
+	})
+
+	It("doesn't purge by default", func() {
+		reconcilerConfig.GracePeriod = 0
+
+		err := reconciler.Reconcile()
+
+		Expect(err).To(BeNil())
+		Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(0))
+		Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+	})
+
+	Context("purge", func() {
+		BeforeEach(func() {
+			reconcilerConfig.PurgeOrphans = true
+		})
+
+		It("keeps orphans during the grace period", func() {
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(reconciler.Orphans()).To(HaveLen(1))
+			Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(0))
+		})
+
+		It("purges orphans after the grace period", func() {
+			reconcilerConfig.GracePeriod = 0
+
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(reconciler.Orphans()).To(BeEmpty())
+			Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(1))
+			releaseName, opts := fakeHelmClient.DeleteReleaseArgsForCall(0)
+			Expect(releaseName).To(Equal("k-5h5kntfw"))
+			Expect(opts).To(HaveLen(1))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(1))
+			namespace, _ := fakeCluster.DeleteNamespaceArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		})
+
+		It("keeps reporting orphans it couldn't purge", func() {
+			reconcilerConfig.GracePeriod = 0
+			fakeHelmClient.DeleteReleaseReturns(nil, errors.New("tiller unavailable"))
+
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(reconciler.Orphans()).To(HaveLen(1))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("instances provisioned before the registry", func() {
+		serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+		BeforeEach(func() {
+			reconcilerConfig.PurgeOrphans = true
+			reconcilerConfig.GracePeriod = 0
+			reconciler = NewOrphanReconciler(broker, nil, reconcilerConfig, logrus.New())
+
+			fakeRepo.GetChartsReturns([]*my_helm.MyChart{
+				{
+					Chart: hapi_chart.Chart{
+						Metadata: &hapi_chart.Metadata{
+							Name:    "spacebears",
+							Version: "1.0.0",
+						},
+					},
+					Plans: map[string]my_helm.Plan{
+						"small": {Name: "small"},
+					},
+				},
+			}, nil)
+			fakeCluster.GetNamespacesReturns(&api_v1.NamespaceList{
+				Items: []api_v1.Namespace{
+					{
+						ObjectMeta: meta_v1.ObjectMeta{
+							Name: "kibosh-my-instance-guid",
+							Labels: map[string]string{
+								"serviceID":                    serviceID,
+								"planID":                       serviceID + "-small",
+								"instanceID":                   "my-instance-guid",
+								"app.kubernetes.io/managed-by": "kibosh",
+							},
+						},
+					},
+				},
+			}, nil)
+		})
+
+		It("backfills the registry from the namespace labels of instances cf confirms exist", func() {
+			fakeCFClient.GetServiceInstanceByGuidReturns(cfclient.ServiceInstance{Guid: "my-instance-guid"}, nil)
+			reconciler = NewOrphanReconciler(broker, fakeCFClient, reconcilerConfig, logrus.New())
+
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(reconciler.Orphans()).To(BeEmpty())
+			Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(0))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+
+			Expect(fakeInstanceStore.SaveCallCount()).To(Equal(1))
+			instance := fakeInstanceStore.SaveArgsForCall(0)
+			Expect(instance.InstanceID).To(Equal("my-instance-guid"))
+			Expect(instance.ServiceID).To(Equal(serviceID))
+			Expect(instance.PlanID).To(Equal(serviceID + "-small"))
+			Expect(instance.ChartName).To(Equal("spacebears"))
+			Expect(instance.Namespace).To(Equal("kibosh-my-instance-guid"))
+			Expect(instance.ReleaseName).To(Equal("k-5h5kntfw"))
+		})
+
+		It("doesn't purge instances cf knows of it couldn't backfill", func() {
+			fakeCFClient.GetServiceInstanceByGuidReturns(cfclient.ServiceInstance{Guid: "my-instance-guid"}, nil)
+			reconciler = NewOrphanReconciler(broker, fakeCFClient, reconcilerConfig, logrus.New())
+			fakeRepo.GetChartsReturns([]*my_helm.MyChart{}, nil)
+
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(reconciler.Orphans()).To(BeEmpty())
+			Expect(fakeInstanceStore.SaveCallCount()).To(Equal(0))
+			Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(0))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+		})
+
+		It("only reports instances without a record when there's no cf to confirm them", func() {
+			err := reconciler.Reconcile()
+			Expect(err).To(BeNil())
+
+			err = reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			orphans := reconciler.Orphans()
+			Expect(orphans).To(HaveLen(1))
+			Expect(orphans[0].Confirmed).To(BeFalse())
+			Expect(fakeInstanceStore.SaveCallCount()).To(Equal(0))
+			Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(0))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+		})
+
+		It("purges releases whose namespace is gone without cf", func() {
+			fakeCluster.GetNamespacesReturns(&api_v1.NamespaceList{}, nil)
+
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(1))
+			releaseName, _ := fakeHelmClient.DeleteReleaseArgsForCall(0)
+			Expect(releaseName).To(Equal("k-5h5kntfw"))
+		})
+
+		It("purges instances cf confirms are gone without backfilling them", func() {
+			reconciler = NewOrphanReconciler(broker, fakeCFClient, reconcilerConfig, logrus.New())
+
+			err := reconciler.Reconcile()
+
+			Expect(err).To(BeNil())
+			Expect(fakeInstanceStore.SaveCallCount()).To(Equal(0))
+			Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(1))
+		})
+	})
+
+	It("serves orphans as json", func() {
+		req, err := http.NewRequest("GET", "/orphans", nil)
+		Expect(err).To(BeNil())
+		recorder := httptest.NewRecorder()
+
+		reconciler.OrphansHandler().ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(200))
+		body := map[string][]map[string]interface{}{}
+		err = json.Unmarshal(recorder.Body.Bytes(), &body)
+		Expect(err).To(BeNil())
+		Expect(body["orphans"]).To(HaveLen(1))
+		Expect(body["orphans"][0]["instanceID"]).To(Equal("my-instance-guid"))
+	})
+
+	It("doesn't purge or backfill when serving orphans", func() {
+		reconcilerConfig.PurgeOrphans = true
+		reconcilerConfig.GracePeriod = 0
+		req, err := http.NewRequest("GET", "/orphans", nil)
+		Expect(err).To(BeNil())
+		recorder := httptest.NewRecorder()
+
+		reconciler.OrphansHandler().ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(200))
+		Expect(fakeHelmClient.DeleteReleaseCallCount()).To(Equal(0))
+		Expect(fakeCluster.DeleteNamespaceCallCount()).To(Equal(0))
+		Expect(fakeInstanceStore.SaveCallCount()).To(Equal(0))
+		Expect(reconciler.Orphans()).To(BeEmpty())
+	})
+
+	It("serves reconcile errors", func() {
+		fakeCluster.GetNamespacesReturns(nil, errors.New("forbidden"))
+		req, err := http.NewRequest("GET", "/orphans", nil)
+		Expect(err).To(BeNil())
+		recorder := httptest.NewRecorder()
+
+		reconciler.OrphansHandler().ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(500))
+	})
+})
@@ -0,0 +1,163 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	"github.com/pivotal-cf/brokerapi"
+	"k8s.io/helm/pkg/helm"
+	hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// tiller describes rollbacks as "Rollback to N", and replaces that with "Rollback "name" failed: ..." on failure
+const rollbackDescriptionPrefix = "Rollback"
+const rollbackReadinessTimeout = 10 * time.Minute
+
+// rollbackEnabled is whether the plan the instance was on before the update opted in to rolling back failed
+// updates. The record and the namespace's planID label keep that plan until the update succeeds.
+func (broker *PksServiceBroker) rollbackEnabled(cluster k8s.Cluster, instanceID string, serviceID string, planID string) (bool, error) {
+	instance, err := broker.getInstanceRecord(instanceID)
+	if err != nil {
+		return false, err
+	}
+	if instance != nil {
+		serviceID = instance.ServiceID
+		planID = instance.PlanID
+	} else {
+		namespaceName, err := broker.getNamespace(instanceID)
+		if err != nil {
+			return false, err
+		}
+		namespace, err := cluster.GetNamespace(namespaceName, nil)
+		if err != nil {
+			return false, err
+		}
+		if namespace != nil && namespace.Labels["planID"] != "" {
+			serviceID = namespace.Labels["serviceID"]
+			planID = namespace.Labels["planID"]
+		}
+	}
+
+	charts, err := broker.GetChartsMap()
+	if err != nil {
+		return false, err
+	}
+	chart := charts[serviceID]
+	if chart == nil {
+		return false, nil
+	}
+	return chart.Plans[strings.TrimPrefix(planID, serviceID+"-")].RollbackOnFailure, nil
+}
+
+// rollback returns the release to the last revision deployed before the operation, along with the limits and network
+// policies of the plan it was on
+func (broker *PksServiceBroker) rollback(cluster k8s.Cluster, helmClient my_helm.MyHelmClient, instanceID string, op operation, reason string) (brokerapi.LastOperation, error) {
+	releaseName, err := broker.getReleaseName(instanceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
+	history, err := helmClient.ReleaseHistory(releaseName, helm.WithMaxHistory(releaseHistoryMax))
+	if err != nil {
+		return brokerapi.LastOperation{}, err
+	}
+
+	var target int32
+	for _, release := range history.GetReleases() {
+		code := release.GetInfo().GetStatus().GetCode()
+		deployed := code == hapi_release.Status_DEPLOYED || code == hapi_release.Status_SUPERSEDED
+		if deployed && release.Version < op.Revision && release.Version > target {
+			target = release.Version
+		}
+	}
+	if target == 0 {
+		return brokerapi.LastOperation{
+			State:       brokerapi.Failed,
+			Description: fmt.Sprintf("%s %s, and there's no earlier revision to roll back to", op.Type, reason),
+		}, nil
+	}
+
+	broker.logger.Info(fmt.Sprintf("Rolling back instance %s to revision %d: %s %s", instanceID, target, op.Type, reason))
+	_, err = helmClient.RollbackRelease(
+		releaseName,
+		helm.RollbackVersion(target),
+		helm.RollbackDescription(fmt.Sprintf("%s to %d after revision %d %s", rollbackDescriptionPrefix, target, op.Revision, reason)),
+	)
+	if err != nil {
+		return brokerapi.LastOperation{
+			State:       brokerapi.Failed,
+			Description: fmt.Sprintf("%s %s, and rolling back to revision %d failed: %v", op.Type, reason, target, err),
+		}, nil
+	}
+
+	err = broker.abandonMigration(cluster, instanceID, op)
+	if err != nil {
+		return brokerapi.LastOperation{
+			State:       brokerapi.Failed,
+			Description: fmt.Sprintf("%s %s, and restoring the previous plan failed: %v", op.Type, reason, err),
+		}, nil
+	}
+
+	return brokerapi.LastOperation{
+		State:       brokerapi.InProgress,
+		Description: fmt.Sprintf("%s %s, rolling back to revision %d", op.Type, reason, target),
+	}, nil
+}
+
+// rollbackState reports on a rollback of the operation's revision, and is false when it hasn't been rolled back
+func (broker *PksServiceBroker) rollbackState(helmClient my_helm.MyHelmClient, instanceID string, op operation) (brokerapi.LastOperation, bool, error) {
+	releaseName, err := broker.getReleaseName(instanceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, false, err
+	}
+	history, err := helmClient.ReleaseHistory(releaseName, helm.WithMaxHistory(releaseHistoryMax))
+	if err != nil {
+		return brokerapi.LastOperation{}, false, err
+	}
+
+	for _, release := range history.GetReleases() {
+		description := release.GetInfo().GetDescription()
+		if release.Version != op.Revision+1 || !strings.HasPrefix(description, rollbackDescriptionPrefix) {
+			continue
+		}
+
+		var target int32
+		fmt.Sscanf(description, rollbackDescriptionPrefix+" to %d", &target)
+		switch release.GetInfo().GetStatus().GetCode() {
+		case hapi_release.Status_DEPLOYED, hapi_release.Status_SUPERSEDED:
+			return brokerapi.LastOperation{
+				State:       brokerapi.Failed,
+				Description: fmt.Sprintf("%s failed and the instance was rolled back to revision %d", op.Type, target),
+			}, true, nil
+		case hapi_release.Status_PENDING_ROLLBACK:
+			return brokerapi.LastOperation{
+				State:       brokerapi.InProgress,
+				Description: fmt.Sprintf("%s failed, rolling back to revision %d", op.Type, target),
+			}, true, nil
+		default:
+			return brokerapi.LastOperation{
+				State:       brokerapi.Failed,
+				Description: fmt.Sprintf("%s failed, and rolling back failed: %s", op.Type, description),
+			}, true, nil
+		}
+	}
+
+	return brokerapi.LastOperation{}, false, nil
+}
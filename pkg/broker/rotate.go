@@ -0,0 +1,169 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/pkg/errors"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RotateCredentials re-renders the binding's credentials, writing a new version to CredHub when it's configured.
+// Charts with a rotate hook get it run to completion first, and until then the hook's progress is returned.
+// Without either, re-rendering changes nothing the app sees, so the request is rejected. The instance is locked
+// for each call, so rotation doesn't overlap other operations on the instance or its pending bindings.
+func (broker *PksServiceBroker) RotateCredentials(instanceID string, bindingID string) (brokerapi.LastOperation, map[string]interface{}, error) {
+	token, err := broker.lockInstance(instanceID, rotateOperation)
+	if err != nil {
+		return brokerapi.LastOperation{}, nil, err
+	}
+	defer broker.unlockInstance(instanceID, token)
+
+	return broker.rotateCredentials(instanceID, bindingID)
+}
+
+func (broker *PksServiceBroker) rotateCredentials(instanceID string, bindingID string) (brokerapi.LastOperation, map[string]interface{}, error) {
+	cluster, namespace, err := broker.findInstance(instanceID)
+	if err != nil {
+		return brokerapi.LastOperation{}, nil, err
+	}
+
+	binding, err := cluster.GetConfigMap(namespace.Name, broker.getBindingConfigMapName(bindingID), meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return brokerapi.LastOperation{}, nil, brokerapi.ErrBindingNotFound
+		}
+		return brokerapi.LastOperation{}, nil, err
+	}
+
+	serviceID := binding.Data["serviceID"]
+	chartsMap, err := broker.GetChartsMap()
+	if err != nil {
+		return brokerapi.LastOperation{}, nil, err
+	}
+	chart, ok := chartsMap[serviceID]
+	if !ok {
+		return brokerapi.LastOperation{}, nil, errors.New(fmt.Sprintf("service %s not found ", serviceID))
+	}
+	if broker.credstore == nil && !hasRotateHook(chart) {
+		return brokerapi.LastOperation{}, nil, brokerapi.NewFailureResponse(
+			errors.New(fmt.Sprintf("rotating credentials of service %s requires CredHub or a rotate hook", serviceID)),
+			http.StatusUnprocessableEntity, "rotate-credentials-unsupported",
+		)
+	}
+
+	if hasRotateHook(chart) {
+		lastOperation, done, err := broker.runBindHook(cluster, instanceID, bindingID, binding.Data["appGUID"], rotateOperation, chart.BindHooks.Rotate)
+		if err != nil {
+			return brokerapi.LastOperation{}, nil, err
+		}
+		if lastOperation.State == brokerapi.Failed {
+			// the next request starts the hook over
+			err = broker.deleteBindHookJob(cluster, instanceID, bindingID, rotateOperation)
+			if err != nil {
+				return brokerapi.LastOperation{}, nil, err
+			}
+		}
+		if !done {
+			return lastOperation, nil, nil
+		}
+	}
+
+	credentials, err := broker.getCredentials(cluster, chart, instanceID, bindingID, binding.Data)
+	if err != nil {
+		return brokerapi.LastOperation{}, nil, err
+	}
+
+	if broker.credstore != nil {
+		credentialName := broker.getCredentialName(broker.getServiceName(chart), bindingID)
+		_, err := broker.credstore.Put(credentialName, credentials)
+		if err != nil {
+			return brokerapi.LastOperation{}, nil, err
+		}
+		credentials = map[string]interface{}{
+			"credhub-ref": credentialName,
+		}
+	}
+
+	if hasRotateHook(chart) {
+		err = broker.deleteBindHookJob(cluster, instanceID, bindingID, rotateOperation)
+		if err != nil {
+			return brokerapi.LastOperation{}, nil, err
+		}
+	}
+
+	broker.logger.Info(fmt.Sprintf("Rotated credentials of binding %s to instance %s", bindingID, instanceID))
+	return brokerapi.LastOperation{
+		State:       brokerapi.Succeeded,
+		Description: "credentials rotated",
+	}, credentials, nil
+}
+
+// RotateCredentialsHandler rotates the credentials of the binding_id binding to the instance_id instance. It
+// responds 202 while the rotate hook runs, so it's called again until it responds 200.
+func (broker *PksServiceBroker) RotateCredentialsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		instanceID := req.URL.Query().Get("instance_id")
+		bindingID := req.URL.Query().Get("binding_id")
+		if instanceID == "" || bindingID == "" {
+			w.WriteHeader(400)
+			w.Write([]byte("instance_id and binding_id are required"))
+			return
+		}
+
+		lastOperation, credentials, err := broker.RotateCredentials(instanceID, bindingID)
+		if err != nil {
+			broker.logger.Error(fmt.Sprintf("Unable to rotate credentials of binding %s ", bindingID), err)
+			code := 500
+			if failure, ok := err.(*brokerapi.FailureResponse); ok {
+				code = failure.ValidatedStatusCode(nil)
+			}
+			w.WriteHeader(code)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"state":       lastOperation.State,
+			"description": lastOperation.Description,
+			"credentials": credentials,
+		})
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch lastOperation.State {
+		case brokerapi.InProgress:
+			w.WriteHeader(http.StatusAccepted)
+		case brokerapi.Failed:
+			w.WriteHeader(500)
+		}
+		w.Write(body)
+	})
+}
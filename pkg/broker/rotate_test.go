@@ -0,0 +1,222 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/broker"
+	"github.com/cf-platform-eng/kibosh/pkg/credstore/credstorefakes"
+	my_helm "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	batch_v1 "k8s.io/api/batch/v1"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("rotate credentials", func() {
+	serviceID := uuid.NewSHA1(uuid.NameSpace_OID, []byte("spacebears")).String()
+
+	var fixture *brokerFixture
+	var fakeCluster *k8sfakes.FakeCluster
+	var chart *my_helm.MyChart
+	var broker *PksServiceBroker
+
+	BeforeEach(func() {
+		fixture = newBrokerFixture()
+		fakeCluster = fixture.cluster
+		chart = fixture.chart
+		chart.BindTemplate = `{password: $.secrets[0].data.password}`
+
+		fakeCluster.GetNamespaceReturns(&api_v1.Namespace{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-my-instance-guid"},
+		}, nil)
+		fakeCluster.GetConfigMapReturns(&api_v1.ConfigMap{
+			Data: map[string]string{"serviceID": serviceID, "appGUID": "my-app-guid"},
+		}, nil)
+		fakeCluster.GetSecretsAndServicesReturns(map[string][]map[string]interface{}{
+			"secrets":  {{"name": "spacebears-admin", "data": map[string]interface{}{"password": "rotated"}}},
+			"services": {},
+		}, nil)
+
+		broker = fixture.newBroker(nil, nil)
+	})
+
+	It("rejects rotation without credhub or a rotate hook", func() {
+		_, credentials, err := broker.RotateCredentials("my-instance-guid", "my-binding-id")
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.(*brokerapi.FailureResponse).ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+		Expect(err.Error()).To(ContainSubstring("requires CredHub or a rotate hook"))
+		Expect(credentials).To(BeNil())
+		Expect(fakeCluster.GetSecretsAndServicesCallCount()).To(Equal(0))
+	})
+
+	It("writes a new version to credhub", func() {
+		fakeCredStore := &credstorefakes.FakeCredStore{}
+		broker = fixture.newBroker(fakeCredStore, nil)
+
+		_, credentials, err := broker.RotateCredentials("my-instance-guid", "my-binding-id")
+
+		Expect(err).To(BeNil())
+		Expect(fakeCredStore.PutCallCount()).To(Equal(1))
+		name, value := fakeCredStore.PutArgsForCall(0)
+		Expect(name).To(Equal("/c/kibosh/spacebears/my-binding-id/secrets-and-services"))
+		Expect(value).To(Equal(map[string]interface{}{"password": "rotated"}))
+		Expect(credentials).To(Equal(map[string]interface{}{"credhub-ref": name}))
+		Expect(fakeCluster.CreateJobCallCount()).To(Equal(0))
+	})
+
+	It("returns not found for unknown bindings", func() {
+		fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-binding-my-binding-id"))
+
+		_, _, err := broker.RotateCredentials("my-instance-guid", "my-binding-id")
+
+		Expect(err).To(Equal(brokerapi.ErrBindingNotFound))
+	})
+
+	Context("rotate hook", func() {
+		BeforeEach(func() {
+			chart.BindHooks = &my_helm.BindHooks{
+				Rotate: &batch_v1.JobSpec{
+					Template: api_v1.PodTemplateSpec{
+						Spec: api_v1.PodSpec{
+							Containers: []api_v1.Container{{Name: "hook", Image: "rotate-password"}},
+						},
+					},
+				},
+			}
+		})
+
+		It("starts the hook before rotating", func() {
+			fakeCluster.GetJobReturns(nil, k8s_errors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, "kibosh-rotate-hook-my-binding-id"))
+
+			lastOperation, credentials, err := broker.RotateCredentials("my-instance-guid", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.InProgress))
+			Expect(credentials).To(BeNil())
+			Expect(fakeCluster.CreateJobCallCount()).To(Equal(1))
+			_, job := fakeCluster.CreateJobArgsForCall(0)
+			Expect(job.Name).To(Equal("kibosh-rotate-hook-my-binding-id"))
+		})
+
+		It("rotates once the hook has completed, and clears it for the next rotation", func() {
+			fakeCluster.GetJobReturns(&batch_v1.Job{
+				Status: batch_v1.JobStatus{
+					Conditions: []batch_v1.JobCondition{{Type: batch_v1.JobComplete, Status: api_v1.ConditionTrue}},
+				},
+			}, nil)
+
+			lastOperation, credentials, err := broker.RotateCredentials("my-instance-guid", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.Succeeded))
+			Expect(credentials).To(Equal(map[string]interface{}{"password": "rotated"}))
+			Expect(fakeCluster.DeleteJobCallCount()).To(Equal(1))
+			_, jobName, _ := fakeCluster.DeleteJobArgsForCall(0)
+			Expect(jobName).To(Equal("kibosh-rotate-hook-my-binding-id"))
+		})
+
+		It("clears a failed hook so it can be retried", func() {
+			fakeCluster.GetJobReturns(&batch_v1.Job{
+				Status: batch_v1.JobStatus{
+					Conditions: []batch_v1.JobCondition{{Type: batch_v1.JobFailed, Status: api_v1.ConditionTrue}},
+				},
+			}, nil)
+
+			lastOperation, credentials, err := broker.RotateCredentials("my-instance-guid", "my-binding-id")
+
+			Expect(err).To(BeNil())
+			Expect(lastOperation.State).To(Equal(brokerapi.Failed))
+			Expect(credentials).To(BeNil())
+			Expect(fakeCluster.DeleteJobCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("handler", func() {
+		BeforeEach(func() {
+			broker = fixture.newBroker(&credstorefakes.FakeCredStore{}, nil)
+		})
+
+		It("serves the rotated credentials", func() {
+			req, err := http.NewRequest("POST", "/rotate_credentials?instance_id=my-instance-guid&binding_id=my-binding-id", nil)
+			Expect(err).To(BeNil())
+			recorder := httptest.NewRecorder()
+
+			broker.RotateCredentialsHandler().ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(200))
+			body := map[string]interface{}{}
+			err = json.Unmarshal(recorder.Body.Bytes(), &body)
+			Expect(err).To(BeNil())
+			Expect(body["state"]).To(Equal("succeeded"))
+			Expect(body["credentials"]).To(Equal(map[string]interface{}{
+				"credhub-ref": "/c/kibosh/spacebears/my-binding-id/secrets-and-services",
+			}))
+		})
+
+		It("serves unprocessable entity when there's nothing to rotate", func() {
+			broker = fixture.newBroker(nil, nil)
+			req, err := http.NewRequest("POST", "/rotate_credentials?instance_id=my-instance-guid&binding_id=my-binding-id", nil)
+			Expect(err).To(BeNil())
+			recorder := httptest.NewRecorder()
+
+			broker.RotateCredentialsHandler().ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(422))
+		})
+
+		It("requires the binding", func() {
+			req, err := http.NewRequest("POST", "/rotate_credentials?instance_id=my-instance-guid", nil)
+			Expect(err).To(BeNil())
+			recorder := httptest.NewRecorder()
+
+			broker.RotateCredentialsHandler().ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("only accepts posts", func() {
+			req, err := http.NewRequest("GET", "/rotate_credentials?instance_id=my-instance-guid&binding_id=my-binding-id", nil)
+			Expect(err).To(BeNil())
+			recorder := httptest.NewRecorder()
+
+			broker.RotateCredentialsHandler().ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(405))
+		})
+
+		It("serves not found for unknown bindings", func() {
+			fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-binding-my-binding-id"))
+			req, err := http.NewRequest("POST", "/rotate_credentials?instance_id=my-instance-guid&binding_id=my-binding-id", nil)
+			Expect(err).To(BeNil())
+			recorder := httptest.NewRecorder()
+
+			broker.RotateCredentialsHandler().ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(404))
+		})
+	})
+})
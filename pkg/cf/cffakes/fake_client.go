@@ -46,6 +46,19 @@ type FakeClient struct {
 		result1 cfclient.ServiceBroker
 		result2 error
 	}
+	GetServiceInstanceByGuidStub        func(string) (cfclient.ServiceInstance, error)
+	getServiceInstanceByGuidMutex       sync.RWMutex
+	getServiceInstanceByGuidArgsForCall []struct {
+		arg1 string
+	}
+	getServiceInstanceByGuidReturns struct {
+		result1 cfclient.ServiceInstance
+		result2 error
+	}
+	getServiceInstanceByGuidReturnsOnCall map[int]struct {
+		result1 cfclient.ServiceInstance
+		result2 error
+	}
 	UpdateServiceBrokerStub        func(string, cfclient.UpdateServiceBrokerRequest) (cfclient.ServiceBroker, error)
 	updateServiceBrokerMutex       sync.RWMutex
 	updateServiceBrokerArgsForCall []struct {
@@ -250,6 +263,69 @@ func (fake *FakeClient) GetServiceBrokerByNameReturnsOnCall(i int, result1 cfcli
 	}{result1, result2}
 }
 
+func (fake *FakeClient) GetServiceInstanceByGuid(arg1 string) (cfclient.ServiceInstance, error) {
+	fake.getServiceInstanceByGuidMutex.Lock()
+	ret, specificReturn := fake.getServiceInstanceByGuidReturnsOnCall[len(fake.getServiceInstanceByGuidArgsForCall)]
+	fake.getServiceInstanceByGuidArgsForCall = append(fake.getServiceInstanceByGuidArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetServiceInstanceByGuid", []interface{}{arg1})
+	fake.getServiceInstanceByGuidMutex.Unlock()
+	if fake.GetServiceInstanceByGuidStub != nil {
+		return fake.GetServiceInstanceByGuidStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getServiceInstanceByGuidReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetServiceInstanceByGuidCallCount() int {
+	fake.getServiceInstanceByGuidMutex.RLock()
+	defer fake.getServiceInstanceByGuidMutex.RUnlock()
+	return len(fake.getServiceInstanceByGuidArgsForCall)
+}
+
+func (fake *FakeClient) GetServiceInstanceByGuidCalls(stub func(string) (cfclient.ServiceInstance, error)) {
+	fake.getServiceInstanceByGuidMutex.Lock()
+	defer fake.getServiceInstanceByGuidMutex.Unlock()
+	fake.GetServiceInstanceByGuidStub = stub
+}
+
+func (fake *FakeClient) GetServiceInstanceByGuidArgsForCall(i int) string {
+	fake.getServiceInstanceByGuidMutex.RLock()
+	defer fake.getServiceInstanceByGuidMutex.RUnlock()
+	argsForCall := fake.getServiceInstanceByGuidArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetServiceInstanceByGuidReturns(result1 cfclient.ServiceInstance, result2 error) {
+	fake.getServiceInstanceByGuidMutex.Lock()
+	defer fake.getServiceInstanceByGuidMutex.Unlock()
+	fake.GetServiceInstanceByGuidStub = nil
+	fake.getServiceInstanceByGuidReturns = struct {
+		result1 cfclient.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetServiceInstanceByGuidReturnsOnCall(i int, result1 cfclient.ServiceInstance, result2 error) {
+	fake.getServiceInstanceByGuidMutex.Lock()
+	defer fake.getServiceInstanceByGuidMutex.Unlock()
+	fake.GetServiceInstanceByGuidStub = nil
+	if fake.getServiceInstanceByGuidReturnsOnCall == nil {
+		fake.getServiceInstanceByGuidReturnsOnCall = make(map[int]struct {
+			result1 cfclient.ServiceInstance
+			result2 error
+		})
+	}
+	fake.getServiceInstanceByGuidReturnsOnCall[i] = struct {
+		result1 cfclient.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) UpdateServiceBroker(arg1 string, arg2 cfclient.UpdateServiceBrokerRequest) (cfclient.ServiceBroker, error) {
 	fake.updateServiceBrokerMutex.Lock()
 	ret, specificReturn := fake.updateServiceBrokerReturnsOnCall[len(fake.updateServiceBrokerArgsForCall)]
@@ -323,6 +399,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.deleteServiceBrokerMutex.RUnlock()
 	fake.getServiceBrokerByNameMutex.RLock()
 	defer fake.getServiceBrokerByNameMutex.RUnlock()
+	fake.getServiceInstanceByGuidMutex.RLock()
+	defer fake.getServiceInstanceByGuidMutex.RUnlock()
 	fake.updateServiceBrokerMutex.RLock()
 	defer fake.updateServiceBrokerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -23,4 +23,5 @@ type Client interface {
 	CreateServiceBroker(csb cfclient.CreateServiceBrokerRequest) (cfclient.ServiceBroker, error)
 	UpdateServiceBroker(guid string, csb cfclient.UpdateServiceBrokerRequest) (cfclient.ServiceBroker, error)
 	DeleteServiceBroker(guid string) error
+	GetServiceInstanceByGuid(guid string) (cfclient.ServiceInstance, error)
 }
@@ -16,6 +16,7 @@
 package config
 
 import (
+	"github.com/Masterminds/sprig"
 	"github.com/cf-platform-eng/kibosh/pkg/moreio"
 	"github.com/kelseyhightower/envconfig"
 
@@ -24,6 +25,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"text/template"
+	"time"
 )
 
 type ClusterCredentials struct {
@@ -66,6 +69,31 @@ type CredStoreConfig struct {
 	CaCertFile        string `envconfig:"CH_CA_CERT_FILE"`
 }
 
+type InstanceStoreConfig struct {
+	Backend string `envconfig:"INSTANCE_STORE" default:"kubernetes"`
+	Dir     string `envconfig:"INSTANCE_STORE_DIR" default:"instances"`
+}
+
+type ReconcilerConfig struct {
+	Interval     time.Duration `envconfig:"RECONCILE_INTERVAL" default:"10m"`
+	PurgeOrphans bool          `envconfig:"PURGE_ORPHANS"`
+	GracePeriod  time.Duration `envconfig:"ORPHAN_GRACE_PERIOD" default:"24h"`
+}
+
+// NodeAddressConfig picks the addresses bindings reach NodePort services on. Each node contributes its first
+// address of the types, in order of preference, and NodeSelector narrows down which nodes are used.
+type NodeAddressConfig struct {
+	AddressTypes []string `envconfig:"NODE_ADDRESS_TYPES" default:"ExternalIP,InternalIP,Hostname"`
+	NodeSelector string   `envconfig:"NODE_SELECTOR"`
+}
+
+// NamingConfig has the Go templates the namespace and release name of new instances are rendered with. Empty
+// templates keep the kibosh-<instance id> namespace and hashed release name.
+type NamingConfig struct {
+	NamespaceTemplate   string `envconfig:"NAMESPACE_TEMPLATE"`
+	ReleaseNameTemplate string `envconfig:"RELEASE_NAME_TEMPLATE"`
+}
+
 type Config struct {
 	AdminUsername string `envconfig:"SECURITY_USER_NAME" required:"true"`
 	AdminPassword string `envconfig:"SECURITY_USER_PASSWORD" required:"true"`
@@ -76,11 +104,21 @@ type Config struct {
 	TillerNamespace string `envconfig:"TILLER_NAMESPACE" default:"kube-system"`
 	TillerSHA       string `envconfig:"TILLER_IMAGE_SHA"`
 
-	ClusterCredentials *ClusterCredentials
-	RegistryConfig     *RegistryConfig
-	CFClientConfig     *CFClientConfig
-	HelmTLSConfig      *HelmTLSConfig
-	CredStoreConfig    *CredStoreConfig
+	// NetworkIsolation isolates the namespaces of every plan, as if each plan set networkIsolation
+	NetworkIsolation bool `envconfig:"NETWORK_ISOLATION"`
+
+	// OperationLockTimeout is how long an instance operation that's never polled to completion blocks others
+	OperationLockTimeout time.Duration `envconfig:"OPERATION_LOCK_TIMEOUT" default:"2h"`
+
+	ClusterCredentials  *ClusterCredentials
+	RegistryConfig      *RegistryConfig
+	CFClientConfig      *CFClientConfig
+	HelmTLSConfig       *HelmTLSConfig
+	CredStoreConfig     *CredStoreConfig
+	InstanceStoreConfig *InstanceStoreConfig
+	ReconcilerConfig    *ReconcilerConfig
+	NodeAddressConfig   *NodeAddressConfig
+	NamingConfig        *NamingConfig
 }
 
 func (r RegistryConfig) HasRegistryConfig() bool {
@@ -99,6 +137,10 @@ func (c *CredStoreConfig) HasCredHubConfig() bool {
 	return c.CredHubURL != ""
 }
 
+func (c *InstanceStoreConfig) UsesFileStore() bool {
+	return c.Backend == "file"
+}
+
 func (r RegistryConfig) GetDockerConfigJson() ([]byte, error) {
 	if r.Server == "" || r.Email == "" || r.Pass == "" || r.User == "" {
 		return nil, errors.New("environment didn't have a proper registry Config")
@@ -123,11 +165,15 @@ func (r RegistryConfig) GetDockerConfigJson() ([]byte, error) {
 
 func EmptyConfig() *Config {
 	return &Config{
-		ClusterCredentials: &ClusterCredentials{},
-		RegistryConfig:     &RegistryConfig{},
-		CFClientConfig:     &CFClientConfig{},
-		HelmTLSConfig:      &HelmTLSConfig{},
-		CredStoreConfig:    &CredStoreConfig{},
+		ClusterCredentials:  &ClusterCredentials{},
+		RegistryConfig:      &RegistryConfig{},
+		CFClientConfig:      &CFClientConfig{},
+		HelmTLSConfig:       &HelmTLSConfig{},
+		CredStoreConfig:     &CredStoreConfig{},
+		InstanceStoreConfig: &InstanceStoreConfig{},
+		ReconcilerConfig:    &ReconcilerConfig{},
+		NodeAddressConfig:   &NodeAddressConfig{},
+		NamingConfig:        &NamingConfig{},
 	}
 }
 
@@ -150,6 +196,16 @@ func Parse() (*Config, error) {
 		}
 	}
 
+	err = c.NodeAddressConfig.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.NamingConfig.validate()
+	if err != nil {
+		return nil, err
+	}
+
 	c.cleanupConfig()
 
 	return c, nil
@@ -198,3 +254,35 @@ func (c Config) cleanupConfig() {
 	c.RegistryConfig.Server = strings.TrimPrefix(c.RegistryConfig.Server, "https://")
 	c.RegistryConfig.Server = strings.TrimPrefix(c.RegistryConfig.Server, "http://")
 }
+
+var nodeAddressTypes = []string{"ExternalIP", "InternalIP", "Hostname", "ExternalDNS", "InternalDNS"}
+
+func (n *NodeAddressConfig) validate() error {
+	for _, addressType := range n.AddressTypes {
+		known := false
+		for _, nodeAddressType := range nodeAddressTypes {
+			known = known || addressType == nodeAddressType
+		}
+		if !known {
+			return errors.New(fmt.Sprintf("unknown node address type %s, expected one of %s", addressType, strings.Join(nodeAddressTypes, ", ")))
+		}
+	}
+	return nil
+}
+
+func (n *NamingConfig) validate() error {
+	_, err := ParseNamingTemplate(n.NamespaceTemplate)
+	if err != nil {
+		return errors.New(fmt.Sprintf("invalid NAMESPACE_TEMPLATE: %s", err))
+	}
+	_, err = ParseNamingTemplate(n.ReleaseNameTemplate)
+	if err != nil {
+		return errors.New(fmt.Sprintf("invalid RELEASE_NAME_TEMPLATE: %s", err))
+	}
+	return nil
+}
+
+// ParseNamingTemplate parses a naming template, with the Sprig functions available to it
+func ParseNamingTemplate(text string) (*template.Template, error) {
+	return template.New("name").Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(text)
+}
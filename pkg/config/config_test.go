@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	. "github.com/cf-platform-eng/kibosh/pkg/config"
 )
@@ -288,5 +289,131 @@ my cert data
 				Expect(c.CredStoreConfig.UaaURL).To(Equal("https://uaa.example.com"))
 			})
 		})
+
+		Context("instance store config", func() {
+			It("defaults to the kubernetes store", func() {
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.InstanceStoreConfig.UsesFileStore()).To(BeFalse())
+			})
+
+			It("parses file store config", func() {
+				os.Setenv("INSTANCE_STORE", "file")
+				os.Setenv("INSTANCE_STORE_DIR", "/tmp/kibosh-instances")
+
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.InstanceStoreConfig.UsesFileStore()).To(BeTrue())
+				Expect(c.InstanceStoreConfig.Dir).To(Equal("/tmp/kibosh-instances"))
+			})
+		})
+
+		It("defaults to no network isolation", func() {
+			c, err := Parse()
+			Expect(err).To(BeNil())
+			Expect(c.NetworkIsolation).To(BeFalse())
+
+			os.Setenv("NETWORK_ISOLATION", "true")
+			c, err = Parse()
+			Expect(err).To(BeNil())
+			Expect(c.NetworkIsolation).To(BeTrue())
+		})
+
+		Context("node address config", func() {
+			It("defaults to preferring external addresses from any node", func() {
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.NodeAddressConfig.AddressTypes).To(Equal([]string{"ExternalIP", "InternalIP", "Hostname"}))
+				Expect(c.NodeAddressConfig.NodeSelector).To(Equal(""))
+			})
+
+			It("parses node address config", func() {
+				os.Setenv("NODE_ADDRESS_TYPES", "InternalIP,ExternalIP")
+				os.Setenv("NODE_SELECTOR", "node-role.kubernetes.io/worker=true")
+
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.NodeAddressConfig.AddressTypes).To(Equal([]string{"InternalIP", "ExternalIP"}))
+				Expect(c.NodeAddressConfig.NodeSelector).To(Equal("node-role.kubernetes.io/worker=true"))
+			})
+
+			It("errors on unknown address types", func() {
+				os.Setenv("NODE_ADDRESS_TYPES", "PublicIP")
+
+				_, err := Parse()
+				Expect(err).NotTo(BeNil())
+			})
+		})
+
+		It("defaults the operation lock timeout", func() {
+			c, err := Parse()
+			Expect(err).To(BeNil())
+
+			Expect(c.OperationLockTimeout).To(Equal(2 * time.Hour))
+		})
+
+		It("parses the operation lock timeout", func() {
+			os.Setenv("OPERATION_LOCK_TIMEOUT", "30m")
+
+			c, err := Parse()
+			Expect(err).To(BeNil())
+
+			Expect(c.OperationLockTimeout).To(Equal(30 * time.Minute))
+		})
+
+		Context("naming config", func() {
+			It("defaults to the kibosh names", func() {
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.NamingConfig.NamespaceTemplate).To(Equal(""))
+				Expect(c.NamingConfig.ReleaseNameTemplate).To(Equal(""))
+			})
+
+			It("parses naming templates", func() {
+				os.Setenv("NAMESPACE_TEMPLATE", "svc-{{ .OrganizationName }}-{{ .ShortID }}")
+				os.Setenv("RELEASE_NAME_TEMPLATE", "{{ .ServiceName | trunc 40 }}-{{ .ShortID }}")
+
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.NamingConfig.NamespaceTemplate).To(Equal("svc-{{ .OrganizationName }}-{{ .ShortID }}"))
+				Expect(c.NamingConfig.ReleaseNameTemplate).To(Equal("{{ .ServiceName | trunc 40 }}-{{ .ShortID }}"))
+			})
+
+			It("errors on templates that don't parse", func() {
+				os.Setenv("NAMESPACE_TEMPLATE", "svc-{{ .OrganizationName")
+
+				_, err := Parse()
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("NAMESPACE_TEMPLATE"))
+			})
+		})
+
+		Context("reconciler config", func() {
+			It("defaults to reporting orphans only", func() {
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.ReconcilerConfig.Interval).To(Equal(10 * time.Minute))
+				Expect(c.ReconcilerConfig.PurgeOrphans).To(BeFalse())
+				Expect(c.ReconcilerConfig.GracePeriod).To(Equal(24 * time.Hour))
+			})
+
+			It("parses purge config", func() {
+				os.Setenv("PURGE_ORPHANS", "true")
+				os.Setenv("ORPHAN_GRACE_PERIOD", "2h")
+
+				c, err := Parse()
+				Expect(err).To(BeNil())
+
+				Expect(c.ReconcilerConfig.PurgeOrphans).To(BeTrue())
+				Expect(c.ReconcilerConfig.GracePeriod).To(Equal(2 * time.Hour))
+			})
+		})
 	})
 })
@@ -19,6 +19,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -28,9 +29,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	batch_v1 "k8s.io/api/batch/v1"
+	api_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	k8sAPI "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/helm/pkg/chartutil"
@@ -43,12 +48,74 @@ type MyChart struct {
 	PrivateRegistryServer string          `json:"privateRegistryServer"`
 	TransformedValues     []byte          `json:"transformedValues"`
 	BindTemplate          string          `json:"bindTemplate"`
+	BindTemplateLine      int             `json:"bindTemplateLine"`
+	BindTemplateType      string          `json:"bindTemplateType"`
+	BindHooks             *BindHooks      `json:"bindHooks"`
+	BindConfigMaps        []string        `json:"bindConfigMaps"`
+	BindSecretExposure    string          `json:"bindSecretExposure"`
+	BindReleaseValues     bool            `json:"bindReleaseValues"`
+	Service               Service         `json:"service"`
 	Plans                 map[string]Plan `json:"plans"`
 	ChartPath             string          `json:"chartPath"`
+
+	ValuesSchema map[string]interface{} `json:"valuesSchema"`
 }
 
 type Bind struct {
 	Template string `json:"template"`
+	// TemplateType is jsonnet, the default, or gotemplate
+	TemplateType string     `json:"templateType"`
+	Hooks        *BindHooks `json:"hooks"`
+
+	// ConfigMaps in the instance namespace are only given to the bind template when they're named here
+	ConfigMaps []string `json:"configMaps"`
+
+	// SecretExposure is all, the default, to give bindings every secret not annotated kibosh.io/bind: "false",
+	// or opt-in to only give those annotated kibosh.io/bind: "true"
+	SecretExposure string `json:"secretExposure"`
+
+	// ReleaseValues gives the bind template the release's values and the instance's parameters, which are read
+	// from tiller for every binding
+	ReleaseValues bool `json:"releaseValues"`
+}
+
+// BindHooks are Jobs run in the instance namespace for each binding. The bind hook writes the binding's
+// credentials to a secret, and the unbind hook revokes them.
+type BindHooks struct {
+	Bind   *batch_v1.JobSpec `json:"bind"`
+	Unbind *batch_v1.JobSpec `json:"unbind"`
+	Rotate *batch_v1.JobSpec `json:"rotate"`
+}
+
+// Service is the catalog metadata read from the chart's service.yaml. Anything left out falls back to Chart.yaml.
+type Service struct {
+	DisplayName          string   `json:"displayName"`
+	LongDescription      string   `json:"longDescription"`
+	ProviderDisplayName  string   `json:"providerDisplayName"`
+	DocumentationUrl     string   `json:"documentationUrl"`
+	SupportUrl           string   `json:"supportUrl"`
+	ImageUrl             string   `json:"imageUrl"`
+	Tags                 []string `json:"tags"`
+	Requires             []string `json:"requires"`
+	Bindable             *bool    `json:"bindable"`
+	PlanUpdateable       *bool    `json:"planUpdateable"`
+	InstancesRetrievable *bool    `json:"instancesRetrievable"`
+	Shareable            *bool    `json:"shareable"`
+
+	Dashboard *Dashboard `json:"dashboard"`
+}
+
+// Dashboard nominates either the ingress serving the instance dashboard, or a Jsonnet template evaluating to its URL
+type Dashboard struct {
+	Ingress  string `json:"ingress"`
+	Template string `json:"template"`
+}
+
+var servicePermissions = []string{"route_forwarding", "syslog_drain", "volume_mount"}
+
+type PlanCost struct {
+	Amount map[string]float64 `json:"amount"`
+	Unit   string             `json:"unit"`
 }
 
 func NewChartValidationError(err error) *ChartValidationError {
@@ -62,16 +129,31 @@ type ChartValidationError struct {
 }
 
 type Plan struct {
-	Name            string   `json:"name" json:"name"`
-	Description     string   `json:"description" json:"description"`
-	Bullets         []string `json:"bullets" json:"bullets"`
-	File            string   `json:"file" json:"file"`
-	Free            *bool    `json:"free,omitempty" json:"free"`
-	Bindable        *bool    `json:"bindable,omitempty" json:"bindable"`
-	CredentialsPath string   `json:"credentials" json:"credentialsPath"`
-
-	Values        []byte         `json:"values"`
-	ClusterConfig *k8sAPI.Config `json:"clusterConfig"`
+	Name            string     `json:"name" json:"name"`
+	DisplayName     string     `json:"displayName"`
+	Description     string     `json:"description" json:"description"`
+	Bullets         []string   `json:"bullets" json:"bullets"`
+	File            string     `json:"file" json:"file"`
+	Free            *bool      `json:"free,omitempty" json:"free"`
+	Bindable        *bool      `json:"bindable,omitempty" json:"bindable"`
+	CredentialsPath string     `json:"credentials" json:"credentialsPath"`
+	SchemaPath      string     `json:"schema"`
+	Costs           []PlanCost `json:"costs"`
+
+	// RollbackOnFailure rolls instances back to their last deployed revision when an update fails
+	RollbackOnFailure bool `json:"rollbackOnFailure"`
+
+	// ResourceQuota and LimitRange constrain what instances of the plan can consume in their namespace
+	ResourceQuota *api_v1.ResourceQuotaSpec `json:"resourceQuota"`
+	LimitRange    *api_v1.LimitRangeSpec    `json:"limitRange"`
+
+	// NetworkIsolation denies ingress from other namespaces, except what AllowIngress lets in
+	NetworkIsolation bool                                     `json:"networkIsolation"`
+	AllowIngress     []networking_v1.NetworkPolicyIngressRule `json:"allowIngress"`
+
+	Values        []byte                 `json:"values"`
+	ClusterConfig *k8sAPI.Config         `json:"clusterConfig"`
+	Schema        map[string]interface{} `json:"parameterSchema"`
 }
 
 func LoadFromDir(dir string, log *logrus.Logger) ([]*MyChart, error) {
@@ -129,6 +211,16 @@ func NewChart(chartPath string, privateRegistryServer string, log *logrus.Logger
 		return nil, NewChartValidationError(err)
 	}
 
+	err = myChart.loadValuesSchema()
+	if err != nil {
+		return nil, NewChartValidationError(err)
+	}
+
+	err = myChart.loadServiceMetadata()
+	if err != nil {
+		return nil, NewChartValidationError(err)
+	}
+
 	if chartPathStat.IsDir() {
 		err = myChart.loadOSBAPIMetadataFromDirectory(chartPath, log)
 	} else {
@@ -149,6 +241,13 @@ func NewChart(chartPath string, privateRegistryServer string, log *logrus.Logger
 		}
 	}
 
+	for name, plan := range myChart.Plans {
+		if plan.Schema == nil {
+			plan.Schema = myChart.ValuesSchema
+			myChart.Plans[name] = plan
+		}
+	}
+
 	myChart.ChartPath = chartPath
 	return myChart, nil
 }
@@ -182,6 +281,55 @@ func (c *MyChart) LoadChartValues() error {
 	return nil
 }
 
+func (c *MyChart) loadValuesSchema() error {
+	for _, file := range c.Chart.Files {
+		if file.TypeUrl == "values.schema.json" {
+			schema := map[string]interface{}{}
+			err := json.Unmarshal(file.Value, &schema)
+			if err != nil {
+				return errors.Wrap(err, "Error reading values.schema.json")
+			}
+			c.ValuesSchema = schema
+		}
+	}
+
+	return nil
+}
+
+func (c *MyChart) loadServiceMetadata() error {
+	for _, file := range c.Chart.Files {
+		if file.TypeUrl != "service.yaml" && file.TypeUrl != "service.yml" {
+			continue
+		}
+
+		service := Service{}
+		err := yaml.Unmarshal(file.Value, &service)
+		if err != nil {
+			return errors.Wrap(err, "Error reading service.yaml")
+		}
+		for _, permission := range service.Requires {
+			if !contains(servicePermissions, permission) {
+				return errors.New(fmt.Sprintf("Unsupported permission [%s] in service.yaml requires, expected one of %v", permission, servicePermissions))
+			}
+		}
+		if service.Dashboard != nil && (service.Dashboard.Ingress == "") == (service.Dashboard.Template == "") {
+			return errors.New("The dashboard in service.yaml needs exactly one of ingress or template")
+		}
+		c.Service = service
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *MyChart) OverrideImageSources(rawVals map[string]interface{}) (map[string]interface{}, error) {
 	transformedVals := map[string]interface{}{}
 	for key, val := range rawVals {
@@ -316,13 +464,10 @@ func (c *MyChart) loadOSBAPIMetadataFromArchive(chartPath string, log *logrus.Lo
 				return err
 			}
 
-			bind := &Bind{}
-			err = yaml.Unmarshal(dst.Bytes(), bind)
+			err = c.loadBind(dst.Bytes())
 			if err != nil {
 				return err
 			}
-
-			c.BindTemplate = bind.Template
 		}
 	}
 
@@ -331,6 +476,34 @@ func (c *MyChart) loadOSBAPIMetadataFromArchive(chartPath string, log *logrus.Lo
 	return err
 }
 
+func (c *MyChart) loadBind(bindYaml []byte) error {
+	bind := &Bind{}
+	err := yaml.Unmarshal(bindYaml, bind)
+	if err != nil {
+		return err
+	}
+
+	switch bind.TemplateType {
+	case "", TemplateTypeJsonnet, TemplateTypeGoTemplate:
+	default:
+		return errors.New(fmt.Sprintf("bind.yaml has unknown templateType %s, expected %s or %s", bind.TemplateType, TemplateTypeJsonnet, TemplateTypeGoTemplate))
+	}
+	switch bind.SecretExposure {
+	case "", k8s.SecretExposureAll, k8s.SecretExposureOptIn:
+	default:
+		return errors.New(fmt.Sprintf("bind.yaml has unknown secretExposure %s, expected %s or %s", bind.SecretExposure, k8s.SecretExposureAll, k8s.SecretExposureOptIn))
+	}
+
+	c.BindTemplate = bind.Template
+	c.BindTemplateType = bind.TemplateType
+	c.BindTemplateLine = BindTemplateLine(bindYaml)
+	c.BindHooks = bind.Hooks
+	c.BindConfigMaps = bind.ConfigMaps
+	c.BindSecretExposure = bind.SecretExposure
+	c.BindReleaseValues = bind.ReleaseValues
+	return nil
+}
+
 func (c *MyChart) loadOSBAPIMetadataFromDirectory(chartPath string, log *logrus.Logger) error {
 	bindTemplatePath := path.Join(chartPath, "bind.yaml")
 	_, err := os.Stat(bindTemplatePath)
@@ -348,13 +521,10 @@ func (c *MyChart) loadOSBAPIMetadataFromDirectory(chartPath string, log *logrus.
 			return err
 		}
 
-		bind := &Bind{}
-		err = yaml.Unmarshal(bindTemplateBytes, bind)
+		err = c.loadBind(bindTemplateBytes)
 		if err != nil {
 			return err
 		}
-
-		c.BindTemplate = bind.Template
 	}
 
 	plansPath := path.Join(chartPath, "plans.yaml")
@@ -419,6 +589,20 @@ func (c *MyChart) loadPlans(plansPath string, plans []Plan) error {
 			p.ClusterConfig = loadedConfig
 		}
 
+		if p.SchemaPath != "" {
+			schemaBytes, err := ioutil.ReadFile(filepath.Join(plansPath, p.SchemaPath))
+			if err != nil {
+				return err
+			}
+
+			schema := map[string]interface{}{}
+			err = yaml.Unmarshal(schemaBytes, &schema)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error reading schema for plan [%s]", p.Name))
+			}
+			p.Schema = schema
+		}
+
 		c.Plans[p.Name] = p
 	}
 
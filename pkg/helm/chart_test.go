@@ -182,6 +182,119 @@ var _ = Describe("Broker", func() {
 
 			Expect(chart.BindTemplate).To(Equal("{hostname: $.services[0].status.loadBalancer.ingress[0].ip}"))
 		})
+
+		It("loads the configmaps given to the bind template", func() {
+			bindYaml := `
+template: '{bucket: $.configmaps[0].data.bucket}'
+configMaps:
+- spacebears-settings
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			chart, err := helm.NewChart(chartPath, "", nil)
+
+			Expect(err).To(BeNil())
+			Expect(chart.BindConfigMaps).To(Equal([]string{"spacebears-settings"}))
+			Expect(chart.BindTemplateLine).To(Equal(2))
+		})
+
+		It("loads whether the bind template gets the release values", func() {
+			bindYaml := `
+template: '{replicas: $.values.replicas}'
+releaseValues: true
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			chart, err := helm.NewChart(chartPath, "", nil)
+
+			Expect(err).To(BeNil())
+			Expect(chart.BindReleaseValues).To(BeTrue())
+		})
+
+		It("loads the bind template type", func() {
+			bindYaml := `
+templateType: gotemplate
+template: '{"host": {{ (index .services 0).name | quote }}}'
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			chart, err := helm.NewChart(chartPath, "", nil)
+
+			Expect(err).To(BeNil())
+			Expect(chart.BindTemplateType).To(Equal("gotemplate"))
+		})
+
+		It("returns error on unknown bind template type", func() {
+			bindYaml := `
+templateType: mustache
+template: '{"host": "{{host}}"}'
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			_, err = helm.NewChart(chartPath, "", nil)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("templateType"))
+		})
+
+		It("loads the secret exposure", func() {
+			bindYaml := `
+secretExposure: opt-in
+template: '{}'
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			chart, err := helm.NewChart(chartPath, "", nil)
+
+			Expect(err).To(BeNil())
+			Expect(chart.BindSecretExposure).To(Equal("opt-in"))
+		})
+
+		It("returns error on unknown secret exposure", func() {
+			bindYaml := `
+secretExposure: some
+template: '{}'
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			_, err = helm.NewChart(chartPath, "", nil)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("secretExposure"))
+		})
+
+		It("loads bind hooks", func() {
+			bindYaml := `
+hooks:
+  bind:
+    template:
+      spec:
+        containers:
+        - name: create-user
+          image: my-registry/create-user
+  unbind:
+    template:
+      spec:
+        containers:
+        - name: drop-user
+          image: my-registry/drop-user
+`
+			err := ioutil.WriteFile(path.Join(chartPath, "bind.yaml"), []byte(bindYaml), 0666)
+			Expect(err).To(BeNil())
+
+			chart, err := helm.NewChart(chartPath, "", nil)
+
+			Expect(err).To(BeNil())
+			Expect(chart.BindTemplate).To(Equal(""))
+			Expect(chart.BindHooks.Bind.Template.Spec.Containers[0].Image).To(Equal("my-registry/create-user"))
+			Expect(chart.BindHooks.Unbind.Template.Spec.Containers[0].Image).To(Equal("my-registry/drop-user"))
+		})
 	})
 
 	Context("archived chart (tgz)", func() {
@@ -630,6 +743,93 @@ images:
 			Expect(len(myChart.Plans)).To(Equal(2))
 			Expect(myChart.Plans["small"].Values).To(Equal(testChart.PlanContents["small"]))
 			Expect(myChart.Plans["medium"].Values).To(Equal(testChart.PlanContents["medium"]))
+			Expect(myChart.Plans["small"].RollbackOnFailure).To(BeFalse())
+		})
+
+		It("loads rollback setting", func() {
+			testChart.PlansYaml = []byte(`
+- name: "small"
+  description: "default (small) plan for mysql"
+  file: "small.yaml"
+  rollbackOnFailure: true
+- name: "medium"
+  description: "medium sized plan for mysql"
+  file: "medium.yaml"
+`)
+			err := testChart.WriteChart(chartPath)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Plans["small"].RollbackOnFailure).To(BeTrue())
+			Expect(myChart.Plans["medium"].RollbackOnFailure).To(BeFalse())
+		})
+
+		It("loads resource quota and limit range", func() {
+			testChart.PlansYaml = []byte(`
+- name: "small"
+  description: "default (small) plan for mysql"
+  file: "small.yaml"
+  resourceQuota:
+    hard:
+      limits.memory: 2Gi
+      pods: "5"
+  limitRange:
+    limits:
+    - type: Container
+      default:
+        memory: 256Mi
+- name: "medium"
+  description: "medium sized plan for mysql"
+  file: "medium.yaml"
+`)
+			err := testChart.WriteChart(chartPath)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			quota := myChart.Plans["small"].ResourceQuota
+			Expect(quota).NotTo(BeNil())
+			Expect(quota.Hard.Pods().String()).To(Equal("5"))
+			limitsMemory := quota.Hard["limits.memory"]
+			Expect(limitsMemory.String()).To(Equal("2Gi"))
+			limitRange := myChart.Plans["small"].LimitRange
+			Expect(limitRange).NotTo(BeNil())
+			Expect(limitRange.Limits).To(HaveLen(1))
+			Expect(limitRange.Limits[0].Default.Memory().String()).To(Equal("256Mi"))
+			Expect(myChart.Plans["medium"].ResourceQuota).To(BeNil())
+			Expect(myChart.Plans["medium"].LimitRange).To(BeNil())
+		})
+
+		It("loads network isolation and allow rules", func() {
+			testChart.PlansYaml = []byte(`
+- name: "small"
+  description: "default (small) plan for mysql"
+  file: "small.yaml"
+  networkIsolation: true
+  allowIngress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          name: ingress-nginx
+- name: "medium"
+  description: "medium sized plan for mysql"
+  file: "medium.yaml"
+`)
+			err := testChart.WriteChart(chartPath)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Plans["small"].NetworkIsolation).To(BeTrue())
+			Expect(myChart.Plans["small"].AllowIngress).To(HaveLen(1))
+			from := myChart.Plans["small"].AllowIngress[0].From
+			Expect(from[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{"name": "ingress-nginx"}))
+			Expect(myChart.Plans["medium"].NetworkIsolation).To(BeFalse())
+			Expect(myChart.Plans["medium"].AllowIngress).To(BeEmpty())
 		})
 
 		It("loads credentials", func() {
@@ -753,4 +953,269 @@ users:
 			Expect(err.Error()).To(ContainSubstring("invalid characters"))
 		})
 	})
+
+	Context("service metadata", func() {
+		serviceYaml := []byte(`
+displayName: Space Bears
+longDescription: Bears, in space
+providerDisplayName: Example Corp
+supportUrl: https://support.example.com
+tags: [database, bears]
+requires: [route_forwarding]
+planUpdateable: false
+shareable: true
+`)
+
+		It("loads service.yaml", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "service.yaml"), serviceYaml, 0666)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Service.DisplayName).To(Equal("Space Bears"))
+			Expect(myChart.Service.LongDescription).To(Equal("Bears, in space"))
+			Expect(myChart.Service.ProviderDisplayName).To(Equal("Example Corp"))
+			Expect(myChart.Service.SupportUrl).To(Equal("https://support.example.com"))
+			Expect(myChart.Service.Tags).To(Equal([]string{"database", "bears"}))
+			Expect(myChart.Service.Requires).To(Equal([]string{"route_forwarding"}))
+			Expect(*myChart.Service.PlanUpdateable).To(BeFalse())
+			Expect(*myChart.Service.Shareable).To(BeTrue())
+			Expect(myChart.Service.Bindable).To(BeNil())
+		})
+
+		It("loads service.yaml from archived chart", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "service.yaml"), serviceYaml, 0666)
+			Expect(err).To(BeNil())
+			chartToSave, err := helm.NewChart(chartPath, "", logger)
+			Expect(err).To(BeNil())
+			chartArchiveDirPath, err := ioutil.TempDir("", "chartarcive-")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(chartArchiveDirPath)
+			chartArchivePath, err := chartutil.Save(&chartToSave.Chart, chartArchiveDirPath)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartArchivePath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Service.DisplayName).To(Equal("Space Bears"))
+		})
+
+		It("ignores a service template", func() {
+			err := os.MkdirAll(filepath.Join(chartPath, "templates"), 0777)
+			Expect(err).To(BeNil())
+			err = ioutil.WriteFile(filepath.Join(chartPath, "templates", "service.yaml"), []byte("kind: Service\n"), 0666)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Service).To(Equal(helm.Service{}))
+		})
+
+		It("loads the dashboard", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "service.yaml"), []byte("dashboard:\n  ingress: ui\n"), 0666)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Service.Dashboard).To(Equal(&helm.Dashboard{Ingress: "ui"}))
+		})
+
+		It("rejects a dashboard with both an ingress and a template", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "service.yaml"), []byte("dashboard:\n  ingress: ui\n  template: '\"http://ui\"'\n"), 0666)
+			Expect(err).To(BeNil())
+
+			_, err = helm.NewChart(chartPath, "", logger)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("dashboard"))
+		})
+
+		It("rejects unknown permissions", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "service.yaml"), []byte("requires: [root_access]"), 0666)
+			Expect(err).To(BeNil())
+
+			_, err = helm.NewChart(chartPath, "", logger)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("root_access"))
+		})
+
+		It("loads plan display names and costs", func() {
+			testChart.PlansYaml = []byte(`
+- name: "small"
+  displayName: "Small"
+  description: "default (small) plan for mysql"
+  file: "small.yaml"
+  costs:
+  - amount:
+      usd: 9.99
+    unit: MONTHLY
+- name: "medium"
+  description: "medium sized plan for mysql"
+  file: "medium.yaml"
+`)
+			err := testChart.WriteChart(chartPath)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Plans["small"].DisplayName).To(Equal("Small"))
+			Expect(myChart.Plans["small"].Costs).To(Equal([]helm.PlanCost{
+				{Amount: map[string]float64{"usd": 9.99}, Unit: "MONTHLY"},
+			}))
+			Expect(myChart.Plans["medium"].Costs).To(BeEmpty())
+		})
+	})
+
+	Context("schemas", func() {
+		valuesSchema := []byte(`{
+  "type": "object",
+  "properties": {
+    "replicas": {"type": "integer", "minimum": 1}
+  }
+}`)
+
+		It("loads values.schema.json for every plan", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "values.schema.json"), valuesSchema, 0666)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.ValuesSchema["type"]).To(Equal("object"))
+			Expect(myChart.Plans["small"].Schema).To(Equal(myChart.ValuesSchema))
+			Expect(myChart.Plans["medium"].Schema).To(Equal(myChart.ValuesSchema))
+		})
+
+		It("loads values.schema.json from archived chart", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "values.schema.json"), valuesSchema, 0666)
+			Expect(err).To(BeNil())
+			chartToSave, err := helm.NewChart(chartPath, "", logger)
+			Expect(err).To(BeNil())
+			chartArchiveDirPath, err := ioutil.TempDir("", "chartarcive-")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(chartArchiveDirPath)
+			chartArchivePath, err := chartutil.Save(&chartToSave.Chart, chartArchiveDirPath)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartArchivePath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.ValuesSchema["type"]).To(Equal("object"))
+		})
+
+		It("prefers plan schema", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "values.schema.json"), valuesSchema, 0666)
+			Expect(err).To(BeNil())
+			testChart.PlansYaml = []byte(`
+- name: "small"
+  description: "default (small) plan for mysql"
+  file: "small.yaml"
+  schema: "small-schema.yaml"
+- name: "medium"
+  description: "medium sized plan for mysql"
+  file: "medium.yaml"
+`)
+			err = testChart.WriteChart(chartPath)
+			Expect(err).To(BeNil())
+			err = ioutil.WriteFile(filepath.Join(chartPath, "plans", "small-schema.yaml"), []byte(`
+type: object
+properties:
+  replicas:
+    type: integer
+    maximum: 1
+`), 0666)
+			Expect(err).To(BeNil())
+
+			myChart, err := helm.NewChart(chartPath, "", logger)
+
+			Expect(err).To(BeNil())
+			Expect(myChart.Plans["small"].Schema["properties"]).To(Equal(map[string]interface{}{
+				"replicas": map[string]interface{}{"type": "integer", "maximum": float64(1)},
+			}))
+			Expect(myChart.Plans["medium"].Schema).To(Equal(myChart.ValuesSchema))
+		})
+
+		It("returns error on bad values.schema.json", func() {
+			err := ioutil.WriteFile(filepath.Join(chartPath, "values.schema.json"), []byte(`{`), 0666)
+			Expect(err).To(BeNil())
+
+			_, err = helm.NewChart(chartPath, "", logger)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("values.schema.json"))
+		})
+
+		Context("validate parameters", func() {
+			var myChart *helm.MyChart
+
+			BeforeEach(func() {
+				err := ioutil.WriteFile(filepath.Join(chartPath, "values.schema.json"), valuesSchema, 0666)
+				Expect(err).To(BeNil())
+
+				myChart, err = helm.NewChart(chartPath, "", logger)
+				Expect(err).To(BeNil())
+			})
+
+			It("accepts valid parameters", func() {
+				err := myChart.ValidateParameters("small", []byte(`replicas: 3`))
+
+				Expect(err).To(BeNil())
+			})
+
+			It("rejects invalid parameters", func() {
+				err := myChart.ValidateParameters("small", []byte(`replicas: "three"`))
+
+				Expect(err).NotTo(BeNil())
+				validationErr, ok := err.(*helm.ParameterValidationError)
+				Expect(ok).To(BeTrue())
+				Expect(validationErr.Problems).To(HaveLen(1))
+				Expect(validationErr.Problems[0]).To(ContainSubstring("replicas"))
+			})
+
+			It("validates only the user supplied parameters", func() {
+				myChart.Plans["small"] = helm.Plan{
+					Name: "small",
+					Schema: map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"replicas": map[string]interface{}{"type": "integer"},
+						},
+						"additionalProperties": false,
+					},
+					Values: []byte("persistence: true\n"),
+				}
+
+				err := myChart.ValidateParameters("small", []byte(`replicas: 3`))
+
+				Expect(err).To(BeNil())
+			})
+
+			It("validates missing parameters as empty", func() {
+				myChart.Plans["small"] = helm.Plan{
+					Name: "small",
+					Schema: map[string]interface{}{
+						"type":     "object",
+						"required": []interface{}{"replicas"},
+					},
+				}
+
+				err := myChart.ValidateParameters("small", nil)
+
+				Expect(err).NotTo(BeNil())
+			})
+
+			It("skips validation without schema", func() {
+				myChart.Plans["small"] = helm.Plan{Name: "small"}
+
+				err := myChart.ValidateParameters("small", []byte(`replicas: "three"`))
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
 })
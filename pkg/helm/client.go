@@ -65,9 +65,10 @@ type MyHelmClient interface {
 	Install(*helmstaller.Options) error
 	Upgrade(*helmstaller.Options) error
 	Uninstall(*helmstaller.Options) error
-	InstallChart(registryConfig *config.RegistryConfig, namespace api_v1.Namespace, releaseName string, chart *MyChart, planName string, installValues []byte, opts ...helm.InstallOption) (*rls.InstallReleaseResponse, error)
+	InstallChart(registryConfig *config.RegistryConfig, networkIsolation bool, namespace api_v1.Namespace, releaseName string, chart *MyChart, planName string, installValues []byte, opts ...helm.InstallOption) (*rls.InstallReleaseResponse, error)
 	InstallOperator(chart *MyChart, namespace string) (*rls.InstallReleaseResponse, error)
 	UpdateChart(chart *MyChart, rlsName string, planName string, updateValues []byte) (*rls.UpdateReleaseResponse, error)
+	UpgradeChart(chart *MyChart, namespaceName string, rlsName string, planName string, userValues []byte) (*rls.UpdateReleaseResponse, error)
 	HasDifferentTLSConfig() bool
 	PrintStatus(out io.Writer, deploymentName string) error
 	RenderTemplatedValues(releaseOptions chartutil.ReleaseOptions, inputValues []byte, chart chart.Chart) ([]byte, error)
@@ -223,13 +224,25 @@ func (c myHelmClient) InstallReleaseFromChartWithContext(ctx context.Context, ch
 	return client.InstallReleaseFromChartWithContext(ctx, chart, namespace, opts...)
 }
 
-func (c myHelmClient) InstallChart(registryConfig *config.RegistryConfig, namespace api_v1.Namespace, releaseName string, chart *MyChart, planName string, installValues []byte, opts ...helm.InstallOption) (*rls.InstallReleaseResponse, error) {
+func (c myHelmClient) InstallChart(registryConfig *config.RegistryConfig, networkIsolation bool, namespace api_v1.Namespace, releaseName string, chart *MyChart, planName string, installValues []byte, opts ...helm.InstallOption) (*rls.InstallReleaseResponse, error) {
 	err := c.cluster.CreateNamespaceIfNotExists(&namespace)
 	if err != nil {
 		return nil, err
 	}
 
 	namespaceName := namespace.Name
+	if planName != "" {
+		err = ApplyPlanLimits(c.cluster, namespaceName, chart.Plans[planName])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = ApplyNetworkPolicies(c.cluster, namespaceName, chart.Plans[planName], networkIsolation)
+	if err != nil {
+		return nil, err
+	}
+
 	if registryConfig.HasRegistryConfig() {
 		privateRegistrySetup := k8s.NewPrivateRegistrySetup(namespaceName, "default", c.cluster, registryConfig)
 		err := privateRegistrySetup.Setup()
@@ -328,6 +341,31 @@ func (c myHelmClient) UpdateChart(chart *MyChart, rlsName string, planName strin
 	return c.UpdateReleaseFromChart(rlsName, &chart.Chart, helm.UpdateValueOverrides(finalValues), helm.ReuseValues(true))
 }
 
+// UpgradeChart recomputes all of the release's values from the chart and plan rather than reusing them
+func (c myHelmClient) UpgradeChart(chart *MyChart, namespaceName string, rlsName string, planName string, userValues []byte) (*rls.UpdateReleaseResponse, error) {
+	planOverrideValues, err := MergeValueBytes(chart.TransformedValues, chart.Plans[planName].Values)
+	if err != nil {
+		return nil, err
+	}
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      rlsName,
+		Namespace: namespaceName,
+		IsInstall: false,
+		IsUpgrade: true,
+	}
+	renderedValues, err := c.RenderTemplatedValues(releaseOptions, planOverrideValues, chart.Chart)
+	if err != nil {
+		return nil, err
+	}
+	finalValues, err := MergeValueBytes(renderedValues, userValues)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Infof("Upgrading helm release to plan %s with these values: %+v", planName, string(finalValues))
+	return c.UpdateReleaseFromChart(rlsName, &chart.Chart, helm.UpdateValueOverrides(finalValues), helm.ResetValues(true))
+}
+
 func (c myHelmClient) DeleteRelease(rlsName string, opts ...helm.DeleteOption) (*rls.UninstallReleaseResponse, error) {
 	tunnel, client, err := c.open()
 	if err != nil {
@@ -512,7 +550,13 @@ func (c myHelmClient) UpdateReleaseFromChartWithContext(ctx context.Context, rls
 }
 
 func (c myHelmClient) RollbackRelease(rlsName string, opts ...helm.RollbackOption) (*rls.RollbackReleaseResponse, error) {
-	panic("Not yet implemented")
+	tunnel, client, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	defer tunnel.Close()
+
+	return client.RollbackRelease(rlsName, opts...)
 }
 
 func (c myHelmClient) ReleaseContent(rlsName string, opts ...helm.ContentOption) (*rls.GetReleaseContentResponse, error) {
@@ -526,7 +570,13 @@ func (c myHelmClient) ReleaseContent(rlsName string, opts ...helm.ContentOption)
 }
 
 func (c myHelmClient) ReleaseHistory(rlsName string, opts ...helm.HistoryOption) (*rls.GetHistoryResponse, error) {
-	panic("Not yet implemented")
+	tunnel, client, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	defer tunnel.Close()
+
+	return client.ReleaseHistory(rlsName, opts...)
 }
 
 func (c myHelmClient) GetVersion(opts ...helm.VersionOption) (*rls.GetVersionResponse, error) {
@@ -553,6 +603,10 @@ func MergeValueBytes(base []byte, override []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if baseVals == nil {
+		// empty documents unmarshal to a nil map
+		baseVals = map[string]interface{}{}
+	}
 	overrideVals := map[string]interface{}{}
 	err = yaml.Unmarshal(override, &overrideVals)
 	if err != nil {
@@ -569,6 +623,46 @@ func MergeValueBytes(base []byte, override []byte) ([]byte, error) {
 	return merged, nil
 }
 
+// DiffValueBytes returns the values that are present in values but absent from, or different in, base
+func DiffValueBytes(base []byte, values []byte) (map[string]interface{}, error) {
+	baseVals := map[string]interface{}{}
+	err := yaml.Unmarshal(base, &baseVals)
+	if err != nil {
+		return nil, err
+	}
+	vals := map[string]interface{}{}
+	err = yaml.Unmarshal(values, &vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffValueMaps(baseVals, vals), nil
+}
+
+func diffValueMaps(base map[string]interface{}, values map[string]interface{}) map[string]interface{} {
+	diff := map[string]interface{}{}
+	for k, v := range values {
+		baseVal, exists := base[k]
+		if !exists {
+			diff[k] = v
+			continue
+		}
+		nextMap, ok := v.(map[string]interface{})
+		baseMap, baseOk := baseVal.(map[string]interface{})
+		if ok && baseOk {
+			nested := diffValueMaps(baseMap, nextMap)
+			if len(nested) > 0 {
+				diff[k] = nested
+			}
+			continue
+		}
+		if !reflect.DeepEqual(baseVal, v) {
+			diff[k] = v
+		}
+	}
+	return diff
+}
+
 // we stole this from Helm cmd/helm/installer/install.mergeValues
 func mergeValueMaps(dest map[string]interface{}, src map[string]interface{}) map[string]interface{} {
 	for k, v := range src {
@@ -164,6 +164,44 @@ foo: bar
 		Expect(err).ToNot(BeNil())
 	})
 
+	It("diff values returns only values that differ from base", func() {
+		base := []byte(`
+foo: bar
+resources:
+  requests:
+    memory: 128Mi
+    cpu: 100m
+`)
+		values := []byte(`
+foo: bar
+baz: qux
+resources:
+  requests:
+    memory: 256Mi
+    cpu: 100m
+`)
+
+		diff, err := DiffValueBytes(base, values)
+		Expect(err).To(BeNil())
+		Expect(diff).To(Equal(map[string]interface{}{
+			"baz": "qux",
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{
+					"memory": "256Mi",
+				},
+			},
+		}))
+	})
+
+	It("diff values is empty when nothing was overridden", func() {
+		base := []byte(`
+foo: bar
+`)
+		diff, err := DiffValueBytes(base, base)
+		Expect(err).To(BeNil())
+		Expect(diff).To(BeEmpty())
+	})
+
 	Context("Rendering values for helm install", func() {
 		var releaseOptions chartutil.ReleaseOptions
 
@@ -0,0 +1,96 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/pkg/errors"
+)
+
+const TemplateTypeJsonnet = "jsonnet"
+const TemplateTypeGoTemplate = "gotemplate"
+
+// RenderGoTemplate executes the template with Sprig's functions against data, as the JSON it marshals to, and
+// checks it renders JSON. Missing keys are errors rather than "<no value>".
+func RenderGoTemplate(goTemplate string, data interface{}, opts ...TemplateOption) (string, error) {
+	options := &templateOptions{
+		filename: "template",
+		line:     1,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	// templates see the same structure as jsonnet does, rather than Go's field names
+	inputBytes, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	var input interface{}
+	err = json.Unmarshal(inputBytes, &input)
+	if err != nil {
+		return "", err
+	}
+
+	// padding puts the template on the line it was read from, for errors to refer to
+	padding := strings.Repeat("\n", maxInt(options.line-1, 0))
+	parsed, err := template.New(options.filename).
+		Option("missingkey=error").
+		Funcs(goTemplateFuncs()).
+		Parse(padding + goTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	rendered := &bytes.Buffer{}
+	err = parsed.Execute(rendered, input)
+	if err != nil {
+		if _, ok := err.(template.ExecError); ok {
+			return "", &templateRuntimeError{err: err}
+		}
+		return "", err
+	}
+
+	if !json.Valid(rendered.Bytes()) {
+		return "", errors.Errorf("%s: the template didn't render JSON: %s", options.filename, strings.TrimSpace(rendered.String()))
+	}
+	return rendered.String(), nil
+}
+
+// goTemplateFuncs are Sprig's functions, without those reading the broker's environment, as helm does
+func goTemplateFuncs() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}
+
+// RenderTemplate renders the template with the engine of its type, which defaults to jsonnet
+func RenderTemplate(templateType string, template string, data interface{}, opts ...TemplateOption) (string, error) {
+	switch templateType {
+	case "", TemplateTypeJsonnet:
+		return RenderJsonnetTemplate(template, data, opts...)
+	case TemplateTypeGoTemplate:
+		return RenderGoTemplate(template, data, opts...)
+	default:
+		return "", errors.Errorf("unknown template type %s", templateType)
+	}
+}
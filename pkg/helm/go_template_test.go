@@ -0,0 +1,100 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/helm"
+)
+
+var _ = Describe("Go templates", func() {
+	data := map[string]interface{}{
+		"secrets": []map[string]interface{}{
+			{"name": "db", "data": map[string]interface{}{"password": "s3cr3t", "user": "bears"}},
+		},
+		"services": []map[string]interface{}{},
+	}
+
+	It("renders with sprig functions", func() {
+		template := `{
+  "user": {{ (index .secrets 0).data.user | quote }},
+  "password": {{ (index .secrets 0).data.password | b64enc | quote }},
+  "services": {{ len .services }}
+}`
+
+		rendered, err := RenderGoTemplate(template, data)
+
+		Expect(err).To(BeNil())
+		Expect(rendered).To(MatchJSON(`{"user": "bears", "password": "czNjcjN0", "services": 0}`))
+	})
+
+	It("fails when the template doesn't render json", func() {
+		_, err := RenderGoTemplate(`user: {{ (index .secrets 0).data.user }}`, data)
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("didn't render JSON"))
+		Expect(IsTemplateRuntimeError(err)).To(BeFalse())
+	})
+
+	It("treats what's missing from the inputs as runtime errors", func() {
+		_, err := RenderGoTemplate(`{"host": {{ (index .services 0).name | quote }}}`, data)
+
+		Expect(IsTemplateRuntimeError(err)).To(BeTrue())
+
+		_, err = RenderGoTemplate(`{"host": {{ .ingresses | toJson }}}`, data)
+
+		Expect(IsTemplateRuntimeError(err)).To(BeTrue())
+	})
+
+	It("doesn't give templates the broker's environment", func() {
+		os.Setenv("SECURITY_USER_PASSWORD", "broker-admin-password")
+		defer os.Unsetenv("SECURITY_USER_PASSWORD")
+
+		rendered, err := RenderGoTemplate(`{"password": {{ env "SECURITY_USER_PASSWORD" | quote }}}`, data)
+
+		Expect(err).NotTo(BeNil())
+		Expect(rendered).NotTo(ContainSubstring("broker-admin-password"))
+
+		_, err = RenderGoTemplate(`{"password": {{ expandenv "$SECURITY_USER_PASSWORD" | quote }}}`, data)
+
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("reports parse errors against the line the template was read from", func() {
+		_, err := RenderGoTemplate("{\n  \"user\": {{ nosuchfunction }}\n}", data, TemplateSource("bind.yaml", 3))
+
+		Expect(err).NotTo(BeNil())
+		Expect(IsTemplateRuntimeError(err)).To(BeFalse())
+		Expect(err.Error()).To(ContainSubstring("bind.yaml:4"))
+	})
+
+	It("renders by template type", func() {
+		rendered, err := RenderTemplate(TemplateTypeGoTemplate, `{"user": {{ (index .secrets 0).data.user | quote }}}`, data)
+		Expect(err).To(BeNil())
+		Expect(rendered).To(MatchJSON(`{"user": "bears"}`))
+
+		rendered, err = RenderTemplate("", `{user: $.secrets[0].data.user}`, data)
+		Expect(err).To(BeNil())
+		Expect(rendered).To(MatchJSON(`{"user": "bears"}`))
+
+		_, err = RenderTemplate("mustache", `{}`, data)
+		Expect(err).NotTo(BeNil())
+	})
+})
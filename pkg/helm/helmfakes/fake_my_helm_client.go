@@ -67,16 +67,17 @@ type FakeMyHelmClient struct {
 	installReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InstallChartStub        func(*config.RegistryConfig, v1.Namespace, string, *helm.MyChart, string, []byte, ...helma.InstallOption) (*services.InstallReleaseResponse, error)
+	InstallChartStub        func(*config.RegistryConfig, bool, v1.Namespace, string, *helm.MyChart, string, []byte, ...helma.InstallOption) (*services.InstallReleaseResponse, error)
 	installChartMutex       sync.RWMutex
 	installChartArgsForCall []struct {
 		arg1 *config.RegistryConfig
-		arg2 v1.Namespace
-		arg3 string
-		arg4 *helm.MyChart
-		arg5 string
-		arg6 []byte
-		arg7 []helma.InstallOption
+		arg2 bool
+		arg3 v1.Namespace
+		arg4 string
+		arg5 *helm.MyChart
+		arg6 string
+		arg7 []byte
+		arg8 []helma.InstallOption
 	}
 	installChartReturns struct {
 		result1 *services.InstallReleaseResponse
@@ -398,6 +399,23 @@ type FakeMyHelmClient struct {
 	upgradeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpgradeChartStub        func(*helm.MyChart, string, string, string, []byte) (*services.UpdateReleaseResponse, error)
+	upgradeChartMutex       sync.RWMutex
+	upgradeChartArgsForCall []struct {
+		arg1 *helm.MyChart
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []byte
+	}
+	upgradeChartReturns struct {
+		result1 *services.UpdateReleaseResponse
+		result2 error
+	}
+	upgradeChartReturnsOnCall map[int]struct {
+		result1 *services.UpdateReleaseResponse
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -641,27 +659,28 @@ func (fake *FakeMyHelmClient) InstallReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeMyHelmClient) InstallChart(arg1 *config.RegistryConfig, arg2 v1.Namespace, arg3 string, arg4 *helm.MyChart, arg5 string, arg6 []byte, arg7 ...helma.InstallOption) (*services.InstallReleaseResponse, error) {
-	var arg6Copy []byte
-	if arg6 != nil {
-		arg6Copy = make([]byte, len(arg6))
-		copy(arg6Copy, arg6)
+func (fake *FakeMyHelmClient) InstallChart(arg1 *config.RegistryConfig, arg2 bool, arg3 v1.Namespace, arg4 string, arg5 *helm.MyChart, arg6 string, arg7 []byte, arg8 ...helma.InstallOption) (*services.InstallReleaseResponse, error) {
+	var arg7Copy []byte
+	if arg7 != nil {
+		arg7Copy = make([]byte, len(arg7))
+		copy(arg7Copy, arg7)
 	}
 	fake.installChartMutex.Lock()
 	ret, specificReturn := fake.installChartReturnsOnCall[len(fake.installChartArgsForCall)]
 	fake.installChartArgsForCall = append(fake.installChartArgsForCall, struct {
 		arg1 *config.RegistryConfig
-		arg2 v1.Namespace
-		arg3 string
-		arg4 *helm.MyChart
-		arg5 string
-		arg6 []byte
-		arg7 []helma.InstallOption
-	}{arg1, arg2, arg3, arg4, arg5, arg6Copy, arg7})
-	fake.recordInvocation("InstallChart", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6Copy, arg7})
+		arg2 bool
+		arg3 v1.Namespace
+		arg4 string
+		arg5 *helm.MyChart
+		arg6 string
+		arg7 []byte
+		arg8 []helma.InstallOption
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7Copy, arg8})
+	fake.recordInvocation("InstallChart", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7Copy, arg8})
 	fake.installChartMutex.Unlock()
 	if fake.InstallChartStub != nil {
-		return fake.InstallChartStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7...)
+		return fake.InstallChartStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -676,17 +695,17 @@ func (fake *FakeMyHelmClient) InstallChartCallCount() int {
 	return len(fake.installChartArgsForCall)
 }
 
-func (fake *FakeMyHelmClient) InstallChartCalls(stub func(*config.RegistryConfig, v1.Namespace, string, *helm.MyChart, string, []byte, ...helma.InstallOption) (*services.InstallReleaseResponse, error)) {
+func (fake *FakeMyHelmClient) InstallChartCalls(stub func(*config.RegistryConfig, bool, v1.Namespace, string, *helm.MyChart, string, []byte, ...helma.InstallOption) (*services.InstallReleaseResponse, error)) {
 	fake.installChartMutex.Lock()
 	defer fake.installChartMutex.Unlock()
 	fake.InstallChartStub = stub
 }
 
-func (fake *FakeMyHelmClient) InstallChartArgsForCall(i int) (*config.RegistryConfig, v1.Namespace, string, *helm.MyChart, string, []byte, []helma.InstallOption) {
+func (fake *FakeMyHelmClient) InstallChartArgsForCall(i int) (*config.RegistryConfig, bool, v1.Namespace, string, *helm.MyChart, string, []byte, []helma.InstallOption) {
 	fake.installChartMutex.RLock()
 	defer fake.installChartMutex.RUnlock()
 	argsForCall := fake.installChartArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8
 }
 
 func (fake *FakeMyHelmClient) InstallChartReturns(result1 *services.InstallReleaseResponse, result2 error) {
@@ -2127,6 +2146,78 @@ func (fake *FakeMyHelmClient) UpgradeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeMyHelmClient) UpgradeChart(arg1 *helm.MyChart, arg2 string, arg3 string, arg4 string, arg5 []byte) (*services.UpdateReleaseResponse, error) {
+	var arg5Copy []byte
+	if arg5 != nil {
+		arg5Copy = make([]byte, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.upgradeChartMutex.Lock()
+	ret, specificReturn := fake.upgradeChartReturnsOnCall[len(fake.upgradeChartArgsForCall)]
+	fake.upgradeChartArgsForCall = append(fake.upgradeChartArgsForCall, struct {
+		arg1 *helm.MyChart
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []byte
+	}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.recordInvocation("UpgradeChart", []interface{}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.upgradeChartMutex.Unlock()
+	if fake.UpgradeChartStub != nil {
+		return fake.UpgradeChartStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.upgradeChartReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMyHelmClient) UpgradeChartCallCount() int {
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
+	return len(fake.upgradeChartArgsForCall)
+}
+
+func (fake *FakeMyHelmClient) UpgradeChartCalls(stub func(*helm.MyChart, string, string, string, []byte) (*services.UpdateReleaseResponse, error)) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = stub
+}
+
+func (fake *FakeMyHelmClient) UpgradeChartArgsForCall(i int) (*helm.MyChart, string, string, string, []byte) {
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
+	argsForCall := fake.upgradeChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeMyHelmClient) UpgradeChartReturns(result1 *services.UpdateReleaseResponse, result2 error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = nil
+	fake.upgradeChartReturns = struct {
+		result1 *services.UpdateReleaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMyHelmClient) UpgradeChartReturnsOnCall(i int, result1 *services.UpdateReleaseResponse, result2 error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = nil
+	if fake.upgradeChartReturnsOnCall == nil {
+		fake.upgradeChartReturnsOnCall = make(map[int]struct {
+			result1 *services.UpdateReleaseResponse
+			result2 error
+		})
+	}
+	fake.upgradeChartReturnsOnCall[i] = struct {
+		result1 *services.UpdateReleaseResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMyHelmClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2184,6 +2275,8 @@ func (fake *FakeMyHelmClient) Invocations() map[string][][]interface{} {
 	defer fake.updateReleaseWithContextMutex.RUnlock()
 	fake.upgradeMutex.RLock()
 	defer fake.upgradeMutex.RUnlock()
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
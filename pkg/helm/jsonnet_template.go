@@ -1,27 +1,248 @@
 package helm
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
 	"strings"
 
 	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/pkg/errors"
 )
 
-func RenderJsonnetTemplate(template string, data map[string][]map[string]interface{}) (string, error) {
-	ssTemplateBytes, err := json.Marshal(data)
+// TemplateInputVar is the external variable templates and the libraries they import read their input from
+const TemplateInputVar = "kibosh"
+
+// BindLibraryDir is the directory of the chart that bind templates import from
+const BindLibraryDir = "bind"
+
+// templateRuntimeError is a template that's valid, but failed evaluating against its inputs
+type templateRuntimeError struct {
+	err error
+}
+
+func (e *templateRuntimeError) Error() string {
+	return e.err.Error()
+}
+
+// runtimeErrorRecorder formats errors the way the VM's own formatter does, and remembers whether the last one was
+// a runtime error, since the VM only returns the formatted text
+type runtimeErrorRecorder struct {
+	jsonnet.ErrorFormatter
+	runtimeError bool
+}
+
+func (f *runtimeErrorRecorder) Format(err error) string {
+	_, f.runtimeError = err.(jsonnet.RuntimeError)
+	return f.ErrorFormatter.Format(err)
+}
+
+type templateOptions struct {
+	filename  string
+	line      int
+	libraries map[string]jsonnet.Contents
+}
+
+type TemplateOption func(*templateOptions)
+
+// TemplateSource makes errors refer to the file the template came from, and the line it starts on
+func TemplateSource(filename string, line int) TemplateOption {
+	return func(options *templateOptions) {
+		options.filename = filename
+		options.line = line
+	}
+}
+
+// TemplateLibraries are what the template can import, keyed by path
+func TemplateLibraries(libraries map[string]string) TemplateOption {
+	return func(options *templateOptions) {
+		for libraryPath, library := range libraries {
+			options.libraries[libraryPath] = jsonnet.MakeContents(library)
+		}
+	}
+}
+
+// RenderJsonnetTemplate evaluates the template with data, which has to marshal to a JSON object, as $ and as
+// std.extVar("kibosh"). Within the template, self still refers to the template's own object.
+func RenderJsonnetTemplate(template string, data interface{}, opts ...TemplateOption) (string, error) {
+	options := &templateOptions{
+		line:      1,
+		libraries: map[string]jsonnet.Contents{},
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	input, err := json.Marshal(data)
 	if err != nil {
 		return "", err
 	}
 
-	ssTemplate := string(ssTemplateBytes)
-	i := strings.LastIndex(ssTemplate, "}")
-	fullTemplate := ssTemplate[0:i] + `,"template": ` + template + "}"
 	vm := jsonnet.MakeVM()
-	renderedTemplate, err := vm.EvaluateSnippetMulti("", fullTemplate)
+	errorRecorder := &runtimeErrorRecorder{ErrorFormatter: vm.ErrorFormatter}
+	vm.ErrorFormatter = errorRecorder
+	vm.ExtCode(TemplateInputVar, string(input))
+	vm.Importer(&jsonnet.MemoryImporter{Data: options.libraries})
+	for _, nativeFunction := range templateNativeFunctions {
+		vm.NativeFunction(nativeFunction)
+	}
+
+	// extending the input keeps $ pointing at it, and padding the program puts the template on the line it
+	// was read from
+	program := strings.Repeat("\n", maxInt(options.line-2, 0)) +
+		fmt.Sprintf("std.extVar(%q) + { template:", TemplateInputVar)
+	if options.line > 1 {
+		program += "\n"
+	}
+	program += template + "\n}"
+
+	renderedTemplate, err := vm.EvaluateSnippetMulti(options.filename, program)
 	if err != nil {
+		if errorRecorder.runtimeError {
+			return "", &templateRuntimeError{err: err}
+		}
 		return "", err
 	}
 
 	return renderedTemplate["template"], nil
+}
 
+// IsTemplateRuntimeError is true when the template is valid but failed evaluating against its inputs, rather
+// than when the template itself is broken
+func IsTemplateRuntimeError(err error) bool {
+	_, ok := errors.Cause(err).(*templateRuntimeError)
+	return ok
+}
+
+// RenderBindTemplate renders the chart's bind template, with the libraries in its bind directory
+func (c *MyChart) RenderBindTemplate(data interface{}) (string, error) {
+	return RenderTemplate(
+		c.BindTemplateType, c.BindTemplate, data,
+		TemplateSource("bind.yaml", c.BindTemplateLine),
+		TemplateLibraries(c.bindLibraries()),
+	)
+}
+
+func (c *MyChart) bindLibraries() map[string]string {
+	libraries := map[string]string{}
+	for _, file := range c.Chart.Files {
+		if strings.HasPrefix(file.TypeUrl, BindLibraryDir+"/") {
+			libraries[strings.TrimPrefix(file.TypeUrl, BindLibraryDir+"/")] = string(file.Value)
+		}
+	}
+	return libraries
+}
+
+// BindTemplateLine is the line of bind.yaml the template starts on, so errors can refer to it
+func BindTemplateLine(bindYaml []byte) int {
+	for i, line := range strings.Split(string(bindYaml), "\n") {
+		if !strings.HasPrefix(line, "template:") {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, "template:"))
+		if value == "" || strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			return i + 2
+		}
+		return i + 1
+	}
+	return 1
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+var templateNativeFunctions = []*jsonnet.NativeFunction{
+	{
+		Name:   "base64Encode",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			str, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			return base64.StdEncoding.EncodeToString([]byte(str)), nil
+		},
+	},
+	{
+		Name:   "base64Decode",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			str, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := base64.StdEncoding.DecodeString(str)
+			if err != nil {
+				return nil, err
+			}
+			return string(decoded), nil
+		},
+	},
+	{
+		Name:   "urlQueryEscape",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			str, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			return url.QueryEscape(str), nil
+		},
+	},
+	{
+		Name:   "urlPathEscape",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			str, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			return url.PathEscape(str), nil
+		},
+	},
+	{
+		Name:   "sha256",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			str, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			sum := sha256.Sum256([]byte(str))
+			return hex.EncodeToString(sum[:]), nil
+		},
+	},
+	{
+		Name:   "joinHostPort",
+		Params: ast.Identifiers{"host", "port"},
+		Func: func(args []interface{}) (interface{}, error) {
+			host, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			// ports are as likely to be numbers as strings
+			port := fmt.Sprintf("%v", args[1])
+			if number, ok := args[1].(float64); ok {
+				port = fmt.Sprintf("%d", int64(number))
+			}
+			return net.JoinHostPort(host, port), nil
+		},
+	},
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	str, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d must be a string, got %v", i+1, args[i])
+	}
+	return str, nil
 }
@@ -0,0 +1,126 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"encoding/json"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/golang/protobuf/ptypes/any"
+	hapi_chart "k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+var _ = Describe("Jsonnet templates", func() {
+	data := map[string]interface{}{
+		"secrets": []map[string]interface{}{
+			{"name": "db", "data": map[string]interface{}{"password": "s3cr3t", "user": "bears"}},
+		},
+	}
+
+	render := func(template string, opts ...TemplateOption) map[string]interface{} {
+		rendered, err := RenderJsonnetTemplate(template, data, opts...)
+		Expect(err).To(BeNil())
+		result := map[string]interface{}{}
+		err = json.Unmarshal([]byte(rendered), &result)
+		Expect(err).To(BeNil())
+		return result
+	}
+
+	It("gives the input as $ and as an external variable, leaving self to the template", func() {
+		Expect(render(`{
+			user: $.secrets[0].data.user,
+			password: std.extVar("kibosh").secrets[0].data.password,
+			uri: self.user + ":" + self.password,
+		}`)).To(Equal(map[string]interface{}{
+			"user":     "bears",
+			"password": "s3cr3t",
+			"uri":      "bears:s3cr3t",
+		}))
+	})
+
+	It("imports libraries", func() {
+		Expect(render(
+			`local db = import "db.libsonnet"; {user: db.user}`,
+			TemplateLibraries(map[string]string{
+				"db.libsonnet": `{user: std.extVar("kibosh").secrets[0].data.user}`,
+			}),
+		)).To(Equal(map[string]interface{}{"user": "bears"}))
+	})
+
+	It("has native functions", func() {
+		Expect(render(`{
+			encoded: std.native("base64Encode")("bears"),
+			decoded: std.native("base64Decode")("YmVhcnM="),
+			query: std.native("urlQueryEscape")("a b&c"),
+			path: std.native("urlPathEscape")("a b/c"),
+			hash: std.native("sha256")("bears"),
+			address: std.native("joinHostPort")("10.0.0.1", 5432),
+			ipv6: std.native("joinHostPort")("::1", "5432"),
+		}`)).To(Equal(map[string]interface{}{
+			"encoded": "YmVhcnM=",
+			"decoded": "bears",
+			"query":   "a+b%26c",
+			"path":    "a%20b%2Fc",
+			"hash":    "faa5af7cea8ae5022bcc38f8942164e92b1d713152ce3e2aa07725c836e009bd",
+			"address": "10.0.0.1:5432",
+			"ipv6":    "[::1]:5432",
+		}))
+	})
+
+	It("reports errors against the line the template was read from", func() {
+		_, err := RenderJsonnetTemplate("{\n  password: $.secrets[1].data.password,\n}", data, TemplateSource("bind.yaml", 3))
+
+		Expect(IsTemplateRuntimeError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("bind.yaml:4"))
+	})
+
+	It("doesn't treat templates that don't parse as runtime errors", func() {
+		_, err := RenderJsonnetTemplate("{\n  password: $.secrets[0].data.password\n  user: 'bears'\n}", data)
+
+		Expect(err).NotTo(BeNil())
+		Expect(IsTemplateRuntimeError(err)).To(BeFalse())
+	})
+
+	Context("bind template line", func() {
+		It("starts after a block scalar", func() {
+			Expect(BindTemplateLine([]byte("configMaps:\n- settings\ntemplate: |\n  {}\n"))).To(Equal(4))
+		})
+
+		It("starts on the line of an inline template", func() {
+			Expect(BindTemplateLine([]byte("hooks: {}\ntemplate: '{}'\n"))).To(Equal(2))
+		})
+	})
+
+	It("renders the chart's bind template with its libraries", func() {
+		chart := &MyChart{
+			Chart: hapi_chart.Chart{
+				Files: []*any.Any{
+					{TypeUrl: "bind/db.libsonnet", Value: []byte(`{user(input): input.secrets[0].data.user}`)},
+					{TypeUrl: "README.md", Value: []byte("# spacebears")},
+				},
+			},
+			BindTemplate: `local db = import "db.libsonnet"; {user: db.user($)}`,
+		}
+
+		rendered, err := chart.RenderBindTemplate(data)
+
+		Expect(err).To(BeNil())
+		Expect(rendered).To(MatchJSON(`{"user": "bears"}`))
+	})
+})
@@ -0,0 +1,67 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	networking_v1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const DenyIngressPolicyName = "kibosh-deny-ingress"
+const AllowIngressPolicyName = "kibosh-allow-ingress"
+
+// ApplyNetworkPolicies denies ingress to the namespace from outside it, apart from the plan's allow rules, when
+// isolateAll is set or the plan asks for isolation. Otherwise it removes any policies applied for an earlier plan.
+func ApplyNetworkPolicies(cluster k8s.Cluster, namespaceName string, plan Plan, isolateAll bool) error {
+	if !isolateAll && !plan.NetworkIsolation && len(plan.AllowIngress) == 0 {
+		for _, name := range []string{DenyIngressPolicyName, AllowIngressPolicyName} {
+			err := cluster.DeleteNetworkPolicy(namespaceName, name, &meta_v1.DeleteOptions{})
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	_, err := cluster.CreateOrUpdateNetworkPolicy(namespaceName, newIngressPolicy(DenyIngressPolicyName, nil))
+	if err != nil {
+		return err
+	}
+
+	// pods of the instance can still reach each other
+	rules := []networking_v1.NetworkPolicyIngressRule{{
+		From: []networking_v1.NetworkPolicyPeer{{PodSelector: &meta_v1.LabelSelector{}}},
+	}}
+	rules = append(rules, plan.AllowIngress...)
+	_, err = cluster.CreateOrUpdateNetworkPolicy(namespaceName, newIngressPolicy(AllowIngressPolicyName, rules))
+	return err
+}
+
+func newIngressPolicy(name string, rules []networking_v1.NetworkPolicyIngressRule) *networking_v1.NetworkPolicy {
+	return &networking_v1.NetworkPolicy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "kibosh"},
+		},
+		Spec: networking_v1.NetworkPolicySpec{
+			PodSelector: meta_v1.LabelSelector{},
+			PolicyTypes: []networking_v1.PolicyType{networking_v1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
@@ -0,0 +1,103 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	networking_v1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Network policies", func() {
+	var cluster *k8sfakes.FakeCluster
+	var allowIngressController networking_v1.NetworkPolicyIngressRule
+
+	BeforeEach(func() {
+		cluster = &k8sfakes.FakeCluster{}
+		allowIngressController = networking_v1.NetworkPolicyIngressRule{
+			From: []networking_v1.NetworkPolicyPeer{{
+				NamespaceSelector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"name": "ingress-nginx"}},
+			}},
+		}
+	})
+
+	It("denies ingress from other namespaces when the plan asks for isolation", func() {
+		err := ApplyNetworkPolicies(cluster, "kibosh-my-instance-guid", Plan{NetworkIsolation: true}, false)
+
+		Expect(err).To(BeNil())
+		Expect(cluster.CreateOrUpdateNetworkPolicyCallCount()).To(Equal(2))
+
+		namespace, deny := cluster.CreateOrUpdateNetworkPolicyArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		Expect(deny.Name).To(Equal("kibosh-deny-ingress"))
+		Expect(deny.Spec.PodSelector).To(Equal(meta_v1.LabelSelector{}))
+		Expect(deny.Spec.PolicyTypes).To(Equal([]networking_v1.PolicyType{networking_v1.PolicyTypeIngress}))
+		Expect(deny.Spec.Ingress).To(BeEmpty())
+
+		_, allow := cluster.CreateOrUpdateNetworkPolicyArgsForCall(1)
+		Expect(allow.Name).To(Equal("kibosh-allow-ingress"))
+		Expect(allow.Spec.Ingress).To(HaveLen(1))
+		Expect(allow.Spec.Ingress[0].From[0].PodSelector).To(Equal(&meta_v1.LabelSelector{}))
+	})
+
+	It("isolates every plan when the broker switch is on", func() {
+		err := ApplyNetworkPolicies(cluster, "kibosh-my-instance-guid", Plan{}, true)
+
+		Expect(err).To(BeNil())
+		Expect(cluster.CreateOrUpdateNetworkPolicyCallCount()).To(Equal(2))
+		Expect(cluster.DeleteNetworkPolicyCallCount()).To(Equal(0))
+	})
+
+	It("adds the plan's allow rules", func() {
+		plan := Plan{AllowIngress: []networking_v1.NetworkPolicyIngressRule{allowIngressController}}
+
+		err := ApplyNetworkPolicies(cluster, "kibosh-my-instance-guid", plan, false)
+
+		Expect(err).To(BeNil())
+		_, allow := cluster.CreateOrUpdateNetworkPolicyArgsForCall(1)
+		Expect(allow.Spec.Ingress).To(HaveLen(2))
+		Expect(allow.Spec.Ingress[1]).To(Equal(allowIngressController))
+	})
+
+	It("removes policies when the plan isn't isolated", func() {
+		cluster.DeleteNetworkPolicyReturns(k8s_errors.NewNotFound(schema.GroupResource{Resource: "networkpolicies"}, "kibosh-deny-ingress"))
+
+		err := ApplyNetworkPolicies(cluster, "kibosh-my-instance-guid", Plan{}, false)
+
+		Expect(err).To(BeNil())
+		Expect(cluster.CreateOrUpdateNetworkPolicyCallCount()).To(Equal(0))
+		Expect(cluster.DeleteNetworkPolicyCallCount()).To(Equal(2))
+		_, name, _ := cluster.DeleteNetworkPolicyArgsForCall(1)
+		Expect(name).To(Equal("kibosh-allow-ingress"))
+	})
+
+	It("returns errors creating the policies", func() {
+		cluster.CreateOrUpdateNetworkPolicyReturns(nil, errors.New("network policies unsupported"))
+
+		err := ApplyNetworkPolicies(cluster, "kibosh-my-instance-guid", Plan{NetworkIsolation: true}, false)
+
+		Expect(err).NotTo(BeNil())
+		Expect(cluster.CreateOrUpdateNetworkPolicyCallCount()).To(Equal(1))
+	})
+})
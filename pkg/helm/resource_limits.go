@@ -0,0 +1,67 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const ResourceQuotaName = "kibosh-quota"
+const LimitRangeName = "kibosh-limits"
+
+// ApplyPlanLimits makes the namespace's ResourceQuota and LimitRange match the plan, removing any the plan doesn't declare
+func ApplyPlanLimits(cluster k8s.Cluster, namespaceName string, plan Plan) error {
+	if plan.ResourceQuota != nil {
+		_, err := cluster.CreateOrUpdateResourceQuota(namespaceName, &api_v1.ResourceQuota{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   ResourceQuotaName,
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "kibosh"},
+			},
+			Spec: *plan.ResourceQuota,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		err := cluster.DeleteResourceQuota(namespaceName, ResourceQuotaName, &meta_v1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if plan.LimitRange != nil {
+		_, err := cluster.CreateOrUpdateLimitRange(namespaceName, &api_v1.LimitRange{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   LimitRangeName,
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "kibosh"},
+			},
+			Spec: *plan.LimitRange,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		err := cluster.DeleteLimitRange(namespaceName, LimitRangeName, &meta_v1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
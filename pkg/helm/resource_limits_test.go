@@ -0,0 +1,98 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/helm"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Plan limits", func() {
+	var cluster *k8sfakes.FakeCluster
+	var plan Plan
+
+	BeforeEach(func() {
+		cluster = &k8sfakes.FakeCluster{}
+		plan = Plan{
+			Name: "small",
+			ResourceQuota: &api_v1.ResourceQuotaSpec{
+				Hard: api_v1.ResourceList{
+					api_v1.ResourceLimitsCPU: resource.MustParse("2"),
+				},
+			},
+			LimitRange: &api_v1.LimitRangeSpec{
+				Limits: []api_v1.LimitRangeItem{{
+					Type:    api_v1.LimitTypeContainer,
+					Default: api_v1.ResourceList{api_v1.ResourceCPU: resource.MustParse("500m")},
+				}},
+			},
+		}
+	})
+
+	It("applies the plan's quota and limit range to the namespace", func() {
+		err := ApplyPlanLimits(cluster, "kibosh-my-instance-guid", plan)
+
+		Expect(err).To(BeNil())
+		Expect(cluster.CreateOrUpdateResourceQuotaCallCount()).To(Equal(1))
+		namespace, quota := cluster.CreateOrUpdateResourceQuotaArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		Expect(quota.Name).To(Equal("kibosh-quota"))
+		Expect(quota.Spec).To(Equal(*plan.ResourceQuota))
+
+		Expect(cluster.CreateOrUpdateLimitRangeCallCount()).To(Equal(1))
+		namespace, limitRange := cluster.CreateOrUpdateLimitRangeArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		Expect(limitRange.Name).To(Equal("kibosh-limits"))
+		Expect(limitRange.Spec).To(Equal(*plan.LimitRange))
+
+		Expect(cluster.DeleteResourceQuotaCallCount()).To(Equal(0))
+		Expect(cluster.DeleteLimitRangeCallCount()).To(Equal(0))
+	})
+
+	It("removes constraints the plan doesn't declare", func() {
+		notFound := k8s_errors.NewNotFound(schema.GroupResource{Resource: "limitranges"}, "kibosh-limits")
+		cluster.DeleteLimitRangeReturns(notFound)
+
+		err := ApplyPlanLimits(cluster, "kibosh-my-instance-guid", Plan{Name: "small"})
+
+		Expect(err).To(BeNil())
+		Expect(cluster.CreateOrUpdateResourceQuotaCallCount()).To(Equal(0))
+		Expect(cluster.CreateOrUpdateLimitRangeCallCount()).To(Equal(0))
+		Expect(cluster.DeleteResourceQuotaCallCount()).To(Equal(1))
+		namespace, name, _ := cluster.DeleteResourceQuotaArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh-my-instance-guid"))
+		Expect(name).To(Equal("kibosh-quota"))
+		Expect(cluster.DeleteLimitRangeCallCount()).To(Equal(1))
+	})
+
+	It("returns errors applying the quota", func() {
+		cluster.CreateOrUpdateResourceQuotaReturns(nil, errors.New("quota denied"))
+
+		err := ApplyPlanLimits(cluster, "kibosh-my-instance-guid", plan)
+
+		Expect(err).NotTo(BeNil())
+		Expect(cluster.CreateOrUpdateLimitRangeCallCount()).To(Equal(0))
+	})
+})
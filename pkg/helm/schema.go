@@ -0,0 +1,64 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type ParameterValidationError struct {
+	Problems []string
+}
+
+func (e *ParameterValidationError) Error() string {
+	return fmt.Sprintf("invalid parameters: %s", strings.Join(e.Problems, "; "))
+}
+
+// ValidateParameters checks the user supplied parameters against the plan's schema, the schema published in the
+// catalog, so the chart's and plan's own values aren't held to it
+func (c *MyChart) ValidateParameters(planName string, parameters []byte) error {
+	plan := c.Plans[planName]
+	if plan.Schema == nil {
+		return nil
+	}
+
+	parametersJSON, err := yaml.YAMLToJSON(parameters)
+	if err != nil {
+		return err
+	}
+	if len(parametersJSON) == 0 || string(parametersJSON) == "null" {
+		parametersJSON = []byte("{}")
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(plan.Schema), gojsonschema.NewBytesLoader(parametersJSON))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error validating against schema for plan [%s]", planName))
+	}
+	if !result.Valid() {
+		problems := []string{}
+		for _, resultError := range result.Errors() {
+			problems = append(problems, resultError.String())
+		}
+		return &ParameterValidationError{Problems: problems}
+	}
+
+	return nil
+}
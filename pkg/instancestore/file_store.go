@@ -0,0 +1,257 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancestore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fileInstanceStore struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileInstanceStore keeps instances as json files in dir, meant for local development
+func NewFileInstanceStore(dir string) (InstanceStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileInstanceStore{
+		dir: dir,
+	}, nil
+}
+
+func (s *fileInstanceStore) Save(instance *Instance) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instanceBytes, err := json.Marshal(instance)
+	if err != nil {
+		return err
+	}
+
+	tempPath := s.getPath(instance.InstanceID) + ".tmp"
+	err = ioutil.WriteFile(tempPath, instanceBytes, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempPath, s.getPath(instance.InstanceID))
+}
+
+func (s *fileInstanceStore) Get(instanceID string) (*Instance, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.read(s.getPath(instanceID))
+}
+
+func (s *fileInstanceStore) Delete(instanceID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.Remove(s.getPath(instanceID))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *fileInstanceStore) List() ([]*Instance, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []*Instance{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		instance, err := s.read(filepath.Join(s.dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// Lock keeps locks as .lock files next to the instances, which a single broker holding the mutex can rely on
+func (s *fileInstanceStore) Lock(instanceID string, operation string, timeout time.Duration) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	locked, err := s.isLocked(s.getLockPath(instanceID), timeout)
+	if err != nil || locked {
+		return "", s.toLockError(err)
+	}
+	bindingLocks, err := ioutil.ReadDir(s.getBindingLockDir(instanceID))
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, bindingLock := range bindingLocks {
+		locked, err := s.isLocked(filepath.Join(s.getBindingLockDir(instanceID), bindingLock.Name()), timeout)
+		if err != nil || locked {
+			return "", s.toLockError(err)
+		}
+	}
+
+	return s.writeLock(s.getLockPath(instanceID), operation)
+}
+
+// LockBinding keeps binding locks in a directory per instance, next to the instance's lock
+func (s *fileInstanceStore) LockBinding(instanceID string, bindingID string, operation string, timeout time.Duration) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, path := range []string{s.getLockPath(instanceID), s.getBindingLockPath(instanceID, bindingID)} {
+		locked, err := s.isLocked(path, timeout)
+		if err != nil || locked {
+			return "", s.toLockError(err)
+		}
+	}
+
+	err := os.MkdirAll(s.getBindingLockDir(instanceID), 0700)
+	if err != nil {
+		return "", err
+	}
+	return s.writeLock(s.getBindingLockPath(instanceID, bindingID), operation)
+}
+
+func (s *fileInstanceStore) Unlock(instanceID string, token string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.removeLock(s.getLockPath(instanceID), token)
+}
+
+func (s *fileInstanceStore) UnlockBinding(instanceID string, bindingID string, token string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.removeLock(s.getBindingLockPath(instanceID, bindingID), token)
+	if err != nil {
+		return err
+	}
+	// only removed once no other binding is locked
+	_ = os.Remove(s.getBindingLockDir(instanceID))
+	return nil
+}
+
+func (s *fileInstanceStore) isLocked(path string, timeout time.Duration) (bool, error) {
+	existing, err := s.readLock(path)
+	if err != nil {
+		return false, err
+	}
+	return existing != nil && !existing.expired(timeout), nil
+}
+
+// toLockError is ErrInstanceLocked unless reading the lock failed
+func (s *fileInstanceStore) toLockError(err error) error {
+	if err != nil {
+		return err
+	}
+	return ErrInstanceLocked
+}
+
+func (s *fileInstanceStore) writeLock(path string, operation string) (string, error) {
+	newLock := newLock(operation)
+	lockBytes, err := json.Marshal(newLock)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, lockBytes, 0600)
+	if err != nil {
+		return "", err
+	}
+	return newLock.Token, nil
+}
+
+func (s *fileInstanceStore) removeLock(path string, token string) error {
+	existing, err := s.readLock(path)
+	if err != nil || existing == nil || !existing.heldBy(token) {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// readLock returns nil when there's no lock at path
+func (s *fileInstanceStore) readLock(path string) (*lock, error) {
+	lockBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	existing := &lock{}
+	err = json.Unmarshal(lockBytes, existing)
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (s *fileInstanceStore) getLockPath(instanceID string) string {
+	return filepath.Join(s.dir, filepath.Base(instanceID)+".lock")
+}
+
+func (s *fileInstanceStore) getBindingLockDir(instanceID string) string {
+	return filepath.Join(s.dir, filepath.Base(instanceID)+".bindings")
+}
+
+func (s *fileInstanceStore) getBindingLockPath(instanceID string, bindingID string) string {
+	return filepath.Join(s.getBindingLockDir(instanceID), filepath.Base(bindingID)+".lock")
+}
+
+func (s *fileInstanceStore) read(path string) (*Instance, error) {
+	instanceBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrInstanceNotFound
+		}
+		return nil, err
+	}
+
+	instance := &Instance{}
+	err = json.Unmarshal(instanceBytes, instance)
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+func (s *fileInstanceStore) getPath(instanceID string) string {
+	return filepath.Join(s.dir, filepath.Base(instanceID)+".json")
+}
@@ -0,0 +1,198 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancestore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("file store", func() {
+	var dir string
+	var store InstanceStore
+	var instance *Instance
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "")
+		Expect(err).To(BeNil())
+
+		store, err = NewFileInstanceStore(filepath.Join(dir, "instances"))
+		Expect(err).To(BeNil())
+
+		instance = &Instance{
+			InstanceID:    "my-instance-guid",
+			ServiceID:     "my-service-id",
+			PlanID:        "my-plan-id",
+			ChartName:     "mysql",
+			ChartVersion:  "0.8.0",
+			ReleaseName:   "k-5h5kntfw",
+			Namespace:     "kibosh-my-instance-guid",
+			ClusterConfig: []byte("apiVersion: v1"),
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("saves and gets an instance", func() {
+		err := store.Save(instance)
+		Expect(err).To(BeNil())
+
+		saved, err := store.Get("my-instance-guid")
+
+		Expect(err).To(BeNil())
+		Expect(saved).To(Equal(instance))
+	})
+
+	It("returns not found for unknown instance", func() {
+		_, err := store.Get("unknown-instance-guid")
+
+		Expect(err).To(Equal(ErrInstanceNotFound))
+	})
+
+	It("lists instances", func() {
+		err := store.Save(instance)
+		Expect(err).To(BeNil())
+		err = store.Save(&Instance{InstanceID: "other-instance-guid"})
+		Expect(err).To(BeNil())
+
+		instances, err := store.List()
+
+		Expect(err).To(BeNil())
+		Expect(instances).To(HaveLen(2))
+	})
+
+	It("deletes an instance", func() {
+		err := store.Save(instance)
+		Expect(err).To(BeNil())
+
+		err = store.Delete("my-instance-guid")
+		Expect(err).To(BeNil())
+
+		_, err = store.Get("my-instance-guid")
+		Expect(err).To(Equal(ErrInstanceNotFound))
+	})
+
+	It("ignores deleting an unknown instance", func() {
+		err := store.Delete("unknown-instance-guid")
+
+		Expect(err).To(BeNil())
+	})
+
+	Context("locks", func() {
+		It("refuses a second lock until the first is released", func() {
+			token, err := store.Lock("my-instance-guid", "provision", time.Hour)
+			Expect(err).To(BeNil())
+			Expect(token).NotTo(BeEmpty())
+
+			_, err = store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(Equal(ErrInstanceLocked))
+
+			err = store.Unlock("my-instance-guid", token)
+			Expect(err).To(BeNil())
+			_, err = store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(BeNil())
+		})
+
+		It("only releases the lock the token was returned for", func() {
+			expiredToken, err := store.Lock("my-instance-guid", "provision", -time.Second)
+			Expect(err).To(BeNil())
+			_, err = store.Lock("my-instance-guid", "update", -time.Second)
+			Expect(err).To(BeNil())
+
+			err = store.Unlock("my-instance-guid", expiredToken)
+			Expect(err).To(BeNil())
+
+			_, err = store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(Equal(ErrInstanceLocked))
+		})
+
+		It("keeps the lock without a token", func() {
+			_, err := store.Lock("my-instance-guid", "provision", time.Hour)
+			Expect(err).To(BeNil())
+
+			err = store.Unlock("my-instance-guid", "")
+			Expect(err).To(BeNil())
+
+			_, err = store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(Equal(ErrInstanceLocked))
+		})
+
+		It("takes over expired locks", func() {
+			_, err := store.Lock("my-instance-guid", "provision", time.Hour)
+			Expect(err).To(BeNil())
+
+			_, err = store.Lock("my-instance-guid", "update", -time.Second)
+			Expect(err).To(BeNil())
+		})
+
+		It("locks bindings of an instance independently", func() {
+			token, err := store.LockBinding("my-instance-guid", "my-binding-guid", "bind", time.Hour)
+			Expect(err).To(BeNil())
+
+			_, err = store.LockBinding("my-instance-guid", "my-binding-guid", "unbind", time.Hour)
+			Expect(err).To(Equal(ErrInstanceLocked))
+			_, err = store.LockBinding("my-instance-guid", "other-binding-guid", "bind", time.Hour)
+			Expect(err).To(BeNil())
+
+			err = store.UnlockBinding("my-instance-guid", "my-binding-guid", token)
+			Expect(err).To(BeNil())
+			_, err = store.LockBinding("my-instance-guid", "my-binding-guid", "unbind", time.Hour)
+			Expect(err).To(BeNil())
+		})
+
+		It("refuses instance locks while a binding is locked", func() {
+			token, err := store.LockBinding("my-instance-guid", "my-binding-guid", "bind", time.Hour)
+			Expect(err).To(BeNil())
+
+			_, err = store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(Equal(ErrInstanceLocked))
+
+			err = store.UnlockBinding("my-instance-guid", "my-binding-guid", token)
+			Expect(err).To(BeNil())
+			_, err = store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(BeNil())
+		})
+
+		It("refuses binding locks while the instance is locked", func() {
+			_, err := store.Lock("my-instance-guid", "update", time.Hour)
+			Expect(err).To(BeNil())
+
+			_, err = store.LockBinding("my-instance-guid", "my-binding-guid", "bind", time.Hour)
+			Expect(err).To(Equal(ErrInstanceLocked))
+		})
+
+		It("doesn't list locks as instances", func() {
+			_, err := store.Lock("my-instance-guid", "provision", time.Hour)
+			Expect(err).To(BeNil())
+			_, err = store.LockBinding("other-instance-guid", "my-binding-guid", "bind", time.Hour)
+			Expect(err).To(BeNil())
+
+			instances, err := store.List()
+
+			Expect(err).To(BeNil())
+			Expect(instances).To(BeEmpty())
+		})
+	})
+})
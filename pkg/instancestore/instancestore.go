@@ -0,0 +1,87 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancestore
+
+import (
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+)
+
+var ErrInstanceNotFound = errors.New("instance not found")
+var ErrInstanceLocked = errors.New("another operation is in progress on the instance")
+
+// Instance is what the broker needs to find a service instance again, independent of the current catalog
+type Instance struct {
+	InstanceID   string `json:"instanceID"`
+	ServiceID    string `json:"serviceID"`
+	PlanID       string `json:"planID"`
+	ChartName    string `json:"chartName"`
+	ChartVersion string `json:"chartVersion"`
+	ReleaseName  string `json:"releaseName"`
+	Namespace    string `json:"namespace"`
+
+	// ClusterConfig is the kubeconfig of the cluster the instance was provisioned to, empty for the default cluster
+	ClusterConfig []byte `json:"clusterConfig,omitempty"`
+}
+
+//go:generate counterfeiter ./ InstanceStore
+type InstanceStore interface {
+	Save(instance *Instance) error
+	Get(instanceID string) (*Instance, error)
+	Delete(instanceID string) error
+	List() ([]*Instance, error)
+
+	// Lock records an operation starting on the instance, returning ErrInstanceLocked while another operation
+	// holds a lock younger than timeout on the instance or any of its bindings. The token it returns is what
+	// releases the lock.
+	Lock(instanceID string, operation string, timeout time.Duration) (string, error)
+	// Unlock releases the lock if it's still the one the token was returned for. Locks whose token is lost
+	// are only taken over once expired.
+	Unlock(instanceID string, token string) error
+
+	// LockBinding records a bind or unbind starting, returning ErrInstanceLocked while another operation holds
+	// a lock younger than timeout on the instance or the same binding. Other bindings aren't affected.
+	LockBinding(instanceID string, bindingID string, operation string, timeout time.Duration) (string, error)
+	// UnlockBinding releases the binding's lock the way Unlock does the instance's
+	UnlockBinding(instanceID string, bindingID string, token string) error
+}
+
+// lock is what's kept while an operation is in progress on an instance
+type lock struct {
+	Operation string    `json:"operation"`
+	Token     string    `json:"token"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+func newLock(operation string) *lock {
+	return &lock{
+		Operation: operation,
+		Token:     uuid.New(),
+		StartedAt: time.Now().UTC(),
+	}
+}
+
+// heldBy is whether the token releases the lock
+func (l *lock) heldBy(token string) bool {
+	return token != "" && l.Token == token
+}
+
+// expired is true for operations never polled to completion within the timeout
+func (l *lock) expired(timeout time.Duration) bool {
+	return time.Since(l.StartedAt) > timeout
+}
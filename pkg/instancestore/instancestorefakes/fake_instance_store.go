@@ -0,0 +1,649 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package instancestorefakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cf-platform-eng/kibosh/pkg/instancestore"
+)
+
+type FakeInstanceStore struct {
+	DeleteStub        func(string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStub        func(string) (*instancestore.Instance, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 *instancestore.Instance
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 *instancestore.Instance
+		result2 error
+	}
+	ListStub        func() ([]*instancestore.Instance, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+	}
+	listReturns struct {
+		result1 []*instancestore.Instance
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []*instancestore.Instance
+		result2 error
+	}
+	LockStub        func(string, string, time.Duration) (string, error)
+	lockMutex       sync.RWMutex
+	lockArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+	}
+	lockReturns struct {
+		result1 string
+		result2 error
+	}
+	lockReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	LockBindingStub        func(string, string, string, time.Duration) (string, error)
+	lockBindingMutex       sync.RWMutex
+	lockBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}
+	lockBindingReturns struct {
+		result1 string
+		result2 error
+	}
+	lockBindingReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SaveStub        func(*instancestore.Instance) error
+	saveMutex       sync.RWMutex
+	saveArgsForCall []struct {
+		arg1 *instancestore.Instance
+	}
+	saveReturns struct {
+		result1 error
+	}
+	saveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnlockStub        func(string, string) error
+	unlockMutex       sync.RWMutex
+	unlockArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	unlockReturns struct {
+		result1 error
+	}
+	unlockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnlockBindingStub        func(string, string, string) error
+	unlockBindingMutex       sync.RWMutex
+	unlockBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	unlockBindingReturns struct {
+		result1 error
+	}
+	unlockBindingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeInstanceStore) Delete(arg1 string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Delete", []interface{}{arg1})
+	fake.deleteMutex.Unlock()
+	if fake.DeleteStub != nil {
+		return fake.DeleteStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstanceStore) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeInstanceStore) DeleteCalls(stub func(string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *FakeInstanceStore) DeleteArgsForCall(i int) string {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstanceStore) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) Get(arg1 string) (*instancestore.Instance, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInstanceStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeInstanceStore) GetCalls(stub func(string) (*instancestore.Instance, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeInstanceStore) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstanceStore) GetReturns(result1 *instancestore.Instance, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 *instancestore.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) GetReturnsOnCall(i int, result1 *instancestore.Instance, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 *instancestore.Instance
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 *instancestore.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) List() ([]*instancestore.Instance, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+	}{})
+	fake.recordInvocation("List", []interface{}{})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInstanceStore) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeInstanceStore) ListCalls(stub func() ([]*instancestore.Instance, error)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *FakeInstanceStore) ListReturns(result1 []*instancestore.Instance, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []*instancestore.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) ListReturnsOnCall(i int, result1 []*instancestore.Instance, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []*instancestore.Instance
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []*instancestore.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) Lock(arg1 string, arg2 string, arg3 time.Duration) (string, error) {
+	fake.lockMutex.Lock()
+	ret, specificReturn := fake.lockReturnsOnCall[len(fake.lockArgsForCall)]
+	fake.lockArgsForCall = append(fake.lockArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Lock", []interface{}{arg1, arg2, arg3})
+	fake.lockMutex.Unlock()
+	if fake.LockStub != nil {
+		return fake.LockStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.lockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInstanceStore) LockCallCount() int {
+	fake.lockMutex.RLock()
+	defer fake.lockMutex.RUnlock()
+	return len(fake.lockArgsForCall)
+}
+
+func (fake *FakeInstanceStore) LockCalls(stub func(string, string, time.Duration) (string, error)) {
+	fake.lockMutex.Lock()
+	defer fake.lockMutex.Unlock()
+	fake.LockStub = stub
+}
+
+func (fake *FakeInstanceStore) LockArgsForCall(i int) (string, string, time.Duration) {
+	fake.lockMutex.RLock()
+	defer fake.lockMutex.RUnlock()
+	argsForCall := fake.lockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeInstanceStore) LockReturns(result1 string, result2 error) {
+	fake.lockMutex.Lock()
+	defer fake.lockMutex.Unlock()
+	fake.LockStub = nil
+	fake.lockReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) LockReturnsOnCall(i int, result1 string, result2 error) {
+	fake.lockMutex.Lock()
+	defer fake.lockMutex.Unlock()
+	fake.LockStub = nil
+	if fake.lockReturnsOnCall == nil {
+		fake.lockReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.lockReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) LockBinding(arg1 string, arg2 string, arg3 string, arg4 time.Duration) (string, error) {
+	fake.lockBindingMutex.Lock()
+	ret, specificReturn := fake.lockBindingReturnsOnCall[len(fake.lockBindingArgsForCall)]
+	fake.lockBindingArgsForCall = append(fake.lockBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("LockBinding", []interface{}{arg1, arg2, arg3, arg4})
+	fake.lockBindingMutex.Unlock()
+	if fake.LockBindingStub != nil {
+		return fake.LockBindingStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.lockBindingReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInstanceStore) LockBindingCallCount() int {
+	fake.lockBindingMutex.RLock()
+	defer fake.lockBindingMutex.RUnlock()
+	return len(fake.lockBindingArgsForCall)
+}
+
+func (fake *FakeInstanceStore) LockBindingCalls(stub func(string, string, string, time.Duration) (string, error)) {
+	fake.lockBindingMutex.Lock()
+	defer fake.lockBindingMutex.Unlock()
+	fake.LockBindingStub = stub
+}
+
+func (fake *FakeInstanceStore) LockBindingArgsForCall(i int) (string, string, string, time.Duration) {
+	fake.lockBindingMutex.RLock()
+	defer fake.lockBindingMutex.RUnlock()
+	argsForCall := fake.lockBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeInstanceStore) LockBindingReturns(result1 string, result2 error) {
+	fake.lockBindingMutex.Lock()
+	defer fake.lockBindingMutex.Unlock()
+	fake.LockBindingStub = nil
+	fake.lockBindingReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) LockBindingReturnsOnCall(i int, result1 string, result2 error) {
+	fake.lockBindingMutex.Lock()
+	defer fake.lockBindingMutex.Unlock()
+	fake.LockBindingStub = nil
+	if fake.lockBindingReturnsOnCall == nil {
+		fake.lockBindingReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.lockBindingReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceStore) Save(arg1 *instancestore.Instance) error {
+	fake.saveMutex.Lock()
+	ret, specificReturn := fake.saveReturnsOnCall[len(fake.saveArgsForCall)]
+	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
+		arg1 *instancestore.Instance
+	}{arg1})
+	fake.recordInvocation("Save", []interface{}{arg1})
+	fake.saveMutex.Unlock()
+	if fake.SaveStub != nil {
+		return fake.SaveStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstanceStore) SaveCallCount() int {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return len(fake.saveArgsForCall)
+}
+
+func (fake *FakeInstanceStore) SaveCalls(stub func(*instancestore.Instance) error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = stub
+}
+
+func (fake *FakeInstanceStore) SaveArgsForCall(i int) *instancestore.Instance {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	argsForCall := fake.saveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstanceStore) SaveReturns(result1 error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = nil
+	fake.saveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) SaveReturnsOnCall(i int, result1 error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = nil
+	if fake.saveReturnsOnCall == nil {
+		fake.saveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) Unlock(arg1 string, arg2 string) error {
+	fake.unlockMutex.Lock()
+	ret, specificReturn := fake.unlockReturnsOnCall[len(fake.unlockArgsForCall)]
+	fake.unlockArgsForCall = append(fake.unlockArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Unlock", []interface{}{arg1, arg2})
+	fake.unlockMutex.Unlock()
+	if fake.UnlockStub != nil {
+		return fake.UnlockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unlockReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstanceStore) UnlockCallCount() int {
+	fake.unlockMutex.RLock()
+	defer fake.unlockMutex.RUnlock()
+	return len(fake.unlockArgsForCall)
+}
+
+func (fake *FakeInstanceStore) UnlockCalls(stub func(string, string) error) {
+	fake.unlockMutex.Lock()
+	defer fake.unlockMutex.Unlock()
+	fake.UnlockStub = stub
+}
+
+func (fake *FakeInstanceStore) UnlockArgsForCall(i int) (string, string) {
+	fake.unlockMutex.RLock()
+	defer fake.unlockMutex.RUnlock()
+	argsForCall := fake.unlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeInstanceStore) UnlockReturns(result1 error) {
+	fake.unlockMutex.Lock()
+	defer fake.unlockMutex.Unlock()
+	fake.UnlockStub = nil
+	fake.unlockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) UnlockReturnsOnCall(i int, result1 error) {
+	fake.unlockMutex.Lock()
+	defer fake.unlockMutex.Unlock()
+	fake.UnlockStub = nil
+	if fake.unlockReturnsOnCall == nil {
+		fake.unlockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) UnlockBinding(arg1 string, arg2 string, arg3 string) error {
+	fake.unlockBindingMutex.Lock()
+	ret, specificReturn := fake.unlockBindingReturnsOnCall[len(fake.unlockBindingArgsForCall)]
+	fake.unlockBindingArgsForCall = append(fake.unlockBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UnlockBinding", []interface{}{arg1, arg2, arg3})
+	fake.unlockBindingMutex.Unlock()
+	if fake.UnlockBindingStub != nil {
+		return fake.UnlockBindingStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unlockBindingReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstanceStore) UnlockBindingCallCount() int {
+	fake.unlockBindingMutex.RLock()
+	defer fake.unlockBindingMutex.RUnlock()
+	return len(fake.unlockBindingArgsForCall)
+}
+
+func (fake *FakeInstanceStore) UnlockBindingCalls(stub func(string, string, string) error) {
+	fake.unlockBindingMutex.Lock()
+	defer fake.unlockBindingMutex.Unlock()
+	fake.UnlockBindingStub = stub
+}
+
+func (fake *FakeInstanceStore) UnlockBindingArgsForCall(i int) (string, string, string) {
+	fake.unlockBindingMutex.RLock()
+	defer fake.unlockBindingMutex.RUnlock()
+	argsForCall := fake.unlockBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeInstanceStore) UnlockBindingReturns(result1 error) {
+	fake.unlockBindingMutex.Lock()
+	defer fake.unlockBindingMutex.Unlock()
+	fake.UnlockBindingStub = nil
+	fake.unlockBindingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) UnlockBindingReturnsOnCall(i int, result1 error) {
+	fake.unlockBindingMutex.Lock()
+	defer fake.unlockBindingMutex.Unlock()
+	fake.UnlockBindingStub = nil
+	if fake.unlockBindingReturnsOnCall == nil {
+		fake.unlockBindingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlockBindingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.lockMutex.RLock()
+	defer fake.lockMutex.RUnlock()
+	fake.lockBindingMutex.RLock()
+	defer fake.lockBindingMutex.RUnlock()
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	fake.unlockMutex.RLock()
+	defer fake.unlockMutex.RUnlock()
+	fake.unlockBindingMutex.RLock()
+	defer fake.unlockBindingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeInstanceStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ instancestore.InstanceStore = new(FakeInstanceStore)
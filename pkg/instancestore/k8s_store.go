@@ -0,0 +1,273 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancestore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const instanceSecretPrefix = "kibosh-instance-"
+const instanceKey = "instance"
+const lockConfigMapPrefix = "kibosh-lock-"
+
+type k8sInstanceStore struct {
+	cluster   k8s.Cluster
+	namespace string
+}
+
+// NewK8sInstanceStore keeps instances as secrets, since they hold cluster credentials
+func NewK8sInstanceStore(cluster k8s.Cluster, namespace string) InstanceStore {
+	return &k8sInstanceStore{
+		cluster:   cluster,
+		namespace: namespace,
+	}
+}
+
+func (s *k8sInstanceStore) Save(instance *Instance) error {
+	instanceBytes, err := json.Marshal(instance)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.cluster.CreateOrUpdateSecret(s.namespace, &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: s.getSecretName(instance.InstanceID),
+			Labels: map[string]string{
+				"record":                       "instance",
+				"instanceID":                   instance.InstanceID,
+				"app.kubernetes.io/managed-by": "kibosh",
+			},
+		},
+		Type: api_v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			instanceKey: instanceBytes,
+		},
+	})
+
+	return err
+}
+
+func (s *k8sInstanceStore) Get(instanceID string) (*Instance, error) {
+	secret, err := s.cluster.GetSecret(s.namespace, s.getSecretName(instanceID), meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil, ErrInstanceNotFound
+		}
+		return nil, err
+	}
+
+	return s.toInstance(secret)
+}
+
+func (s *k8sInstanceStore) Delete(instanceID string) error {
+	err := s.cluster.DeleteSecret(s.namespace, s.getSecretName(instanceID), &meta_v1.DeleteOptions{})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *k8sInstanceStore) List() ([]*Instance, error) {
+	secrets, err := s.cluster.ListSecrets(s.namespace, meta_v1.ListOptions{
+		LabelSelector: "record=instance,app.kubernetes.io/managed-by=kibosh",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []*Instance{}
+	for i := range secrets.Items {
+		instance, err := s.toInstance(&secrets.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// Lock keeps locks as ConfigMaps, which only one replica can create, and only one can take over once expired
+// since the update is conditional on the resource version that was read. The lock is taken before looking
+// for binding locks, and LockBinding does the reverse, so of an instance and a binding operation racing at
+// least one backs off.
+func (s *k8sInstanceStore) Lock(instanceID string, operation string, timeout time.Duration) (string, error) {
+	name := s.getLockName(instanceID)
+	token, err := s.createLock(name, map[string]string{
+		"lock":                         "operation",
+		"instanceID":                   instanceID,
+		"app.kubernetes.io/managed-by": "kibosh",
+	}, operation, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	bindingLocks, err := s.cluster.ListConfigMaps(s.namespace, meta_v1.ListOptions{
+		LabelSelector: "lock=binding,instanceID=" + instanceID + ",app.kubernetes.io/managed-by=kibosh",
+	})
+	if err == nil {
+		for i := range bindingLocks.Items {
+			if !s.toLock(&bindingLocks.Items[i]).expired(timeout) {
+				err = ErrInstanceLocked
+				break
+			}
+		}
+	}
+	if err != nil {
+		s.releaseLock(name, token)
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *k8sInstanceStore) LockBinding(instanceID string, bindingID string, operation string, timeout time.Duration) (string, error) {
+	name := s.getBindingLockName(instanceID, bindingID)
+	token, err := s.createLock(name, map[string]string{
+		"lock":                         "binding",
+		"instanceID":                   instanceID,
+		"bindingID":                    bindingID,
+		"app.kubernetes.io/managed-by": "kibosh",
+	}, operation, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	instanceLock, err := s.cluster.GetConfigMap(s.namespace, s.getLockName(instanceID), meta_v1.GetOptions{})
+	if err == nil && !s.toLock(instanceLock).expired(timeout) {
+		err = ErrInstanceLocked
+	} else if k8s_errors.IsNotFound(err) {
+		err = nil
+	}
+	if err != nil {
+		s.releaseLock(name, token)
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *k8sInstanceStore) Unlock(instanceID string, token string) error {
+	return s.deleteLock(s.getLockName(instanceID), token)
+}
+
+func (s *k8sInstanceStore) UnlockBinding(instanceID string, bindingID string, token string) error {
+	return s.deleteLock(s.getBindingLockName(instanceID, bindingID), token)
+}
+
+func (s *k8sInstanceStore) createLock(name string, labels map[string]string, operation string, timeout time.Duration) (string, error) {
+	newLock := newLock(operation)
+	configMap := &api_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Data: map[string]string{
+			"operation": newLock.Operation,
+			"token":     newLock.Token,
+			"startedAt": newLock.StartedAt.Format(time.RFC3339),
+		},
+	}
+	_, err := s.cluster.CreateConfigMap(s.namespace, configMap)
+	if err == nil {
+		return newLock.Token, nil
+	}
+	if !k8s_errors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	existing, err := s.cluster.GetConfigMap(s.namespace, configMap.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return "", ErrInstanceLocked
+		}
+		return "", err
+	}
+	if !s.toLock(existing).expired(timeout) {
+		return "", ErrInstanceLocked
+	}
+
+	existing.Data = configMap.Data
+	_, err = s.cluster.UpdateConfigMap(s.namespace, existing)
+	if err != nil {
+		if k8s_errors.IsConflict(err) {
+			return "", ErrInstanceLocked
+		}
+		return "", err
+	}
+	return newLock.Token, nil
+}
+
+// releaseLock backs off from a lock just taken, leaving it to expire if it can't be removed
+func (s *k8sInstanceStore) releaseLock(name string, token string) {
+	_ = s.deleteLock(name, token)
+}
+
+func (s *k8sInstanceStore) deleteLock(name string, token string) error {
+	existing, err := s.cluster.GetConfigMap(s.namespace, name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !s.toLock(existing).heldBy(token) {
+		return nil
+	}
+
+	err = s.cluster.DeleteConfigMap(s.namespace, existing.Name, &meta_v1.DeleteOptions{
+		Preconditions: &meta_v1.Preconditions{ResourceVersion: &existing.ResourceVersion},
+	})
+	if err != nil && !k8s_errors.IsNotFound(err) && !k8s_errors.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+// toLock treats an unreadable start time as long ago, so a damaged lock expires
+func (s *k8sInstanceStore) toLock(configMap *api_v1.ConfigMap) *lock {
+	startedAt, _ := time.Parse(time.RFC3339, configMap.Data["startedAt"])
+	return &lock{
+		Operation: configMap.Data["operation"],
+		Token:     configMap.Data["token"],
+		StartedAt: startedAt,
+	}
+}
+
+func (s *k8sInstanceStore) getLockName(instanceID string) string {
+	return lockConfigMapPrefix + instanceID
+}
+
+func (s *k8sInstanceStore) getBindingLockName(instanceID string, bindingID string) string {
+	return lockConfigMapPrefix + instanceID + "-" + bindingID
+}
+
+func (s *k8sInstanceStore) toInstance(secret *api_v1.Secret) (*Instance, error) {
+	instance := &Instance{}
+	err := json.Unmarshal(secret.Data[instanceKey], instance)
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+func (s *k8sInstanceStore) getSecretName(instanceID string) string {
+	return instanceSecretPrefix + instanceID
+}
@@ -0,0 +1,279 @@
+// kibosh
+//
+// Copyright (c) 2017-Present Pivotal Software, Inc. All Rights Reserved.
+//
+// This program and the accompanying materials are made available under the terms of the under the Apache License,
+// Version 2.0 (the "License”); you may not use this file except in compliance with the License. You may
+// obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancestore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/cf-platform-eng/kibosh/pkg/instancestore"
+	"github.com/cf-platform-eng/kibosh/pkg/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("kubernetes store", func() {
+	var fakeCluster k8sfakes.FakeCluster
+	var store InstanceStore
+	var instance *Instance
+
+	BeforeEach(func() {
+		fakeCluster = k8sfakes.FakeCluster{}
+		store = NewK8sInstanceStore(&fakeCluster, "kibosh")
+
+		instance = &Instance{
+			InstanceID:   "my-instance-guid",
+			ServiceID:    "my-service-id",
+			PlanID:       "my-plan-id",
+			ChartName:    "mysql",
+			ChartVersion: "0.8.0",
+			ReleaseName:  "k-5h5kntfw",
+			Namespace:    "kibosh-my-instance-guid",
+		}
+	})
+
+	It("saves instance as secret", func() {
+		err := store.Save(instance)
+
+		Expect(err).To(BeNil())
+		Expect(fakeCluster.CreateOrUpdateSecretCallCount()).To(Equal(1))
+		namespace, secret := fakeCluster.CreateOrUpdateSecretArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh"))
+		Expect(secret.Name).To(Equal("kibosh-instance-my-instance-guid"))
+		Expect(secret.Labels["instanceID"]).To(Equal("my-instance-guid"))
+
+		saved := &Instance{}
+		err = json.Unmarshal(secret.Data["instance"], saved)
+		Expect(err).To(BeNil())
+		Expect(saved).To(Equal(instance))
+	})
+
+	It("gets instance from secret", func() {
+		instanceBytes, _ := json.Marshal(instance)
+		fakeCluster.GetSecretReturns(&api_v1.Secret{
+			Data: map[string][]byte{"instance": instanceBytes},
+		}, nil)
+
+		saved, err := store.Get("my-instance-guid")
+
+		Expect(err).To(BeNil())
+		Expect(saved).To(Equal(instance))
+		namespace, name, _ := fakeCluster.GetSecretArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh"))
+		Expect(name).To(Equal("kibosh-instance-my-instance-guid"))
+	})
+
+	It("returns not found when there's no secret", func() {
+		fakeCluster.GetSecretReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("secrets"), "kibosh-instance-my-instance-guid"))
+
+		_, err := store.Get("my-instance-guid")
+
+		Expect(err).To(Equal(ErrInstanceNotFound))
+	})
+
+	It("returns other errors getting secret", func() {
+		fakeCluster.GetSecretReturns(nil, errors.New("forbidden"))
+
+		_, err := store.Get("my-instance-guid")
+
+		Expect(err).NotTo(BeNil())
+		Expect(err).NotTo(Equal(ErrInstanceNotFound))
+	})
+
+	It("lists instances by label", func() {
+		instanceBytes, _ := json.Marshal(instance)
+		fakeCluster.ListSecretsReturns(&api_v1.SecretList{
+			Items: []api_v1.Secret{
+				{Data: map[string][]byte{"instance": instanceBytes}},
+			},
+		}, nil)
+
+		instances, err := store.List()
+
+		Expect(err).To(BeNil())
+		Expect(instances).To(Equal([]*Instance{instance}))
+		_, listOptions := fakeCluster.ListSecretsArgsForCall(0)
+		Expect(listOptions.LabelSelector).To(ContainSubstring("record=instance"))
+	})
+
+	It("ignores deleting missing secret", func() {
+		fakeCluster.DeleteSecretReturns(k8s_errors.NewNotFound(api_v1.Resource("secrets"), "kibosh-instance-my-instance-guid"))
+
+		err := store.Delete("my-instance-guid")
+
+		Expect(err).To(BeNil())
+		namespace, name, options := fakeCluster.DeleteSecretArgsForCall(0)
+		Expect(namespace).To(Equal("kibosh"))
+		Expect(name).To(Equal("kibosh-instance-my-instance-guid"))
+		Expect(options).To(Equal(&meta_v1.DeleteOptions{}))
+	})
+
+	Context("locks", func() {
+		BeforeEach(func() {
+			fakeCluster.ListConfigMapsReturns(&api_v1.ConfigMapList{}, nil)
+			fakeCluster.GetConfigMapReturns(nil, k8s_errors.NewNotFound(api_v1.Resource("configmaps"), "kibosh-lock-my-instance-guid"))
+		})
+
+		lockAt := func(operation string, startedAt time.Time) *api_v1.ConfigMap {
+			return &api_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "kibosh-lock-my-instance-guid", ResourceVersion: "42"},
+				Data: map[string]string{
+					"operation": operation,
+					"token":     operation + "-token",
+					"startedAt": startedAt.Format(time.RFC3339),
+				},
+			}
+		}
+
+		It("creates a lock configmap", func() {
+			token, err := store.Lock("my-instance-guid", "provision", time.Hour)
+
+			Expect(err).To(BeNil())
+			namespace, configMap := fakeCluster.CreateConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh"))
+			Expect(configMap.Name).To(Equal("kibosh-lock-my-instance-guid"))
+			Expect(configMap.Labels["instanceID"]).To(Equal("my-instance-guid"))
+			Expect(configMap.Data["operation"]).To(Equal("provision"))
+			Expect(token).NotTo(BeEmpty())
+			Expect(configMap.Data["token"]).To(Equal(token))
+		})
+
+		It("returns locked while another operation holds the lock", func() {
+			fakeCluster.CreateConfigMapReturns(nil, k8s_errors.NewAlreadyExists(api_v1.Resource("configmaps"), "kibosh-lock-my-instance-guid"))
+			fakeCluster.GetConfigMapReturns(lockAt("provision", time.Now()), nil)
+
+			_, err := store.Lock("my-instance-guid", "update", time.Hour)
+
+			Expect(err).To(Equal(ErrInstanceLocked))
+			Expect(fakeCluster.UpdateConfigMapCallCount()).To(Equal(0))
+		})
+
+		It("takes over an expired lock", func() {
+			fakeCluster.CreateConfigMapReturns(nil, k8s_errors.NewAlreadyExists(api_v1.Resource("configmaps"), "kibosh-lock-my-instance-guid"))
+			fakeCluster.GetConfigMapReturns(lockAt("provision", time.Now().Add(-2*time.Hour)), nil)
+
+			token, err := store.Lock("my-instance-guid", "update", time.Hour)
+
+			Expect(err).To(BeNil())
+			_, configMap := fakeCluster.UpdateConfigMapArgsForCall(0)
+			Expect(configMap.ResourceVersion).To(Equal("42"))
+			Expect(configMap.Data["operation"]).To(Equal("update"))
+			Expect(configMap.Data["token"]).To(Equal(token))
+		})
+
+		It("returns locked when another replica takes over first", func() {
+			fakeCluster.CreateConfigMapReturns(nil, k8s_errors.NewAlreadyExists(api_v1.Resource("configmaps"), "kibosh-lock-my-instance-guid"))
+			fakeCluster.GetConfigMapReturns(lockAt("provision", time.Now().Add(-2*time.Hour)), nil)
+			fakeCluster.UpdateConfigMapReturns(nil, k8s_errors.NewConflict(api_v1.Resource("configmaps"), "kibosh-lock-my-instance-guid", errors.New("modified")))
+
+			_, err := store.Lock("my-instance-guid", "update", time.Hour)
+
+			Expect(err).To(Equal(ErrInstanceLocked))
+		})
+
+		It("unlocks the version of the lock it read", func() {
+			fakeCluster.GetConfigMapReturns(lockAt("provision", time.Now()), nil)
+
+			err := store.Unlock("my-instance-guid", "provision-token")
+
+			Expect(err).To(BeNil())
+			namespace, name, options := fakeCluster.DeleteConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh"))
+			Expect(name).To(Equal("kibosh-lock-my-instance-guid"))
+			Expect(*options.Preconditions.ResourceVersion).To(Equal("42"))
+		})
+
+		It("leaves a lock taken over by another operation", func() {
+			fakeCluster.GetConfigMapReturns(lockAt("update", time.Now()), nil)
+
+			err := store.Unlock("my-instance-guid", "provision-token")
+
+			Expect(err).To(BeNil())
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(0))
+		})
+
+		It("keeps the lock without a token", func() {
+			fakeCluster.GetConfigMapReturns(lockAt("update", time.Now()), nil)
+
+			err := store.Unlock("my-instance-guid", "")
+
+			Expect(err).To(BeNil())
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(0))
+		})
+
+		It("backs off while a binding of the instance is locked", func() {
+			fakeCluster.ListConfigMapsReturns(&api_v1.ConfigMapList{
+				Items: []api_v1.ConfigMap{*lockAt("bind", time.Now())},
+			}, nil)
+
+			_, err := store.Lock("my-instance-guid", "update", time.Hour)
+
+			Expect(err).To(Equal(ErrInstanceLocked))
+			_, options := fakeCluster.ListConfigMapsArgsForCall(0)
+			Expect(options.LabelSelector).To(ContainSubstring("lock=binding,instanceID=my-instance-guid"))
+			_, name, _ := fakeCluster.GetConfigMapArgsForCall(0)
+			Expect(name).To(Equal("kibosh-lock-my-instance-guid"))
+		})
+
+		It("ignores expired binding locks", func() {
+			fakeCluster.ListConfigMapsReturns(&api_v1.ConfigMapList{
+				Items: []api_v1.ConfigMap{*lockAt("bind", time.Now().Add(-2*time.Hour))},
+			}, nil)
+
+			_, err := store.Lock("my-instance-guid", "update", time.Hour)
+
+			Expect(err).To(BeNil())
+		})
+
+		It("creates a lock configmap per binding", func() {
+			token, err := store.LockBinding("my-instance-guid", "my-binding-guid", "bind", time.Hour)
+
+			Expect(err).To(BeNil())
+			namespace, configMap := fakeCluster.CreateConfigMapArgsForCall(0)
+			Expect(namespace).To(Equal("kibosh"))
+			Expect(configMap.Name).To(Equal("kibosh-lock-my-instance-guid-my-binding-guid"))
+			Expect(configMap.Labels["lock"]).To(Equal("binding"))
+			Expect(configMap.Labels["instanceID"]).To(Equal("my-instance-guid"))
+			Expect(configMap.Labels["bindingID"]).To(Equal("my-binding-guid"))
+			Expect(configMap.Data["token"]).To(Equal(token))
+		})
+
+		It("backs off binding while the instance is locked", func() {
+			fakeCluster.GetConfigMapReturns(lockAt("update", time.Now()), nil)
+
+			_, err := store.LockBinding("my-instance-guid", "my-binding-guid", "bind", time.Hour)
+
+			Expect(err).To(Equal(ErrInstanceLocked))
+			_, name, _ := fakeCluster.GetConfigMapArgsForCall(0)
+			Expect(name).To(Equal("kibosh-lock-my-instance-guid"))
+		})
+
+		It("unlocks the binding's lock", func() {
+			fakeCluster.GetConfigMapReturns(lockAt("bind", time.Now()), nil)
+
+			err := store.UnlockBinding("my-instance-guid", "my-binding-guid", "bind-token")
+
+			Expect(err).To(BeNil())
+			_, name, _ := fakeCluster.GetConfigMapArgsForCall(0)
+			Expect(name).To(Equal("kibosh-lock-my-instance-guid-my-binding-guid"))
+			Expect(fakeCluster.DeleteConfigMapCallCount()).To(Equal(1))
+		})
+	})
+})
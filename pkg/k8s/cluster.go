@@ -16,11 +16,16 @@
 package k8s
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/cf-platform-eng/kibosh/pkg/config"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	networking_v1 "k8s.io/api/networking/v1"
 	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
@@ -40,9 +45,14 @@ type Cluster interface {
 
 	CreateNamespaceIfNotExists(*api_v1.Namespace) error
 	NamespaceExists(namespaceName string) (bool, error)
-	GetSecretsAndServices(namespace string) (map[string][]map[string]interface{}, error)
+	GetSecretsAndServices(namespace string, secretExposure string, nodeAddressConfig *config.NodeAddressConfig) (map[string][]map[string]interface{}, error)
+	GetNodeAddresses(nodeAddressConfig *config.NodeAddressConfig) ([]string, error)
 	SecretExists(namespaceName string, secretName string) (bool, error)
 	CreateOrUpdateSecret(namespaceName string, secret *api_v1.Secret) (*api_v1.Secret, error)
+	CreateOrUpdateConfigMap(namespaceName string, configMap *api_v1.ConfigMap) (*api_v1.ConfigMap, error)
+	CreateOrUpdateResourceQuota(namespaceName string, resourceQuota *api_v1.ResourceQuota) (*api_v1.ResourceQuota, error)
+	CreateOrUpdateLimitRange(namespaceName string, limitRange *api_v1.LimitRange) (*api_v1.LimitRange, error)
+	CreateOrUpdateNetworkPolicy(namespaceName string, networkPolicy *networking_v1.NetworkPolicy) (*networking_v1.NetworkPolicy, error)
 	GetIngresses(namespace string) ([]map[string]interface{}, error)
 }
 
@@ -56,6 +66,7 @@ type ClusterDelegate interface {
 	CreateNamespace(*api_v1.Namespace) (*api_v1.Namespace, error)
 	DeleteNamespace(name string, options *meta_v1.DeleteOptions) error
 	GetNamespace(name string, options *meta_v1.GetOptions) (*api_v1.Namespace, error)
+	UpdateNamespace(*api_v1.Namespace) (*api_v1.Namespace, error)
 	GetNamespaces() (*api_v1.NamespaceList, error)
 	ListServiceAccounts(string, meta_v1.ListOptions) (*api_v1.ServiceAccountList, error)
 	CreateServiceAccount(string, *api_v1.ServiceAccount) (*api_v1.ServiceAccount, error)
@@ -64,6 +75,27 @@ type ClusterDelegate interface {
 	CreateSecret(nameSpace string, secret *api_v1.Secret) (*api_v1.Secret, error)
 	UpdateSecret(nameSpace string, secret *api_v1.Secret) (*api_v1.Secret, error)
 	GetSecret(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.Secret, error)
+	DeleteSecret(nameSpace string, name string, options *meta_v1.DeleteOptions) error
+	CreateConfigMap(nameSpace string, configMap *api_v1.ConfigMap) (*api_v1.ConfigMap, error)
+	UpdateConfigMap(nameSpace string, configMap *api_v1.ConfigMap) (*api_v1.ConfigMap, error)
+	GetConfigMap(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.ConfigMap, error)
+	DeleteConfigMap(nameSpace string, name string, options *meta_v1.DeleteOptions) error
+	ListConfigMaps(nameSpace string, listOptions meta_v1.ListOptions) (*api_v1.ConfigMapList, error)
+	CreateResourceQuota(nameSpace string, resourceQuota *api_v1.ResourceQuota) (*api_v1.ResourceQuota, error)
+	UpdateResourceQuota(nameSpace string, resourceQuota *api_v1.ResourceQuota) (*api_v1.ResourceQuota, error)
+	GetResourceQuota(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.ResourceQuota, error)
+	DeleteResourceQuota(nameSpace string, name string, options *meta_v1.DeleteOptions) error
+	CreateLimitRange(nameSpace string, limitRange *api_v1.LimitRange) (*api_v1.LimitRange, error)
+	UpdateLimitRange(nameSpace string, limitRange *api_v1.LimitRange) (*api_v1.LimitRange, error)
+	GetLimitRange(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.LimitRange, error)
+	DeleteLimitRange(nameSpace string, name string, options *meta_v1.DeleteOptions) error
+	CreateNetworkPolicy(nameSpace string, networkPolicy *networking_v1.NetworkPolicy) (*networking_v1.NetworkPolicy, error)
+	UpdateNetworkPolicy(nameSpace string, networkPolicy *networking_v1.NetworkPolicy) (*networking_v1.NetworkPolicy, error)
+	GetNetworkPolicy(nameSpace string, name string, getOptions meta_v1.GetOptions) (*networking_v1.NetworkPolicy, error)
+	DeleteNetworkPolicy(nameSpace string, name string, options *meta_v1.DeleteOptions) error
+	CreateJob(nameSpace string, job *batch_v1.Job) (*batch_v1.Job, error)
+	GetJob(nameSpace string, name string, getOptions meta_v1.GetOptions) (*batch_v1.Job, error)
+	DeleteJob(nameSpace string, name string, options *meta_v1.DeleteOptions) error
 	ListNodes(listOptions meta_v1.ListOptions) (*api_v1.NodeList, error)
 	ListSecrets(nameSpace string, listOptions meta_v1.ListOptions) (*api_v1.SecretList, error)
 	ListServices(nameSpace string, listOptions meta_v1.ListOptions) (*api_v1.ServiceList, error)
@@ -192,45 +224,54 @@ func (cluster *cluster) NamespaceExists(namespaceName string) (bool, error) {
 
 }
 
-func (cluster *cluster) GetSecretsAndServices(namespace string) (map[string][]map[string]interface{}, error) {
+// GetSecretsAndServices returns the secrets of the namespace that secretExposure allows, and its services, adding
+// the node addresses to the external IPs of NodePort services and the endpoints each service can be reached at
+func (cluster *cluster) GetSecretsAndServices(namespace string, secretExposure string, nodeAddressConfig *config.NodeAddressConfig) (map[string][]map[string]interface{}, error) {
 	secrets, err := cluster.ListSecrets(namespace, meta_v1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	secretsMap := []map[string]interface{}{}
+	exposed := []api_v1.Secret{}
 	for _, secret := range secrets.Items {
-		if secret.Type == api_v1.SecretTypeOpaque {
-			credentialSecrets := map[string]string{}
-			for key, val := range secret.Data {
-				credentialSecrets[key] = string(val)
-			}
-			credential := map[string]interface{}{
-				"name": secret.Name,
-				"data": credentialSecrets,
-			}
-			secretsMap = append(secretsMap, credential)
+		if IsSecretExposed(secret, secretExposure) {
+			exposed = append(exposed, secret)
 		}
 	}
+	// typed secrets go last, so indexes into the opaque secrets templates have always had stay the same
+	sort.SliceStable(exposed, func(i, j int) bool {
+		return exposed[i].Type == api_v1.SecretTypeOpaque && exposed[j].Type != api_v1.SecretTypeOpaque
+	})
+
+	secretsMap := []map[string]interface{}{}
+	for i := range exposed {
+		secretsMap = append(secretsMap, SecretToMap(&exposed[i]))
+	}
 
 	services, err := cluster.ListServices(namespace, meta_v1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
+	var nodeAddresses []string
 	servicesMap := []map[string]interface{}{}
 	for _, service := range services.Items {
-		if service.Spec.Type == "NodePort" {
-			nodes, _ := cluster.ListNodes(meta_v1.ListOptions{})
-			for _, node := range nodes.Items {
-				service.Spec.ExternalIPs = append(service.Spec.ExternalIPs, node.ObjectMeta.Labels["spec.ip"])
+		if service.Spec.Type == api_v1.ServiceTypeNodePort && nodeAddresses == nil {
+			nodeAddresses, err = cluster.GetNodeAddresses(nodeAddressConfig)
+			if err != nil {
+				return nil, err
 			}
 		}
+		endpoints := ServiceEndpoints(service, namespace, nodeAddresses)
+		if service.Spec.Type == api_v1.ServiceTypeNodePort {
+			service.Spec.ExternalIPs = append(service.Spec.ExternalIPs, nodeAddresses...)
+		}
 		credentialService := map[string]interface{}{
-			"name":     service.ObjectMeta.Name,
-			"metadata": service.ObjectMeta,
-			"spec":     service.Spec,
-			"status":   service.Status,
+			"name":      service.ObjectMeta.Name,
+			"metadata":  service.ObjectMeta,
+			"spec":      service.Spec,
+			"status":    service.Status,
+			"endpoints": endpoints,
 		}
 		servicesMap = append(servicesMap, credentialService)
 	}
@@ -244,6 +285,157 @@ func (cluster *cluster) GetSecretsAndServices(namespace string) (map[string][]ma
 
 }
 
+// Endpoint is an address a port of a service can be reached at
+type Endpoint struct {
+	// Type is LoadBalancer, NodePort or ClusterIP, the last only being reachable from inside the cluster
+	Type     string `json:"type"`
+	Host     string `json:"host"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+	PortName string `json:"portName"`
+}
+
+// ServiceEndpoints lists the endpoints of every port of the service, those reachable from outside the cluster
+// first. NodePort services are reached through nodeAddresses, and headless services by their DNS name.
+func ServiceEndpoints(service api_v1.Service, namespace string, nodeAddresses []string) []Endpoint {
+	endpoints := []Endpoint{}
+	addEndpoints := func(endpointType string, hosts []string, nodePort bool) {
+		for _, host := range hosts {
+			for _, port := range service.Spec.Ports {
+				endpoint := Endpoint{
+					Type:     endpointType,
+					Host:     host,
+					Port:     port.Port,
+					Protocol: string(port.Protocol),
+					PortName: port.Name,
+				}
+				if nodePort {
+					if port.NodePort == 0 {
+						continue
+					}
+					endpoint.Port = port.NodePort
+				}
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+
+	switch service.Spec.Type {
+	case api_v1.ServiceTypeLoadBalancer:
+		hosts := []string{}
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.Hostname != "" {
+				hosts = append(hosts, ingress.Hostname)
+			} else if ingress.IP != "" {
+				hosts = append(hosts, ingress.IP)
+			}
+		}
+		addEndpoints(string(api_v1.ServiceTypeLoadBalancer), hosts, false)
+	case api_v1.ServiceTypeNodePort:
+		addEndpoints(string(api_v1.ServiceTypeNodePort), nodeAddresses, true)
+	case api_v1.ServiceTypeExternalName:
+		return endpoints
+	}
+
+	clusterHost := service.Spec.ClusterIP
+	if clusterHost == "" || clusterHost == api_v1.ClusterIPNone {
+		clusterHost = fmt.Sprintf("%s.%s.svc", service.Name, namespace)
+	}
+	addEndpoints(string(api_v1.ServiceTypeClusterIP), []string{clusterHost}, false)
+
+	return endpoints
+}
+
+// SecretBindAnnotation opts a secret in to ("true") or out of ("false") being given to bindings
+const SecretBindAnnotation = "kibosh.io/bind"
+
+// SecretExposureAll gives bindings every secret that isn't opted out, and SecretExposureOptIn only those opted in
+const SecretExposureAll = "all"
+const SecretExposureOptIn = "opt-in"
+
+var exposableSecretTypes = []api_v1.SecretType{
+	api_v1.SecretTypeOpaque,
+	api_v1.SecretTypeTLS,
+	api_v1.SecretTypeBasicAuth,
+}
+
+// IsSecretExposed is whether bindings get the secret. Service account tokens and registry credentials never are,
+// and TLS and basic auth secrets, which bindings didn't use to get, only are when opted in.
+func IsSecretExposed(secret api_v1.Secret, secretExposure string) bool {
+	exposable := false
+	for _, secretType := range exposableSecretTypes {
+		exposable = exposable || secret.Type == secretType
+	}
+	if !exposable {
+		return false
+	}
+
+	switch secret.Annotations[SecretBindAnnotation] {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return secret.Type == api_v1.SecretTypeOpaque && secretExposure != SecretExposureOptIn
+}
+
+// SecretToMap is the secret as bind templates see it, with its data decoded
+func SecretToMap(secret *api_v1.Secret) map[string]interface{} {
+	data := map[string]string{}
+	for key, val := range secret.Data {
+		data[key] = string(val)
+	}
+	return map[string]interface{}{
+		"name": secret.Name,
+		"type": string(secret.Type),
+		"data": data,
+	}
+}
+
+// defaultNodeAddressTypes is the order of preference when the config doesn't have one
+var defaultNodeAddressTypes = []string{"ExternalIP", "InternalIP", "Hostname"}
+
+// GetNodeAddresses returns the preferred address of each selected node. Nodes without an address of the preferred
+// types fall back to their spec.ip label, which is where addresses used to be read from.
+func (cluster *cluster) GetNodeAddresses(nodeAddressConfig *config.NodeAddressConfig) ([]string, error) {
+	addressTypes := defaultNodeAddressTypes
+	listOptions := meta_v1.ListOptions{}
+	if nodeAddressConfig != nil {
+		if len(nodeAddressConfig.AddressTypes) > 0 {
+			addressTypes = nodeAddressConfig.AddressTypes
+		}
+		listOptions.LabelSelector = nodeAddressConfig.NodeSelector
+	}
+
+	nodes, err := cluster.ListNodes(listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []string{}
+	for _, node := range nodes.Items {
+		address := preferredNodeAddress(node, addressTypes)
+		if address == "" {
+			address = node.ObjectMeta.Labels["spec.ip"]
+		}
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+func preferredNodeAddress(node api_v1.Node, addressTypes []string) string {
+	for _, addressType := range addressTypes {
+		for _, address := range node.Status.Addresses {
+			if string(address.Type) == addressType && address.Address != "" {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
+
 func (cluster *cluster) GetIngresses(namespace string) ([]map[string]interface{}, error) {
 	ingresses, err := cluster.ListIngresses(namespace, meta_v1.ListOptions{})
 	if err != nil {
@@ -293,6 +485,54 @@ func (cluster *cluster) CreateOrUpdateSecret(namespaceName string, secret *api_v
 	}
 }
 
+func (cluster *cluster) CreateOrUpdateConfigMap(namespaceName string, configMap *api_v1.ConfigMap) (*api_v1.ConfigMap, error) {
+	_, err := cluster.GetConfigMap(namespaceName, configMap.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return cluster.CreateConfigMap(namespaceName, configMap)
+		}
+		return nil, err
+	}
+
+	return cluster.UpdateConfigMap(namespaceName, configMap)
+}
+
+func (cluster *cluster) CreateOrUpdateResourceQuota(namespaceName string, resourceQuota *api_v1.ResourceQuota) (*api_v1.ResourceQuota, error) {
+	_, err := cluster.GetResourceQuota(namespaceName, resourceQuota.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return cluster.CreateResourceQuota(namespaceName, resourceQuota)
+		}
+		return nil, err
+	}
+
+	return cluster.UpdateResourceQuota(namespaceName, resourceQuota)
+}
+
+func (cluster *cluster) CreateOrUpdateLimitRange(namespaceName string, limitRange *api_v1.LimitRange) (*api_v1.LimitRange, error) {
+	_, err := cluster.GetLimitRange(namespaceName, limitRange.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return cluster.CreateLimitRange(namespaceName, limitRange)
+		}
+		return nil, err
+	}
+
+	return cluster.UpdateLimitRange(namespaceName, limitRange)
+}
+
+func (cluster *cluster) CreateOrUpdateNetworkPolicy(namespaceName string, networkPolicy *networking_v1.NetworkPolicy) (*networking_v1.NetworkPolicy, error) {
+	_, err := cluster.GetNetworkPolicy(namespaceName, networkPolicy.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return cluster.CreateNetworkPolicy(namespaceName, networkPolicy)
+		}
+		return nil, err
+	}
+
+	return cluster.UpdateNetworkPolicy(namespaceName, networkPolicy)
+}
+
 func (cluster *clusterDelegate) GetClientConfig() *rest.Config {
 	return cluster.k8sConfig
 }
@@ -329,6 +569,10 @@ func (cluster *clusterDelegate) GetNamespace(name string, options *meta_v1.GetOp
 	}
 }
 
+func (cluster *clusterDelegate) UpdateNamespace(namespace *api_v1.Namespace) (*api_v1.Namespace, error) {
+	return cluster.GetClient().CoreV1().Namespaces().Update(namespace)
+}
+
 func (cluster *clusterDelegate) ListPods(nameSpace string, listOptions meta_v1.ListOptions) (*api_v1.PodList, error) {
 	pods, err := cluster.client.CoreV1().Pods(nameSpace).List(listOptions)
 	if err != nil {
@@ -373,6 +617,90 @@ func (cluster *clusterDelegate) GetSecret(nameSpace string, name string, getOpti
 	return cluster.GetClient().CoreV1().Secrets(nameSpace).Get(name, getOptions)
 }
 
+func (cluster *clusterDelegate) DeleteSecret(nameSpace string, name string, options *meta_v1.DeleteOptions) error {
+	return cluster.GetClient().CoreV1().Secrets(nameSpace).Delete(name, options)
+}
+
+func (cluster *clusterDelegate) CreateConfigMap(nameSpace string, configMap *api_v1.ConfigMap) (*api_v1.ConfigMap, error) {
+	return cluster.GetClient().CoreV1().ConfigMaps(nameSpace).Create(configMap)
+}
+
+func (cluster *clusterDelegate) UpdateConfigMap(nameSpace string, configMap *api_v1.ConfigMap) (*api_v1.ConfigMap, error) {
+	return cluster.GetClient().CoreV1().ConfigMaps(nameSpace).Update(configMap)
+}
+
+func (cluster *clusterDelegate) GetConfigMap(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.ConfigMap, error) {
+	return cluster.GetClient().CoreV1().ConfigMaps(nameSpace).Get(name, getOptions)
+}
+
+func (cluster *clusterDelegate) DeleteConfigMap(nameSpace string, name string, options *meta_v1.DeleteOptions) error {
+	return cluster.GetClient().CoreV1().ConfigMaps(nameSpace).Delete(name, options)
+}
+
+func (cluster *clusterDelegate) ListConfigMaps(nameSpace string, listOptions meta_v1.ListOptions) (*api_v1.ConfigMapList, error) {
+	return cluster.GetClient().CoreV1().ConfigMaps(nameSpace).List(listOptions)
+}
+
+func (cluster *clusterDelegate) CreateResourceQuota(nameSpace string, resourceQuota *api_v1.ResourceQuota) (*api_v1.ResourceQuota, error) {
+	return cluster.GetClient().CoreV1().ResourceQuotas(nameSpace).Create(resourceQuota)
+}
+
+func (cluster *clusterDelegate) UpdateResourceQuota(nameSpace string, resourceQuota *api_v1.ResourceQuota) (*api_v1.ResourceQuota, error) {
+	return cluster.GetClient().CoreV1().ResourceQuotas(nameSpace).Update(resourceQuota)
+}
+
+func (cluster *clusterDelegate) GetResourceQuota(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.ResourceQuota, error) {
+	return cluster.GetClient().CoreV1().ResourceQuotas(nameSpace).Get(name, getOptions)
+}
+
+func (cluster *clusterDelegate) DeleteResourceQuota(nameSpace string, name string, options *meta_v1.DeleteOptions) error {
+	return cluster.GetClient().CoreV1().ResourceQuotas(nameSpace).Delete(name, options)
+}
+
+func (cluster *clusterDelegate) CreateLimitRange(nameSpace string, limitRange *api_v1.LimitRange) (*api_v1.LimitRange, error) {
+	return cluster.GetClient().CoreV1().LimitRanges(nameSpace).Create(limitRange)
+}
+
+func (cluster *clusterDelegate) UpdateLimitRange(nameSpace string, limitRange *api_v1.LimitRange) (*api_v1.LimitRange, error) {
+	return cluster.GetClient().CoreV1().LimitRanges(nameSpace).Update(limitRange)
+}
+
+func (cluster *clusterDelegate) GetLimitRange(nameSpace string, name string, getOptions meta_v1.GetOptions) (*api_v1.LimitRange, error) {
+	return cluster.GetClient().CoreV1().LimitRanges(nameSpace).Get(name, getOptions)
+}
+
+func (cluster *clusterDelegate) DeleteLimitRange(nameSpace string, name string, options *meta_v1.DeleteOptions) error {
+	return cluster.GetClient().CoreV1().LimitRanges(nameSpace).Delete(name, options)
+}
+
+func (cluster *clusterDelegate) CreateNetworkPolicy(nameSpace string, networkPolicy *networking_v1.NetworkPolicy) (*networking_v1.NetworkPolicy, error) {
+	return cluster.GetClient().NetworkingV1().NetworkPolicies(nameSpace).Create(networkPolicy)
+}
+
+func (cluster *clusterDelegate) UpdateNetworkPolicy(nameSpace string, networkPolicy *networking_v1.NetworkPolicy) (*networking_v1.NetworkPolicy, error) {
+	return cluster.GetClient().NetworkingV1().NetworkPolicies(nameSpace).Update(networkPolicy)
+}
+
+func (cluster *clusterDelegate) GetNetworkPolicy(nameSpace string, name string, getOptions meta_v1.GetOptions) (*networking_v1.NetworkPolicy, error) {
+	return cluster.GetClient().NetworkingV1().NetworkPolicies(nameSpace).Get(name, getOptions)
+}
+
+func (cluster *clusterDelegate) DeleteNetworkPolicy(nameSpace string, name string, options *meta_v1.DeleteOptions) error {
+	return cluster.GetClient().NetworkingV1().NetworkPolicies(nameSpace).Delete(name, options)
+}
+
+func (cluster *clusterDelegate) CreateJob(nameSpace string, job *batch_v1.Job) (*batch_v1.Job, error) {
+	return cluster.GetClient().BatchV1().Jobs(nameSpace).Create(job)
+}
+
+func (cluster *clusterDelegate) GetJob(nameSpace string, name string, getOptions meta_v1.GetOptions) (*batch_v1.Job, error) {
+	return cluster.GetClient().BatchV1().Jobs(nameSpace).Get(name, getOptions)
+}
+
+func (cluster *clusterDelegate) DeleteJob(nameSpace string, name string, options *meta_v1.DeleteOptions) error {
+	return cluster.GetClient().BatchV1().Jobs(nameSpace).Delete(name, options)
+}
+
 func (cluster *clusterDelegate) ListSecrets(nameSpace string, listOptions meta_v1.ListOptions) (*api_v1.SecretList, error) {
 	return cluster.GetClient().CoreV1().Secrets(nameSpace).List(listOptions)
 }
@@ -33,6 +33,7 @@ import (
 	. "github.com/onsi/gomega"
 	api_v1 "k8s.io/api/core/v1"
 	v1_beta1 "k8s.io/api/extensions/v1beta1"
+	networking_v1 "k8s.io/api/networking/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sAPI "k8s.io/client-go/tools/clientcmd/api"
@@ -329,7 +330,7 @@ users:
 			cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
 			Expect(err).To(BeNil())
 
-			creds, err := cluster.GetSecretsAndServices("mynamespaceid")
+			creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
 			Expect(err).To(BeNil())
 
 			services := creds["services"]
@@ -376,7 +377,7 @@ users:
 			cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
 			Expect(err).To(BeNil())
 
-			creds, err := cluster.GetSecretsAndServices("mynamespaceid")
+			creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
 
 			services := creds["services"]
 			spec := services[0]["spec"]
@@ -386,7 +387,91 @@ users:
 			Expect(namespace).To(Equal("mynamespaceid"))
 		})
 
-		It("get secrets and services filters to only opaque secrets", func() {
+		Context("node addresses", func() {
+			node := func(addresses ...api_v1.NodeAddress) api_v1.Node {
+				return api_v1.Node{Status: api_v1.NodeStatus{Addresses: addresses}}
+			}
+
+			BeforeEach(func() {
+				fakeClusterDelegate.ListNodesReturns(&api_v1.NodeList{
+					Items: []api_v1.Node{
+						node(
+							api_v1.NodeAddress{Type: api_v1.NodeInternalIP, Address: "10.0.0.1"},
+							api_v1.NodeAddress{Type: api_v1.NodeExternalIP, Address: "35.0.0.1"},
+						),
+						node(
+							api_v1.NodeAddress{Type: api_v1.NodeHostName, Address: "node-2"},
+							api_v1.NodeAddress{Type: api_v1.NodeInternalIP, Address: "10.0.0.2"},
+						),
+						node(api_v1.NodeAddress{Type: api_v1.NodeHostName, Address: "node-3"}),
+					},
+				}, nil)
+			})
+
+			It("prefers external, then internal addresses, then hostnames", func() {
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				addresses, err := cluster.GetNodeAddresses(nil)
+
+				Expect(err).To(BeNil())
+				Expect(addresses).To(Equal([]string{"35.0.0.1", "10.0.0.2", "node-3"}))
+			})
+
+			It("uses the configured preference and node selector", func() {
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				addresses, err := cluster.GetNodeAddresses(&config.NodeAddressConfig{
+					AddressTypes: []string{"InternalIP"},
+					NodeSelector: "pool=workers",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(addresses).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+				Expect(fakeClusterDelegate.ListNodesArgsForCall(0).LabelSelector).To(Equal("pool=workers"))
+			})
+
+			It("lists nodes once for all the NodePort services", func() {
+				fakeClusterDelegate.ListSecretsReturns(&api_v1.SecretList{}, nil)
+				fakeClusterDelegate.ListServicesReturns(&api_v1.ServiceList{
+					Items: []api_v1.Service{
+						{ObjectMeta: meta_v1.ObjectMeta{Name: "mysql"}, Spec: api_v1.ServiceSpec{Type: "NodePort"}},
+						{ObjectMeta: meta_v1.ObjectMeta{Name: "admin"}, Spec: api_v1.ServiceSpec{Type: "NodePort"}},
+						{ObjectMeta: meta_v1.ObjectMeta{Name: "internal"}, Spec: api_v1.ServiceSpec{Type: "ClusterIP"}},
+					},
+				}, nil)
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
+
+				Expect(err).To(BeNil())
+				Expect(fakeClusterDelegate.ListNodesCallCount()).To(Equal(1))
+				services := creds["services"]
+				Expect(services[0]["spec"].(api_v1.ServiceSpec).ExternalIPs).To(Equal([]string{"35.0.0.1", "10.0.0.2", "node-3"}))
+				Expect(services[1]["spec"].(api_v1.ServiceSpec).ExternalIPs).To(Equal([]string{"35.0.0.1", "10.0.0.2", "node-3"}))
+				Expect(services[2]["spec"].(api_v1.ServiceSpec).ExternalIPs).To(BeEmpty())
+			})
+
+			It("bubbles up list nodes errors", func() {
+				fakeClusterDelegate.ListNodesReturns(nil, errors.New("nodes are forbidden"))
+				fakeClusterDelegate.ListSecretsReturns(&api_v1.SecretList{}, nil)
+				fakeClusterDelegate.ListServicesReturns(&api_v1.ServiceList{
+					Items: []api_v1.Service{
+						{ObjectMeta: meta_v1.ObjectMeta{Name: "mysql"}, Spec: api_v1.ServiceSpec{Type: "NodePort"}},
+					},
+				}, nil)
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
+
+				Expect(err).NotTo(BeNil())
+			})
+		})
+
+		It("get secrets and services filters out service account tokens", func() {
 			serviceList := api_v1.ServiceList{Items: []api_v1.Service{}}
 			fakeClusterDelegate.ListServicesReturns(&serviceList, nil)
 
@@ -408,12 +493,159 @@ users:
 			cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
 			Expect(err).To(BeNil())
 
-			creds, err := cluster.GetSecretsAndServices("mynamespaceid")
+			creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
 			Expect(fakeClusterDelegate.ListSecretsCallCount()).To(Equal(1))
 
 			secrets := creds["secrets"]
 			secretsJson, err := json.Marshal(secrets)
-			Expect(string(secretsJson)).To(Equal(`[{"data":{"db-password":"abc123"},"name":"passwords"}]`))
+			Expect(string(secretsJson)).To(Equal(`[{"data":{"db-password":"abc123"},"name":"passwords","type":"Opaque"}]`))
+		})
+
+		Context("secret exposure", func() {
+			secretNames := func(creds map[string][]map[string]interface{}) []interface{} {
+				names := []interface{}{}
+				for _, secret := range creds["secrets"] {
+					names = append(names, secret["name"])
+				}
+				return names
+			}
+
+			BeforeEach(func() {
+				fakeClusterDelegate.ListServicesReturns(&api_v1.ServiceList{}, nil)
+				fakeClusterDelegate.ListSecretsReturns(&api_v1.SecretList{
+					Items: []api_v1.Secret{
+						{
+							ObjectMeta: meta_v1.ObjectMeta{
+								Name:        "ca",
+								Annotations: map[string]string{SecretBindAnnotation: "true"},
+							},
+							Data: map[string][]byte{"tls.crt": []byte("my-cert"), "tls.key": []byte("my-key")},
+							Type: api_v1.SecretTypeTLS,
+						}, {
+							ObjectMeta: meta_v1.ObjectMeta{Name: "ingress-tls"},
+							Data:       map[string][]byte{"tls.crt": []byte("ingress-cert"), "tls.key": []byte("ingress-key")},
+							Type:       api_v1.SecretTypeTLS,
+						}, {
+							ObjectMeta: meta_v1.ObjectMeta{Name: "passwords"},
+							Data:       map[string][]byte{"db-password": []byte("abc123")},
+							Type:       api_v1.SecretTypeOpaque,
+						}, {
+							ObjectMeta: meta_v1.ObjectMeta{
+								Name:        "admin",
+								Annotations: map[string]string{SecretBindAnnotation: "true"},
+							},
+							Data: map[string][]byte{"username": []byte("root"), "password": []byte("hunter2")},
+							Type: api_v1.SecretTypeBasicAuth,
+						}, {
+							ObjectMeta: meta_v1.ObjectMeta{
+								Name:        "internal",
+								Annotations: map[string]string{SecretBindAnnotation: "false"},
+							},
+							Data: map[string][]byte{"replication-key": []byte("xyz")},
+							Type: api_v1.SecretTypeOpaque,
+						},
+					},
+				}, nil)
+			})
+
+			It("includes annotated tls and basic auth secrets after the opaque ones", func() {
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
+				Expect(err).To(BeNil())
+
+				Expect(secretNames(creds)).To(Equal([]interface{}{"passwords", "ca", "admin"}))
+				Expect(creds["secrets"][1]["type"]).To(Equal("kubernetes.io/tls"))
+				Expect(creds["secrets"][1]["data"]).To(Equal(map[string]string{"tls.crt": "my-cert", "tls.key": "my-key"}))
+				Expect(creds["secrets"][2]["data"]).To(HaveKeyWithValue("password", "hunter2"))
+			})
+
+			It("only includes annotated secrets when opt-in", func() {
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureOptIn, nil)
+				Expect(err).To(BeNil())
+
+				Expect(secretNames(creds)).To(Equal([]interface{}{"ca", "admin"}))
+			})
+		})
+
+		Context("endpoints", func() {
+			ports := []api_v1.ServicePort{
+				{Name: "mysql", Port: 3306, NodePort: 30306, Protocol: api_v1.ProtocolTCP},
+			}
+
+			endpointsOf := func(service api_v1.Service) interface{} {
+				fakeClusterDelegate.ListSecretsReturns(&api_v1.SecretList{}, nil)
+				fakeClusterDelegate.ListServicesReturns(&api_v1.ServiceList{Items: []api_v1.Service{service}}, nil)
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
+				Expect(err).To(BeNil())
+
+				return creds["services"][0]["endpoints"]
+			}
+
+			It("lists load balancer hostnames and IPs before the cluster IP", func() {
+				endpoints := endpointsOf(api_v1.Service{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "mysql"},
+					Spec:       api_v1.ServiceSpec{Type: api_v1.ServiceTypeLoadBalancer, ClusterIP: "10.100.0.5", Ports: ports},
+					Status: api_v1.ServiceStatus{LoadBalancer: api_v1.LoadBalancerStatus{
+						Ingress: []api_v1.LoadBalancerIngress{{Hostname: "mysql.elb.example.com"}, {IP: "35.1.1.1"}},
+					}},
+				})
+
+				Expect(endpoints).To(Equal([]Endpoint{
+					{Type: "LoadBalancer", Host: "mysql.elb.example.com", Port: 3306, Protocol: "TCP", PortName: "mysql"},
+					{Type: "LoadBalancer", Host: "35.1.1.1", Port: 3306, Protocol: "TCP", PortName: "mysql"},
+					{Type: "ClusterIP", Host: "10.100.0.5", Port: 3306, Protocol: "TCP", PortName: "mysql"},
+				}))
+			})
+
+			It("uses the node port on each node address", func() {
+				fakeClusterDelegate.ListNodesReturns(&api_v1.NodeList{
+					Items: []api_v1.Node{
+						{Status: api_v1.NodeStatus{Addresses: []api_v1.NodeAddress{{Type: api_v1.NodeExternalIP, Address: "35.0.0.1"}}}},
+						{Status: api_v1.NodeStatus{Addresses: []api_v1.NodeAddress{{Type: api_v1.NodeExternalIP, Address: "35.0.0.2"}}}},
+					},
+				}, nil)
+
+				endpoints := endpointsOf(api_v1.Service{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "mysql"},
+					Spec:       api_v1.ServiceSpec{Type: api_v1.ServiceTypeNodePort, ClusterIP: "10.100.0.5", Ports: ports},
+				})
+
+				Expect(endpoints).To(Equal([]Endpoint{
+					{Type: "NodePort", Host: "35.0.0.1", Port: 30306, Protocol: "TCP", PortName: "mysql"},
+					{Type: "NodePort", Host: "35.0.0.2", Port: 30306, Protocol: "TCP", PortName: "mysql"},
+					{Type: "ClusterIP", Host: "10.100.0.5", Port: 3306, Protocol: "TCP", PortName: "mysql"},
+				}))
+			})
+
+			It("uses the DNS name of headless services", func() {
+				endpoints := endpointsOf(api_v1.Service{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "mysql"},
+					Spec:       api_v1.ServiceSpec{ClusterIP: api_v1.ClusterIPNone, Ports: ports},
+				})
+
+				Expect(endpoints).To(Equal([]Endpoint{
+					{Type: "ClusterIP", Host: "mysql.mynamespaceid.svc", Port: 3306, Protocol: "TCP", PortName: "mysql"},
+				}))
+			})
+
+			It("has no endpoints for a load balancer without an address yet, besides the cluster IP", func() {
+				endpoints := endpointsOf(api_v1.Service{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "mysql"},
+					Spec:       api_v1.ServiceSpec{Type: api_v1.ServiceTypeLoadBalancer, ClusterIP: "10.100.0.5", Ports: ports},
+				})
+
+				Expect(endpoints).To(Equal([]Endpoint{
+					{Type: "ClusterIP", Host: "10.100.0.5", Port: 3306, Protocol: "TCP", PortName: "mysql"},
+				}))
+			})
 		})
 
 		It("bubbles up list secrets errors", func() {
@@ -425,7 +657,7 @@ users:
 			cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
 			Expect(err).To(BeNil())
 
-			_, err = cluster.GetSecretsAndServices("mynamespaceid")
+			_, err = cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
 
 			Expect(fakeClusterDelegate.ListSecretsCallCount()).To(Equal(1))
 		})
@@ -463,7 +695,7 @@ users:
 			cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
 			Expect(err).To(BeNil())
 
-			creds, err := cluster.GetSecretsAndServices("mynamespaceid")
+			creds, err := cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
 
 			Expect(fakeClusterDelegate.ListServicesCallCount()).To(Equal(1))
 
@@ -489,7 +721,7 @@ users:
 			cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
 			Expect(err).To(BeNil())
 
-			_, err = cluster.GetSecretsAndServices("mynamespaceid")
+			_, err = cluster.GetSecretsAndServices("mynamespaceid", SecretExposureAll, nil)
 			Expect(err).NotTo(BeNil())
 		})
 
@@ -558,5 +790,146 @@ users:
 				Expect(fakeClusterDelegate.UpdateSecretCallCount()).To(Equal(1))
 			})
 		})
+
+		Context("config maps", func() {
+			It("creates config map when NOT exists", func() {
+				notFoundError := &k8s_errors.StatusError{ErrStatus: meta_v1.Status{
+					Reason: meta_v1.StatusReasonNotFound},
+				}
+				fakeClusterDelegate.GetConfigMapReturns(nil, notFoundError)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateConfigMap("my-namespace", &api_v1.ConfigMap{
+					Data: map[string]string{"foo": "bar"},
+				})
+
+				Expect(err).To(BeNil())
+
+				Expect(fakeClusterDelegate.CreateConfigMapCallCount()).To(Equal(1))
+				Expect(fakeClusterDelegate.UpdateConfigMapCallCount()).To(Equal(0))
+			})
+
+			It("updates config map when DOES exist", func() {
+				fakeClusterDelegate.GetConfigMapReturns(&api_v1.ConfigMap{}, nil)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateConfigMap("my-namespace", &api_v1.ConfigMap{
+					Data: map[string]string{"foo": "bar"},
+				})
+
+				Expect(err).To(BeNil())
+
+				Expect(fakeClusterDelegate.CreateConfigMapCallCount()).To(Equal(0))
+				Expect(fakeClusterDelegate.UpdateConfigMapCallCount()).To(Equal(1))
+			})
+
+			It("bubbles up unexpected errors", func() {
+				fakeClusterDelegate.GetConfigMapReturns(nil, errors.New("no config maps for you"))
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateConfigMap("my-namespace", &api_v1.ConfigMap{})
+
+				Expect(err).NotTo(BeNil())
+				Expect(fakeClusterDelegate.CreateConfigMapCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("resource quotas", func() {
+			It("creates resource quota when NOT exists", func() {
+				notFoundError := &k8s_errors.StatusError{ErrStatus: meta_v1.Status{
+					Reason: meta_v1.StatusReasonNotFound},
+				}
+				fakeClusterDelegate.GetResourceQuotaReturns(nil, notFoundError)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateResourceQuota("my-namespace", &api_v1.ResourceQuota{})
+
+				Expect(err).To(BeNil())
+				Expect(fakeClusterDelegate.CreateResourceQuotaCallCount()).To(Equal(1))
+				Expect(fakeClusterDelegate.UpdateResourceQuotaCallCount()).To(Equal(0))
+			})
+
+			It("updates resource quota when DOES exist", func() {
+				fakeClusterDelegate.GetResourceQuotaReturns(&api_v1.ResourceQuota{}, nil)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateResourceQuota("my-namespace", &api_v1.ResourceQuota{})
+
+				Expect(err).To(BeNil())
+				Expect(fakeClusterDelegate.CreateResourceQuotaCallCount()).To(Equal(0))
+				Expect(fakeClusterDelegate.UpdateResourceQuotaCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("network policies", func() {
+			It("creates network policy when NOT exists", func() {
+				notFoundError := &k8s_errors.StatusError{ErrStatus: meta_v1.Status{
+					Reason: meta_v1.StatusReasonNotFound},
+				}
+				fakeClusterDelegate.GetNetworkPolicyReturns(nil, notFoundError)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateNetworkPolicy("my-namespace", &networking_v1.NetworkPolicy{})
+
+				Expect(err).To(BeNil())
+				Expect(fakeClusterDelegate.CreateNetworkPolicyCallCount()).To(Equal(1))
+				Expect(fakeClusterDelegate.UpdateNetworkPolicyCallCount()).To(Equal(0))
+			})
+
+			It("updates network policy when DOES exist", func() {
+				fakeClusterDelegate.GetNetworkPolicyReturns(&networking_v1.NetworkPolicy{}, nil)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateNetworkPolicy("my-namespace", &networking_v1.NetworkPolicy{})
+
+				Expect(err).To(BeNil())
+				Expect(fakeClusterDelegate.CreateNetworkPolicyCallCount()).To(Equal(0))
+				Expect(fakeClusterDelegate.UpdateNetworkPolicyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("limit ranges", func() {
+			It("creates limit range when NOT exists", func() {
+				notFoundError := &k8s_errors.StatusError{ErrStatus: meta_v1.Status{
+					Reason: meta_v1.StatusReasonNotFound},
+				}
+				fakeClusterDelegate.GetLimitRangeReturns(nil, notFoundError)
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateLimitRange("my-namespace", &api_v1.LimitRange{})
+
+				Expect(err).To(BeNil())
+				Expect(fakeClusterDelegate.CreateLimitRangeCallCount()).To(Equal(1))
+				Expect(fakeClusterDelegate.UpdateLimitRangeCallCount()).To(Equal(0))
+			})
+
+			It("bubbles up unexpected errors", func() {
+				fakeClusterDelegate.GetLimitRangeReturns(nil, errors.New("no limit ranges for you"))
+
+				cluster, err := NewUnitTestCluster(&fakeClusterDelegate)
+				Expect(err).To(BeNil())
+
+				_, err = cluster.CreateOrUpdateLimitRange("my-namespace", &api_v1.LimitRange{})
+
+				Expect(err).NotTo(BeNil())
+				Expect(fakeClusterDelegate.CreateLimitRangeCallCount()).To(Equal(0))
+			})
+		})
 	})
 })
@@ -4,11 +4,14 @@ package k8sfakes
 import (
 	"sync"
 
+	"github.com/cf-platform-eng/kibosh/pkg/config"
 	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	v1a "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	v1beta1a "k8s.io/api/extensions/v1beta1"
+	v1b "k8s.io/api/networking/v1"
 	"k8s.io/api/rbac/v1beta1"
-	v1a "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1c "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -28,6 +31,48 @@ type FakeCluster struct {
 		result1 *v1beta1.ClusterRoleBinding
 		result2 error
 	}
+	CreateConfigMapStub        func(string, *v1.ConfigMap) (*v1.ConfigMap, error)
+	createConfigMapMutex       sync.RWMutex
+	createConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}
+	createConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	createConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	CreateJobStub        func(string, *v1a.Job) (*v1a.Job, error)
+	createJobMutex       sync.RWMutex
+	createJobArgsForCall []struct {
+		arg1 string
+		arg2 *v1a.Job
+	}
+	createJobReturns struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	createJobReturnsOnCall map[int]struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	CreateLimitRangeStub        func(string, *v1.LimitRange) (*v1.LimitRange, error)
+	createLimitRangeMutex       sync.RWMutex
+	createLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}
+	createLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	createLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
 	CreateNamespaceStub        func(*v1.Namespace) (*v1.Namespace, error)
 	createNamespaceMutex       sync.RWMutex
 	createNamespaceArgsForCall []struct {
@@ -52,6 +97,76 @@ type FakeCluster struct {
 	createNamespaceIfNotExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreateNetworkPolicyStub        func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)
+	createNetworkPolicyMutex       sync.RWMutex
+	createNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}
+	createNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	createNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	CreateOrUpdateConfigMapStub        func(string, *v1.ConfigMap) (*v1.ConfigMap, error)
+	createOrUpdateConfigMapMutex       sync.RWMutex
+	createOrUpdateConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}
+	createOrUpdateConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	createOrUpdateConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	CreateOrUpdateLimitRangeStub        func(string, *v1.LimitRange) (*v1.LimitRange, error)
+	createOrUpdateLimitRangeMutex       sync.RWMutex
+	createOrUpdateLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}
+	createOrUpdateLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	createOrUpdateLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	CreateOrUpdateNetworkPolicyStub        func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)
+	createOrUpdateNetworkPolicyMutex       sync.RWMutex
+	createOrUpdateNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}
+	createOrUpdateNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	createOrUpdateNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	CreateOrUpdateResourceQuotaStub        func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)
+	createOrUpdateResourceQuotaMutex       sync.RWMutex
+	createOrUpdateResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}
+	createOrUpdateResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	createOrUpdateResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
 	CreateOrUpdateSecretStub        func(string, *v1.Secret) (*v1.Secret, error)
 	createOrUpdateSecretMutex       sync.RWMutex
 	createOrUpdateSecretArgsForCall []struct {
@@ -66,6 +181,20 @@ type FakeCluster struct {
 		result1 *v1.Secret
 		result2 error
 	}
+	CreateResourceQuotaStub        func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)
+	createResourceQuotaMutex       sync.RWMutex
+	createResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}
+	createResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	createResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
 	CreateSecretStub        func(string, *v1.Secret) (*v1.Secret, error)
 	createSecretMutex       sync.RWMutex
 	createSecretArgsForCall []struct {
@@ -94,11 +223,50 @@ type FakeCluster struct {
 		result1 *v1.ServiceAccount
 		result2 error
 	}
-	DeleteNamespaceStub        func(string, *v1a.DeleteOptions) error
+	DeleteConfigMapStub        func(string, string, *v1c.DeleteOptions) error
+	deleteConfigMapMutex       sync.RWMutex
+	deleteConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteConfigMapReturns struct {
+		result1 error
+	}
+	deleteConfigMapReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteJobStub        func(string, string, *v1c.DeleteOptions) error
+	deleteJobMutex       sync.RWMutex
+	deleteJobArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteJobReturns struct {
+		result1 error
+	}
+	deleteJobReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteLimitRangeStub        func(string, string, *v1c.DeleteOptions) error
+	deleteLimitRangeMutex       sync.RWMutex
+	deleteLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteLimitRangeReturns struct {
+		result1 error
+	}
+	deleteLimitRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteNamespaceStub        func(string, *v1c.DeleteOptions) error
 	deleteNamespaceMutex       sync.RWMutex
 	deleteNamespaceArgsForCall []struct {
 		arg1 string
-		arg2 *v1a.DeleteOptions
+		arg2 *v1c.DeleteOptions
 	}
 	deleteNamespaceReturns struct {
 		result1 error
@@ -106,6 +274,45 @@ type FakeCluster struct {
 	deleteNamespaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteNetworkPolicyStub        func(string, string, *v1c.DeleteOptions) error
+	deleteNetworkPolicyMutex       sync.RWMutex
+	deleteNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteNetworkPolicyReturns struct {
+		result1 error
+	}
+	deleteNetworkPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteResourceQuotaStub        func(string, string, *v1c.DeleteOptions) error
+	deleteResourceQuotaMutex       sync.RWMutex
+	deleteResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteResourceQuotaReturns struct {
+		result1 error
+	}
+	deleteResourceQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteSecretStub        func(string, string, *v1c.DeleteOptions) error
+	deleteSecretMutex       sync.RWMutex
+	deleteSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteSecretReturns struct {
+		result1 error
+	}
+	deleteSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetClientStub        func() kubernetes.Interface
 	getClientMutex       sync.RWMutex
 	getClientArgsForCall []struct {
@@ -126,12 +333,27 @@ type FakeCluster struct {
 	getClientConfigReturnsOnCall map[int]struct {
 		result1 *rest.Config
 	}
-	GetDeploymentStub        func(string, string, v1a.GetOptions) (*v1beta1a.Deployment, error)
+	GetConfigMapStub        func(string, string, v1c.GetOptions) (*v1.ConfigMap, error)
+	getConfigMapMutex       sync.RWMutex
+	getConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	getConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	GetDeploymentStub        func(string, string, v1c.GetOptions) (*v1beta1a.Deployment, error)
 	getDeploymentMutex       sync.RWMutex
 	getDeploymentArgsForCall []struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}
 	getDeploymentReturns struct {
 		result1 *v1beta1a.Deployment
@@ -154,11 +376,41 @@ type FakeCluster struct {
 		result1 []map[string]interface{}
 		result2 error
 	}
-	GetNamespaceStub        func(string, *v1a.GetOptions) (*v1.Namespace, error)
+	GetJobStub        func(string, string, v1c.GetOptions) (*v1a.Job, error)
+	getJobMutex       sync.RWMutex
+	getJobArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getJobReturns struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	getJobReturnsOnCall map[int]struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	GetLimitRangeStub        func(string, string, v1c.GetOptions) (*v1.LimitRange, error)
+	getLimitRangeMutex       sync.RWMutex
+	getLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	getLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	GetNamespaceStub        func(string, *v1c.GetOptions) (*v1.Namespace, error)
 	getNamespaceMutex       sync.RWMutex
 	getNamespaceArgsForCall []struct {
 		arg1 string
-		arg2 *v1a.GetOptions
+		arg2 *v1c.GetOptions
 	}
 	getNamespaceReturns struct {
 		result1 *v1.Namespace
@@ -180,12 +432,55 @@ type FakeCluster struct {
 		result1 *v1.NamespaceList
 		result2 error
 	}
-	GetSecretStub        func(string, string, v1a.GetOptions) (*v1.Secret, error)
+	GetNetworkPolicyStub        func(string, string, v1c.GetOptions) (*v1b.NetworkPolicy, error)
+	getNetworkPolicyMutex       sync.RWMutex
+	getNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	getNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	GetNodeAddressesStub        func(*config.NodeAddressConfig) ([]string, error)
+	getNodeAddressesMutex       sync.RWMutex
+	getNodeAddressesArgsForCall []struct {
+		arg1 *config.NodeAddressConfig
+	}
+	getNodeAddressesReturns struct {
+		result1 []string
+		result2 error
+	}
+	getNodeAddressesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	GetResourceQuotaStub        func(string, string, v1c.GetOptions) (*v1.ResourceQuota, error)
+	getResourceQuotaMutex       sync.RWMutex
+	getResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	getResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	GetSecretStub        func(string, string, v1c.GetOptions) (*v1.Secret, error)
 	getSecretMutex       sync.RWMutex
 	getSecretArgsForCall []struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}
 	getSecretReturns struct {
 		result1 *v1.Secret
@@ -195,10 +490,12 @@ type FakeCluster struct {
 		result1 *v1.Secret
 		result2 error
 	}
-	GetSecretsAndServicesStub        func(string) (map[string][]map[string]interface{}, error)
+	GetSecretsAndServicesStub        func(string, string, *config.NodeAddressConfig) (map[string][]map[string]interface{}, error)
 	getSecretsAndServicesMutex       sync.RWMutex
 	getSecretsAndServicesArgsForCall []struct {
 		arg1 string
+		arg2 string
+		arg3 *config.NodeAddressConfig
 	}
 	getSecretsAndServicesReturns struct {
 		result1 map[string][]map[string]interface{}
@@ -208,10 +505,10 @@ type FakeCluster struct {
 		result1 map[string][]map[string]interface{}
 		result2 error
 	}
-	ListClusterRoleBindingsStub        func(v1a.ListOptions) (*v1beta1.ClusterRoleBindingList, error)
+	ListClusterRoleBindingsStub        func(v1c.ListOptions) (*v1beta1.ClusterRoleBindingList, error)
 	listClusterRoleBindingsMutex       sync.RWMutex
 	listClusterRoleBindingsArgsForCall []struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}
 	listClusterRoleBindingsReturns struct {
 		result1 *v1beta1.ClusterRoleBindingList
@@ -221,11 +518,25 @@ type FakeCluster struct {
 		result1 *v1beta1.ClusterRoleBindingList
 		result2 error
 	}
-	ListDeploymentsStub        func(string, v1a.ListOptions) (*k8s.DeploymentList, error)
+	ListConfigMapsStub        func(string, v1c.ListOptions) (*v1.ConfigMapList, error)
+	listConfigMapsMutex       sync.RWMutex
+	listConfigMapsArgsForCall []struct {
+		arg1 string
+		arg2 v1c.ListOptions
+	}
+	listConfigMapsReturns struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}
+	listConfigMapsReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}
+	ListDeploymentsStub        func(string, v1c.ListOptions) (*k8s.DeploymentList, error)
 	listDeploymentsMutex       sync.RWMutex
 	listDeploymentsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listDeploymentsReturns struct {
 		result1 *k8s.DeploymentList
@@ -235,11 +546,11 @@ type FakeCluster struct {
 		result1 *k8s.DeploymentList
 		result2 error
 	}
-	ListIngressesStub        func(string, v1a.ListOptions) (*v1beta1a.IngressList, error)
+	ListIngressesStub        func(string, v1c.ListOptions) (*v1beta1a.IngressList, error)
 	listIngressesMutex       sync.RWMutex
 	listIngressesArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listIngressesReturns struct {
 		result1 *v1beta1a.IngressList
@@ -249,10 +560,10 @@ type FakeCluster struct {
 		result1 *v1beta1a.IngressList
 		result2 error
 	}
-	ListNodesStub        func(v1a.ListOptions) (*v1.NodeList, error)
+	ListNodesStub        func(v1c.ListOptions) (*v1.NodeList, error)
 	listNodesMutex       sync.RWMutex
 	listNodesArgsForCall []struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}
 	listNodesReturns struct {
 		result1 *v1.NodeList
@@ -262,11 +573,11 @@ type FakeCluster struct {
 		result1 *v1.NodeList
 		result2 error
 	}
-	ListPersistentVolumesStub        func(string, v1a.ListOptions) (*v1.PersistentVolumeClaimList, error)
+	ListPersistentVolumesStub        func(string, v1c.ListOptions) (*v1.PersistentVolumeClaimList, error)
 	listPersistentVolumesMutex       sync.RWMutex
 	listPersistentVolumesArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listPersistentVolumesReturns struct {
 		result1 *v1.PersistentVolumeClaimList
@@ -276,11 +587,11 @@ type FakeCluster struct {
 		result1 *v1.PersistentVolumeClaimList
 		result2 error
 	}
-	ListPodsStub        func(string, v1a.ListOptions) (*v1.PodList, error)
+	ListPodsStub        func(string, v1c.ListOptions) (*v1.PodList, error)
 	listPodsMutex       sync.RWMutex
 	listPodsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listPodsReturns struct {
 		result1 *v1.PodList
@@ -290,11 +601,11 @@ type FakeCluster struct {
 		result1 *v1.PodList
 		result2 error
 	}
-	ListSecretsStub        func(string, v1a.ListOptions) (*v1.SecretList, error)
+	ListSecretsStub        func(string, v1c.ListOptions) (*v1.SecretList, error)
 	listSecretsMutex       sync.RWMutex
 	listSecretsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listSecretsReturns struct {
 		result1 *v1.SecretList
@@ -304,11 +615,11 @@ type FakeCluster struct {
 		result1 *v1.SecretList
 		result2 error
 	}
-	ListServiceAccountsStub        func(string, v1a.ListOptions) (*v1.ServiceAccountList, error)
+	ListServiceAccountsStub        func(string, v1c.ListOptions) (*v1.ServiceAccountList, error)
 	listServiceAccountsMutex       sync.RWMutex
 	listServiceAccountsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listServiceAccountsReturns struct {
 		result1 *v1.ServiceAccountList
@@ -318,11 +629,11 @@ type FakeCluster struct {
 		result1 *v1.ServiceAccountList
 		result2 error
 	}
-	ListServicesStub        func(string, v1a.ListOptions) (*v1.ServiceList, error)
+	ListServicesStub        func(string, v1c.ListOptions) (*v1.ServiceList, error)
 	listServicesMutex       sync.RWMutex
 	listServicesArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listServicesReturns struct {
 		result1 *v1.ServiceList
@@ -376,6 +687,75 @@ type FakeCluster struct {
 		result1 bool
 		result2 error
 	}
+	UpdateConfigMapStub        func(string, *v1.ConfigMap) (*v1.ConfigMap, error)
+	updateConfigMapMutex       sync.RWMutex
+	updateConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}
+	updateConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	updateConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	UpdateLimitRangeStub        func(string, *v1.LimitRange) (*v1.LimitRange, error)
+	updateLimitRangeMutex       sync.RWMutex
+	updateLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}
+	updateLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	updateLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	UpdateNamespaceStub        func(*v1.Namespace) (*v1.Namespace, error)
+	updateNamespaceMutex       sync.RWMutex
+	updateNamespaceArgsForCall []struct {
+		arg1 *v1.Namespace
+	}
+	updateNamespaceReturns struct {
+		result1 *v1.Namespace
+		result2 error
+	}
+	updateNamespaceReturnsOnCall map[int]struct {
+		result1 *v1.Namespace
+		result2 error
+	}
+	UpdateNetworkPolicyStub        func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)
+	updateNetworkPolicyMutex       sync.RWMutex
+	updateNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}
+	updateNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	updateNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	UpdateResourceQuotaStub        func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)
+	updateResourceQuotaMutex       sync.RWMutex
+	updateResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}
+	updateResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	updateResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
 	UpdateSecretStub        func(string, *v1.Secret) (*v1.Secret, error)
 	updateSecretMutex       sync.RWMutex
 	updateSecretArgsForCall []struct {
@@ -457,6 +837,198 @@ func (fake *FakeCluster) CreateClusterRoleBindingReturnsOnCall(i int, result1 *v
 	}{result1, result2}
 }
 
+func (fake *FakeCluster) CreateConfigMap(arg1 string, arg2 *v1.ConfigMap) (*v1.ConfigMap, error) {
+	fake.createConfigMapMutex.Lock()
+	ret, specificReturn := fake.createConfigMapReturnsOnCall[len(fake.createConfigMapArgsForCall)]
+	fake.createConfigMapArgsForCall = append(fake.createConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}{arg1, arg2})
+	fake.recordInvocation("CreateConfigMap", []interface{}{arg1, arg2})
+	fake.createConfigMapMutex.Unlock()
+	if fake.CreateConfigMapStub != nil {
+		return fake.CreateConfigMapStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateConfigMapCallCount() int {
+	fake.createConfigMapMutex.RLock()
+	defer fake.createConfigMapMutex.RUnlock()
+	return len(fake.createConfigMapArgsForCall)
+}
+
+func (fake *FakeCluster) CreateConfigMapCalls(stub func(string, *v1.ConfigMap) (*v1.ConfigMap, error)) {
+	fake.createConfigMapMutex.Lock()
+	defer fake.createConfigMapMutex.Unlock()
+	fake.CreateConfigMapStub = stub
+}
+
+func (fake *FakeCluster) CreateConfigMapArgsForCall(i int) (string, *v1.ConfigMap) {
+	fake.createConfigMapMutex.RLock()
+	defer fake.createConfigMapMutex.RUnlock()
+	argsForCall := fake.createConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.createConfigMapMutex.Lock()
+	defer fake.createConfigMapMutex.Unlock()
+	fake.CreateConfigMapStub = nil
+	fake.createConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.createConfigMapMutex.Lock()
+	defer fake.createConfigMapMutex.Unlock()
+	fake.CreateConfigMapStub = nil
+	if fake.createConfigMapReturnsOnCall == nil {
+		fake.createConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.createConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateJob(arg1 string, arg2 *v1a.Job) (*v1a.Job, error) {
+	fake.createJobMutex.Lock()
+	ret, specificReturn := fake.createJobReturnsOnCall[len(fake.createJobArgsForCall)]
+	fake.createJobArgsForCall = append(fake.createJobArgsForCall, struct {
+		arg1 string
+		arg2 *v1a.Job
+	}{arg1, arg2})
+	fake.recordInvocation("CreateJob", []interface{}{arg1, arg2})
+	fake.createJobMutex.Unlock()
+	if fake.CreateJobStub != nil {
+		return fake.CreateJobStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createJobReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateJobCallCount() int {
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	return len(fake.createJobArgsForCall)
+}
+
+func (fake *FakeCluster) CreateJobCalls(stub func(string, *v1a.Job) (*v1a.Job, error)) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = stub
+}
+
+func (fake *FakeCluster) CreateJobArgsForCall(i int) (string, *v1a.Job) {
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	argsForCall := fake.createJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateJobReturns(result1 *v1a.Job, result2 error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = nil
+	fake.createJobReturns = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateJobReturnsOnCall(i int, result1 *v1a.Job, result2 error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = nil
+	if fake.createJobReturnsOnCall == nil {
+		fake.createJobReturnsOnCall = make(map[int]struct {
+			result1 *v1a.Job
+			result2 error
+		})
+	}
+	fake.createJobReturnsOnCall[i] = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateLimitRange(arg1 string, arg2 *v1.LimitRange) (*v1.LimitRange, error) {
+	fake.createLimitRangeMutex.Lock()
+	ret, specificReturn := fake.createLimitRangeReturnsOnCall[len(fake.createLimitRangeArgsForCall)]
+	fake.createLimitRangeArgsForCall = append(fake.createLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}{arg1, arg2})
+	fake.recordInvocation("CreateLimitRange", []interface{}{arg1, arg2})
+	fake.createLimitRangeMutex.Unlock()
+	if fake.CreateLimitRangeStub != nil {
+		return fake.CreateLimitRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createLimitRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateLimitRangeCallCount() int {
+	fake.createLimitRangeMutex.RLock()
+	defer fake.createLimitRangeMutex.RUnlock()
+	return len(fake.createLimitRangeArgsForCall)
+}
+
+func (fake *FakeCluster) CreateLimitRangeCalls(stub func(string, *v1.LimitRange) (*v1.LimitRange, error)) {
+	fake.createLimitRangeMutex.Lock()
+	defer fake.createLimitRangeMutex.Unlock()
+	fake.CreateLimitRangeStub = stub
+}
+
+func (fake *FakeCluster) CreateLimitRangeArgsForCall(i int) (string, *v1.LimitRange) {
+	fake.createLimitRangeMutex.RLock()
+	defer fake.createLimitRangeMutex.RUnlock()
+	argsForCall := fake.createLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.createLimitRangeMutex.Lock()
+	defer fake.createLimitRangeMutex.Unlock()
+	fake.CreateLimitRangeStub = nil
+	fake.createLimitRangeReturns = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.createLimitRangeMutex.Lock()
+	defer fake.createLimitRangeMutex.Unlock()
+	fake.CreateLimitRangeStub = nil
+	if fake.createLimitRangeReturnsOnCall == nil {
+		fake.createLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
+			result2 error
+		})
+	}
+	fake.createLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCluster) CreateNamespace(arg1 *v1.Namespace) (*v1.Namespace, error) {
 	fake.createNamespaceMutex.Lock()
 	ret, specificReturn := fake.createNamespaceReturnsOnCall[len(fake.createNamespaceArgsForCall)]
@@ -580,6 +1152,326 @@ func (fake *FakeCluster) CreateNamespaceIfNotExistsReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCluster) CreateNetworkPolicy(arg1 string, arg2 *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error) {
+	fake.createNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.createNetworkPolicyReturnsOnCall[len(fake.createNetworkPolicyArgsForCall)]
+	fake.createNetworkPolicyArgsForCall = append(fake.createNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}{arg1, arg2})
+	fake.recordInvocation("CreateNetworkPolicy", []interface{}{arg1, arg2})
+	fake.createNetworkPolicyMutex.Unlock()
+	if fake.CreateNetworkPolicyStub != nil {
+		return fake.CreateNetworkPolicyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createNetworkPolicyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateNetworkPolicyCallCount() int {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	return len(fake.createNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeCluster) CreateNetworkPolicyCalls(stub func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = stub
+}
+
+func (fake *FakeCluster) CreateNetworkPolicyArgsForCall(i int) (string, *v1b.NetworkPolicy) {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.createNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	fake.createNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	if fake.createNetworkPolicyReturnsOnCall == nil {
+		fake.createNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.createNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateConfigMap(arg1 string, arg2 *v1.ConfigMap) (*v1.ConfigMap, error) {
+	fake.createOrUpdateConfigMapMutex.Lock()
+	ret, specificReturn := fake.createOrUpdateConfigMapReturnsOnCall[len(fake.createOrUpdateConfigMapArgsForCall)]
+	fake.createOrUpdateConfigMapArgsForCall = append(fake.createOrUpdateConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}{arg1, arg2})
+	fake.recordInvocation("CreateOrUpdateConfigMap", []interface{}{arg1, arg2})
+	fake.createOrUpdateConfigMapMutex.Unlock()
+	if fake.CreateOrUpdateConfigMapStub != nil {
+		return fake.CreateOrUpdateConfigMapStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOrUpdateConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateOrUpdateConfigMapCallCount() int {
+	fake.createOrUpdateConfigMapMutex.RLock()
+	defer fake.createOrUpdateConfigMapMutex.RUnlock()
+	return len(fake.createOrUpdateConfigMapArgsForCall)
+}
+
+func (fake *FakeCluster) CreateOrUpdateConfigMapCalls(stub func(string, *v1.ConfigMap) (*v1.ConfigMap, error)) {
+	fake.createOrUpdateConfigMapMutex.Lock()
+	defer fake.createOrUpdateConfigMapMutex.Unlock()
+	fake.CreateOrUpdateConfigMapStub = stub
+}
+
+func (fake *FakeCluster) CreateOrUpdateConfigMapArgsForCall(i int) (string, *v1.ConfigMap) {
+	fake.createOrUpdateConfigMapMutex.RLock()
+	defer fake.createOrUpdateConfigMapMutex.RUnlock()
+	argsForCall := fake.createOrUpdateConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateOrUpdateConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.createOrUpdateConfigMapMutex.Lock()
+	defer fake.createOrUpdateConfigMapMutex.Unlock()
+	fake.CreateOrUpdateConfigMapStub = nil
+	fake.createOrUpdateConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.createOrUpdateConfigMapMutex.Lock()
+	defer fake.createOrUpdateConfigMapMutex.Unlock()
+	fake.CreateOrUpdateConfigMapStub = nil
+	if fake.createOrUpdateConfigMapReturnsOnCall == nil {
+		fake.createOrUpdateConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.createOrUpdateConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateLimitRange(arg1 string, arg2 *v1.LimitRange) (*v1.LimitRange, error) {
+	fake.createOrUpdateLimitRangeMutex.Lock()
+	ret, specificReturn := fake.createOrUpdateLimitRangeReturnsOnCall[len(fake.createOrUpdateLimitRangeArgsForCall)]
+	fake.createOrUpdateLimitRangeArgsForCall = append(fake.createOrUpdateLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}{arg1, arg2})
+	fake.recordInvocation("CreateOrUpdateLimitRange", []interface{}{arg1, arg2})
+	fake.createOrUpdateLimitRangeMutex.Unlock()
+	if fake.CreateOrUpdateLimitRangeStub != nil {
+		return fake.CreateOrUpdateLimitRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOrUpdateLimitRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateOrUpdateLimitRangeCallCount() int {
+	fake.createOrUpdateLimitRangeMutex.RLock()
+	defer fake.createOrUpdateLimitRangeMutex.RUnlock()
+	return len(fake.createOrUpdateLimitRangeArgsForCall)
+}
+
+func (fake *FakeCluster) CreateOrUpdateLimitRangeCalls(stub func(string, *v1.LimitRange) (*v1.LimitRange, error)) {
+	fake.createOrUpdateLimitRangeMutex.Lock()
+	defer fake.createOrUpdateLimitRangeMutex.Unlock()
+	fake.CreateOrUpdateLimitRangeStub = stub
+}
+
+func (fake *FakeCluster) CreateOrUpdateLimitRangeArgsForCall(i int) (string, *v1.LimitRange) {
+	fake.createOrUpdateLimitRangeMutex.RLock()
+	defer fake.createOrUpdateLimitRangeMutex.RUnlock()
+	argsForCall := fake.createOrUpdateLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateOrUpdateLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.createOrUpdateLimitRangeMutex.Lock()
+	defer fake.createOrUpdateLimitRangeMutex.Unlock()
+	fake.CreateOrUpdateLimitRangeStub = nil
+	fake.createOrUpdateLimitRangeReturns = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.createOrUpdateLimitRangeMutex.Lock()
+	defer fake.createOrUpdateLimitRangeMutex.Unlock()
+	fake.CreateOrUpdateLimitRangeStub = nil
+	if fake.createOrUpdateLimitRangeReturnsOnCall == nil {
+		fake.createOrUpdateLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
+			result2 error
+		})
+	}
+	fake.createOrUpdateLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateNetworkPolicy(arg1 string, arg2 *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error) {
+	fake.createOrUpdateNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.createOrUpdateNetworkPolicyReturnsOnCall[len(fake.createOrUpdateNetworkPolicyArgsForCall)]
+	fake.createOrUpdateNetworkPolicyArgsForCall = append(fake.createOrUpdateNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}{arg1, arg2})
+	fake.recordInvocation("CreateOrUpdateNetworkPolicy", []interface{}{arg1, arg2})
+	fake.createOrUpdateNetworkPolicyMutex.Unlock()
+	if fake.CreateOrUpdateNetworkPolicyStub != nil {
+		return fake.CreateOrUpdateNetworkPolicyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOrUpdateNetworkPolicyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateOrUpdateNetworkPolicyCallCount() int {
+	fake.createOrUpdateNetworkPolicyMutex.RLock()
+	defer fake.createOrUpdateNetworkPolicyMutex.RUnlock()
+	return len(fake.createOrUpdateNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeCluster) CreateOrUpdateNetworkPolicyCalls(stub func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)) {
+	fake.createOrUpdateNetworkPolicyMutex.Lock()
+	defer fake.createOrUpdateNetworkPolicyMutex.Unlock()
+	fake.CreateOrUpdateNetworkPolicyStub = stub
+}
+
+func (fake *FakeCluster) CreateOrUpdateNetworkPolicyArgsForCall(i int) (string, *v1b.NetworkPolicy) {
+	fake.createOrUpdateNetworkPolicyMutex.RLock()
+	defer fake.createOrUpdateNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.createOrUpdateNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateOrUpdateNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.createOrUpdateNetworkPolicyMutex.Lock()
+	defer fake.createOrUpdateNetworkPolicyMutex.Unlock()
+	fake.CreateOrUpdateNetworkPolicyStub = nil
+	fake.createOrUpdateNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.createOrUpdateNetworkPolicyMutex.Lock()
+	defer fake.createOrUpdateNetworkPolicyMutex.Unlock()
+	fake.CreateOrUpdateNetworkPolicyStub = nil
+	if fake.createOrUpdateNetworkPolicyReturnsOnCall == nil {
+		fake.createOrUpdateNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.createOrUpdateNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateResourceQuota(arg1 string, arg2 *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	fake.createOrUpdateResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.createOrUpdateResourceQuotaReturnsOnCall[len(fake.createOrUpdateResourceQuotaArgsForCall)]
+	fake.createOrUpdateResourceQuotaArgsForCall = append(fake.createOrUpdateResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}{arg1, arg2})
+	fake.recordInvocation("CreateOrUpdateResourceQuota", []interface{}{arg1, arg2})
+	fake.createOrUpdateResourceQuotaMutex.Unlock()
+	if fake.CreateOrUpdateResourceQuotaStub != nil {
+		return fake.CreateOrUpdateResourceQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOrUpdateResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateOrUpdateResourceQuotaCallCount() int {
+	fake.createOrUpdateResourceQuotaMutex.RLock()
+	defer fake.createOrUpdateResourceQuotaMutex.RUnlock()
+	return len(fake.createOrUpdateResourceQuotaArgsForCall)
+}
+
+func (fake *FakeCluster) CreateOrUpdateResourceQuotaCalls(stub func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)) {
+	fake.createOrUpdateResourceQuotaMutex.Lock()
+	defer fake.createOrUpdateResourceQuotaMutex.Unlock()
+	fake.CreateOrUpdateResourceQuotaStub = stub
+}
+
+func (fake *FakeCluster) CreateOrUpdateResourceQuotaArgsForCall(i int) (string, *v1.ResourceQuota) {
+	fake.createOrUpdateResourceQuotaMutex.RLock()
+	defer fake.createOrUpdateResourceQuotaMutex.RUnlock()
+	argsForCall := fake.createOrUpdateResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateOrUpdateResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.createOrUpdateResourceQuotaMutex.Lock()
+	defer fake.createOrUpdateResourceQuotaMutex.Unlock()
+	fake.CreateOrUpdateResourceQuotaStub = nil
+	fake.createOrUpdateResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateOrUpdateResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.createOrUpdateResourceQuotaMutex.Lock()
+	defer fake.createOrUpdateResourceQuotaMutex.Unlock()
+	fake.CreateOrUpdateResourceQuotaStub = nil
+	if fake.createOrUpdateResourceQuotaReturnsOnCall == nil {
+		fake.createOrUpdateResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.createOrUpdateResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCluster) CreateOrUpdateSecret(arg1 string, arg2 *v1.Secret) (*v1.Secret, error) {
 	fake.createOrUpdateSecretMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateSecretReturnsOnCall[len(fake.createOrUpdateSecretArgsForCall)]
@@ -644,6 +1536,70 @@ func (fake *FakeCluster) CreateOrUpdateSecretReturnsOnCall(i int, result1 *v1.Se
 	}{result1, result2}
 }
 
+func (fake *FakeCluster) CreateResourceQuota(arg1 string, arg2 *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	fake.createResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.createResourceQuotaReturnsOnCall[len(fake.createResourceQuotaArgsForCall)]
+	fake.createResourceQuotaArgsForCall = append(fake.createResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}{arg1, arg2})
+	fake.recordInvocation("CreateResourceQuota", []interface{}{arg1, arg2})
+	fake.createResourceQuotaMutex.Unlock()
+	if fake.CreateResourceQuotaStub != nil {
+		return fake.CreateResourceQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) CreateResourceQuotaCallCount() int {
+	fake.createResourceQuotaMutex.RLock()
+	defer fake.createResourceQuotaMutex.RUnlock()
+	return len(fake.createResourceQuotaArgsForCall)
+}
+
+func (fake *FakeCluster) CreateResourceQuotaCalls(stub func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)) {
+	fake.createResourceQuotaMutex.Lock()
+	defer fake.createResourceQuotaMutex.Unlock()
+	fake.CreateResourceQuotaStub = stub
+}
+
+func (fake *FakeCluster) CreateResourceQuotaArgsForCall(i int) (string, *v1.ResourceQuota) {
+	fake.createResourceQuotaMutex.RLock()
+	defer fake.createResourceQuotaMutex.RUnlock()
+	argsForCall := fake.createResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) CreateResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.createResourceQuotaMutex.Lock()
+	defer fake.createResourceQuotaMutex.Unlock()
+	fake.CreateResourceQuotaStub = nil
+	fake.createResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) CreateResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.createResourceQuotaMutex.Lock()
+	defer fake.createResourceQuotaMutex.Unlock()
+	fake.CreateResourceQuotaStub = nil
+	if fake.createResourceQuotaReturnsOnCall == nil {
+		fake.createResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.createResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCluster) CreateSecret(arg1 string, arg2 *v1.Secret) (*v1.Secret, error) {
 	fake.createSecretMutex.Lock()
 	ret, specificReturn := fake.createSecretReturnsOnCall[len(fake.createSecretArgsForCall)]
@@ -772,12 +1728,198 @@ func (fake *FakeCluster) CreateServiceAccountReturnsOnCall(i int, result1 *v1.Se
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) DeleteNamespace(arg1 string, arg2 *v1a.DeleteOptions) error {
+func (fake *FakeCluster) DeleteConfigMap(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteConfigMapMutex.Lock()
+	ret, specificReturn := fake.deleteConfigMapReturnsOnCall[len(fake.deleteConfigMapArgsForCall)]
+	fake.deleteConfigMapArgsForCall = append(fake.deleteConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteConfigMap", []interface{}{arg1, arg2, arg3})
+	fake.deleteConfigMapMutex.Unlock()
+	if fake.DeleteConfigMapStub != nil {
+		return fake.DeleteConfigMapStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteConfigMapReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCluster) DeleteConfigMapCallCount() int {
+	fake.deleteConfigMapMutex.RLock()
+	defer fake.deleteConfigMapMutex.RUnlock()
+	return len(fake.deleteConfigMapArgsForCall)
+}
+
+func (fake *FakeCluster) DeleteConfigMapCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteConfigMapMutex.Lock()
+	defer fake.deleteConfigMapMutex.Unlock()
+	fake.DeleteConfigMapStub = stub
+}
+
+func (fake *FakeCluster) DeleteConfigMapArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteConfigMapMutex.RLock()
+	defer fake.deleteConfigMapMutex.RUnlock()
+	argsForCall := fake.deleteConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) DeleteConfigMapReturns(result1 error) {
+	fake.deleteConfigMapMutex.Lock()
+	defer fake.deleteConfigMapMutex.Unlock()
+	fake.DeleteConfigMapStub = nil
+	fake.deleteConfigMapReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteConfigMapReturnsOnCall(i int, result1 error) {
+	fake.deleteConfigMapMutex.Lock()
+	defer fake.deleteConfigMapMutex.Unlock()
+	fake.DeleteConfigMapStub = nil
+	if fake.deleteConfigMapReturnsOnCall == nil {
+		fake.deleteConfigMapReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteConfigMapReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteJob(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteJobMutex.Lock()
+	ret, specificReturn := fake.deleteJobReturnsOnCall[len(fake.deleteJobArgsForCall)]
+	fake.deleteJobArgsForCall = append(fake.deleteJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteJob", []interface{}{arg1, arg2, arg3})
+	fake.deleteJobMutex.Unlock()
+	if fake.DeleteJobStub != nil {
+		return fake.DeleteJobStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteJobReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCluster) DeleteJobCallCount() int {
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	return len(fake.deleteJobArgsForCall)
+}
+
+func (fake *FakeCluster) DeleteJobCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = stub
+}
+
+func (fake *FakeCluster) DeleteJobArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	argsForCall := fake.deleteJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) DeleteJobReturns(result1 error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = nil
+	fake.deleteJobReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteJobReturnsOnCall(i int, result1 error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = nil
+	if fake.deleteJobReturnsOnCall == nil {
+		fake.deleteJobReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteJobReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteLimitRange(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteLimitRangeMutex.Lock()
+	ret, specificReturn := fake.deleteLimitRangeReturnsOnCall[len(fake.deleteLimitRangeArgsForCall)]
+	fake.deleteLimitRangeArgsForCall = append(fake.deleteLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteLimitRange", []interface{}{arg1, arg2, arg3})
+	fake.deleteLimitRangeMutex.Unlock()
+	if fake.DeleteLimitRangeStub != nil {
+		return fake.DeleteLimitRangeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteLimitRangeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCluster) DeleteLimitRangeCallCount() int {
+	fake.deleteLimitRangeMutex.RLock()
+	defer fake.deleteLimitRangeMutex.RUnlock()
+	return len(fake.deleteLimitRangeArgsForCall)
+}
+
+func (fake *FakeCluster) DeleteLimitRangeCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteLimitRangeMutex.Lock()
+	defer fake.deleteLimitRangeMutex.Unlock()
+	fake.DeleteLimitRangeStub = stub
+}
+
+func (fake *FakeCluster) DeleteLimitRangeArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteLimitRangeMutex.RLock()
+	defer fake.deleteLimitRangeMutex.RUnlock()
+	argsForCall := fake.deleteLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) DeleteLimitRangeReturns(result1 error) {
+	fake.deleteLimitRangeMutex.Lock()
+	defer fake.deleteLimitRangeMutex.Unlock()
+	fake.DeleteLimitRangeStub = nil
+	fake.deleteLimitRangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteLimitRangeReturnsOnCall(i int, result1 error) {
+	fake.deleteLimitRangeMutex.Lock()
+	defer fake.deleteLimitRangeMutex.Unlock()
+	fake.DeleteLimitRangeStub = nil
+	if fake.deleteLimitRangeReturnsOnCall == nil {
+		fake.deleteLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteLimitRangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteNamespace(arg1 string, arg2 *v1c.DeleteOptions) error {
 	fake.deleteNamespaceMutex.Lock()
 	ret, specificReturn := fake.deleteNamespaceReturnsOnCall[len(fake.deleteNamespaceArgsForCall)]
 	fake.deleteNamespaceArgsForCall = append(fake.deleteNamespaceArgsForCall, struct {
 		arg1 string
-		arg2 *v1a.DeleteOptions
+		arg2 *v1c.DeleteOptions
 	}{arg1, arg2})
 	fake.recordInvocation("DeleteNamespace", []interface{}{arg1, arg2})
 	fake.deleteNamespaceMutex.Unlock()
@@ -797,13 +1939,13 @@ func (fake *FakeCluster) DeleteNamespaceCallCount() int {
 	return len(fake.deleteNamespaceArgsForCall)
 }
 
-func (fake *FakeCluster) DeleteNamespaceCalls(stub func(string, *v1a.DeleteOptions) error) {
+func (fake *FakeCluster) DeleteNamespaceCalls(stub func(string, *v1c.DeleteOptions) error) {
 	fake.deleteNamespaceMutex.Lock()
 	defer fake.deleteNamespaceMutex.Unlock()
 	fake.DeleteNamespaceStub = stub
 }
 
-func (fake *FakeCluster) DeleteNamespaceArgsForCall(i int) (string, *v1a.DeleteOptions) {
+func (fake *FakeCluster) DeleteNamespaceArgsForCall(i int) (string, *v1c.DeleteOptions) {
 	fake.deleteNamespaceMutex.RLock()
 	defer fake.deleteNamespaceMutex.RUnlock()
 	argsForCall := fake.deleteNamespaceArgsForCall[i]
@@ -833,6 +1975,192 @@ func (fake *FakeCluster) DeleteNamespaceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeCluster) DeleteNetworkPolicy(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.deleteNetworkPolicyReturnsOnCall[len(fake.deleteNetworkPolicyArgsForCall)]
+	fake.deleteNetworkPolicyArgsForCall = append(fake.deleteNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.deleteNetworkPolicyMutex.Unlock()
+	if fake.DeleteNetworkPolicyStub != nil {
+		return fake.DeleteNetworkPolicyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteNetworkPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCluster) DeleteNetworkPolicyCallCount() int {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	return len(fake.deleteNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeCluster) DeleteNetworkPolicyCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = stub
+}
+
+func (fake *FakeCluster) DeleteNetworkPolicyArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.deleteNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) DeleteNetworkPolicyReturns(result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	fake.deleteNetworkPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteNetworkPolicyReturnsOnCall(i int, result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	if fake.deleteNetworkPolicyReturnsOnCall == nil {
+		fake.deleteNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteNetworkPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteResourceQuota(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.deleteResourceQuotaReturnsOnCall[len(fake.deleteResourceQuotaArgsForCall)]
+	fake.deleteResourceQuotaArgsForCall = append(fake.deleteResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteResourceQuota", []interface{}{arg1, arg2, arg3})
+	fake.deleteResourceQuotaMutex.Unlock()
+	if fake.DeleteResourceQuotaStub != nil {
+		return fake.DeleteResourceQuotaStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteResourceQuotaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCluster) DeleteResourceQuotaCallCount() int {
+	fake.deleteResourceQuotaMutex.RLock()
+	defer fake.deleteResourceQuotaMutex.RUnlock()
+	return len(fake.deleteResourceQuotaArgsForCall)
+}
+
+func (fake *FakeCluster) DeleteResourceQuotaCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteResourceQuotaMutex.Lock()
+	defer fake.deleteResourceQuotaMutex.Unlock()
+	fake.DeleteResourceQuotaStub = stub
+}
+
+func (fake *FakeCluster) DeleteResourceQuotaArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteResourceQuotaMutex.RLock()
+	defer fake.deleteResourceQuotaMutex.RUnlock()
+	argsForCall := fake.deleteResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) DeleteResourceQuotaReturns(result1 error) {
+	fake.deleteResourceQuotaMutex.Lock()
+	defer fake.deleteResourceQuotaMutex.Unlock()
+	fake.DeleteResourceQuotaStub = nil
+	fake.deleteResourceQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteResourceQuotaReturnsOnCall(i int, result1 error) {
+	fake.deleteResourceQuotaMutex.Lock()
+	defer fake.deleteResourceQuotaMutex.Unlock()
+	fake.DeleteResourceQuotaStub = nil
+	if fake.deleteResourceQuotaReturnsOnCall == nil {
+		fake.deleteResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteResourceQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteSecret(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteSecretMutex.Lock()
+	ret, specificReturn := fake.deleteSecretReturnsOnCall[len(fake.deleteSecretArgsForCall)]
+	fake.deleteSecretArgsForCall = append(fake.deleteSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteSecret", []interface{}{arg1, arg2, arg3})
+	fake.deleteSecretMutex.Unlock()
+	if fake.DeleteSecretStub != nil {
+		return fake.DeleteSecretStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSecretReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCluster) DeleteSecretCallCount() int {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	return len(fake.deleteSecretArgsForCall)
+}
+
+func (fake *FakeCluster) DeleteSecretCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = stub
+}
+
+func (fake *FakeCluster) DeleteSecretArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	argsForCall := fake.deleteSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) DeleteSecretReturns(result1 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	fake.deleteSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCluster) DeleteSecretReturnsOnCall(i int, result1 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	if fake.deleteSecretReturnsOnCall == nil {
+		fake.deleteSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCluster) GetClient() kubernetes.Interface {
 	fake.getClientMutex.Lock()
 	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
@@ -937,13 +2265,78 @@ func (fake *FakeCluster) GetClientConfigReturnsOnCall(i int, result1 *rest.Confi
 	}{result1}
 }
 
-func (fake *FakeCluster) GetDeployment(arg1 string, arg2 string, arg3 v1a.GetOptions) (*v1beta1a.Deployment, error) {
+func (fake *FakeCluster) GetConfigMap(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.ConfigMap, error) {
+	fake.getConfigMapMutex.Lock()
+	ret, specificReturn := fake.getConfigMapReturnsOnCall[len(fake.getConfigMapArgsForCall)]
+	fake.getConfigMapArgsForCall = append(fake.getConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetConfigMap", []interface{}{arg1, arg2, arg3})
+	fake.getConfigMapMutex.Unlock()
+	if fake.GetConfigMapStub != nil {
+		return fake.GetConfigMapStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) GetConfigMapCallCount() int {
+	fake.getConfigMapMutex.RLock()
+	defer fake.getConfigMapMutex.RUnlock()
+	return len(fake.getConfigMapArgsForCall)
+}
+
+func (fake *FakeCluster) GetConfigMapCalls(stub func(string, string, v1c.GetOptions) (*v1.ConfigMap, error)) {
+	fake.getConfigMapMutex.Lock()
+	defer fake.getConfigMapMutex.Unlock()
+	fake.GetConfigMapStub = stub
+}
+
+func (fake *FakeCluster) GetConfigMapArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getConfigMapMutex.RLock()
+	defer fake.getConfigMapMutex.RUnlock()
+	argsForCall := fake.getConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) GetConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.getConfigMapMutex.Lock()
+	defer fake.getConfigMapMutex.Unlock()
+	fake.GetConfigMapStub = nil
+	fake.getConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.getConfigMapMutex.Lock()
+	defer fake.getConfigMapMutex.Unlock()
+	fake.GetConfigMapStub = nil
+	if fake.getConfigMapReturnsOnCall == nil {
+		fake.getConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.getConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetDeployment(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1beta1a.Deployment, error) {
 	fake.getDeploymentMutex.Lock()
 	ret, specificReturn := fake.getDeploymentReturnsOnCall[len(fake.getDeploymentArgsForCall)]
 	fake.getDeploymentArgsForCall = append(fake.getDeploymentArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}{arg1, arg2, arg3})
 	fake.recordInvocation("GetDeployment", []interface{}{arg1, arg2, arg3})
 	fake.getDeploymentMutex.Unlock()
@@ -963,13 +2356,13 @@ func (fake *FakeCluster) GetDeploymentCallCount() int {
 	return len(fake.getDeploymentArgsForCall)
 }
 
-func (fake *FakeCluster) GetDeploymentCalls(stub func(string, string, v1a.GetOptions) (*v1beta1a.Deployment, error)) {
+func (fake *FakeCluster) GetDeploymentCalls(stub func(string, string, v1c.GetOptions) (*v1beta1a.Deployment, error)) {
 	fake.getDeploymentMutex.Lock()
 	defer fake.getDeploymentMutex.Unlock()
 	fake.GetDeploymentStub = stub
 }
 
-func (fake *FakeCluster) GetDeploymentArgsForCall(i int) (string, string, v1a.GetOptions) {
+func (fake *FakeCluster) GetDeploymentArgsForCall(i int) (string, string, v1c.GetOptions) {
 	fake.getDeploymentMutex.RLock()
 	defer fake.getDeploymentMutex.RUnlock()
 	argsForCall := fake.getDeploymentArgsForCall[i]
@@ -1059,18 +2452,148 @@ func (fake *FakeCluster) GetIngressesReturnsOnCall(i int, result1 []map[string]i
 			result2 error
 		})
 	}
-	fake.getIngressesReturnsOnCall[i] = struct {
-		result1 []map[string]interface{}
+	fake.getIngressesReturnsOnCall[i] = struct {
+		result1 []map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetJob(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1a.Job, error) {
+	fake.getJobMutex.Lock()
+	ret, specificReturn := fake.getJobReturnsOnCall[len(fake.getJobArgsForCall)]
+	fake.getJobArgsForCall = append(fake.getJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetJob", []interface{}{arg1, arg2, arg3})
+	fake.getJobMutex.Unlock()
+	if fake.GetJobStub != nil {
+		return fake.GetJobStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getJobReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) GetJobCallCount() int {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	return len(fake.getJobArgsForCall)
+}
+
+func (fake *FakeCluster) GetJobCalls(stub func(string, string, v1c.GetOptions) (*v1a.Job, error)) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = stub
+}
+
+func (fake *FakeCluster) GetJobArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	argsForCall := fake.getJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) GetJobReturns(result1 *v1a.Job, result2 error) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = nil
+	fake.getJobReturns = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetJobReturnsOnCall(i int, result1 *v1a.Job, result2 error) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = nil
+	if fake.getJobReturnsOnCall == nil {
+		fake.getJobReturnsOnCall = make(map[int]struct {
+			result1 *v1a.Job
+			result2 error
+		})
+	}
+	fake.getJobReturnsOnCall[i] = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetLimitRange(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.LimitRange, error) {
+	fake.getLimitRangeMutex.Lock()
+	ret, specificReturn := fake.getLimitRangeReturnsOnCall[len(fake.getLimitRangeArgsForCall)]
+	fake.getLimitRangeArgsForCall = append(fake.getLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetLimitRange", []interface{}{arg1, arg2, arg3})
+	fake.getLimitRangeMutex.Unlock()
+	if fake.GetLimitRangeStub != nil {
+		return fake.GetLimitRangeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getLimitRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) GetLimitRangeCallCount() int {
+	fake.getLimitRangeMutex.RLock()
+	defer fake.getLimitRangeMutex.RUnlock()
+	return len(fake.getLimitRangeArgsForCall)
+}
+
+func (fake *FakeCluster) GetLimitRangeCalls(stub func(string, string, v1c.GetOptions) (*v1.LimitRange, error)) {
+	fake.getLimitRangeMutex.Lock()
+	defer fake.getLimitRangeMutex.Unlock()
+	fake.GetLimitRangeStub = stub
+}
+
+func (fake *FakeCluster) GetLimitRangeArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getLimitRangeMutex.RLock()
+	defer fake.getLimitRangeMutex.RUnlock()
+	argsForCall := fake.getLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) GetLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.getLimitRangeMutex.Lock()
+	defer fake.getLimitRangeMutex.Unlock()
+	fake.GetLimitRangeStub = nil
+	fake.getLimitRangeReturns = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.getLimitRangeMutex.Lock()
+	defer fake.getLimitRangeMutex.Unlock()
+	fake.GetLimitRangeStub = nil
+	if fake.getLimitRangeReturnsOnCall == nil {
+		fake.getLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
+			result2 error
+		})
+	}
+	fake.getLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) GetNamespace(arg1 string, arg2 *v1a.GetOptions) (*v1.Namespace, error) {
+func (fake *FakeCluster) GetNamespace(arg1 string, arg2 *v1c.GetOptions) (*v1.Namespace, error) {
 	fake.getNamespaceMutex.Lock()
 	ret, specificReturn := fake.getNamespaceReturnsOnCall[len(fake.getNamespaceArgsForCall)]
 	fake.getNamespaceArgsForCall = append(fake.getNamespaceArgsForCall, struct {
 		arg1 string
-		arg2 *v1a.GetOptions
+		arg2 *v1c.GetOptions
 	}{arg1, arg2})
 	fake.recordInvocation("GetNamespace", []interface{}{arg1, arg2})
 	fake.getNamespaceMutex.Unlock()
@@ -1090,13 +2613,13 @@ func (fake *FakeCluster) GetNamespaceCallCount() int {
 	return len(fake.getNamespaceArgsForCall)
 }
 
-func (fake *FakeCluster) GetNamespaceCalls(stub func(string, *v1a.GetOptions) (*v1.Namespace, error)) {
+func (fake *FakeCluster) GetNamespaceCalls(stub func(string, *v1c.GetOptions) (*v1.Namespace, error)) {
 	fake.getNamespaceMutex.Lock()
 	defer fake.getNamespaceMutex.Unlock()
 	fake.GetNamespaceStub = stub
 }
 
-func (fake *FakeCluster) GetNamespaceArgsForCall(i int) (string, *v1a.GetOptions) {
+func (fake *FakeCluster) GetNamespaceArgsForCall(i int) (string, *v1c.GetOptions) {
 	fake.getNamespaceMutex.RLock()
 	defer fake.getNamespaceMutex.RUnlock()
 	argsForCall := fake.getNamespaceArgsForCall[i]
@@ -1184,13 +2707,206 @@ func (fake *FakeCluster) GetNamespacesReturnsOnCall(i int, result1 *v1.Namespace
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) GetSecret(arg1 string, arg2 string, arg3 v1a.GetOptions) (*v1.Secret, error) {
+func (fake *FakeCluster) GetNetworkPolicy(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1b.NetworkPolicy, error) {
+	fake.getNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.getNetworkPolicyReturnsOnCall[len(fake.getNetworkPolicyArgsForCall)]
+	fake.getNetworkPolicyArgsForCall = append(fake.getNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.getNetworkPolicyMutex.Unlock()
+	if fake.GetNetworkPolicyStub != nil {
+		return fake.GetNetworkPolicyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getNetworkPolicyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) GetNetworkPolicyCallCount() int {
+	fake.getNetworkPolicyMutex.RLock()
+	defer fake.getNetworkPolicyMutex.RUnlock()
+	return len(fake.getNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeCluster) GetNetworkPolicyCalls(stub func(string, string, v1c.GetOptions) (*v1b.NetworkPolicy, error)) {
+	fake.getNetworkPolicyMutex.Lock()
+	defer fake.getNetworkPolicyMutex.Unlock()
+	fake.GetNetworkPolicyStub = stub
+}
+
+func (fake *FakeCluster) GetNetworkPolicyArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getNetworkPolicyMutex.RLock()
+	defer fake.getNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.getNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) GetNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.getNetworkPolicyMutex.Lock()
+	defer fake.getNetworkPolicyMutex.Unlock()
+	fake.GetNetworkPolicyStub = nil
+	fake.getNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.getNetworkPolicyMutex.Lock()
+	defer fake.getNetworkPolicyMutex.Unlock()
+	fake.GetNetworkPolicyStub = nil
+	if fake.getNetworkPolicyReturnsOnCall == nil {
+		fake.getNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.getNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetNodeAddresses(arg1 *config.NodeAddressConfig) ([]string, error) {
+	fake.getNodeAddressesMutex.Lock()
+	ret, specificReturn := fake.getNodeAddressesReturnsOnCall[len(fake.getNodeAddressesArgsForCall)]
+	fake.getNodeAddressesArgsForCall = append(fake.getNodeAddressesArgsForCall, struct {
+		arg1 *config.NodeAddressConfig
+	}{arg1})
+	fake.recordInvocation("GetNodeAddresses", []interface{}{arg1})
+	fake.getNodeAddressesMutex.Unlock()
+	if fake.GetNodeAddressesStub != nil {
+		return fake.GetNodeAddressesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getNodeAddressesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) GetNodeAddressesCallCount() int {
+	fake.getNodeAddressesMutex.RLock()
+	defer fake.getNodeAddressesMutex.RUnlock()
+	return len(fake.getNodeAddressesArgsForCall)
+}
+
+func (fake *FakeCluster) GetNodeAddressesCalls(stub func(*config.NodeAddressConfig) ([]string, error)) {
+	fake.getNodeAddressesMutex.Lock()
+	defer fake.getNodeAddressesMutex.Unlock()
+	fake.GetNodeAddressesStub = stub
+}
+
+func (fake *FakeCluster) GetNodeAddressesArgsForCall(i int) *config.NodeAddressConfig {
+	fake.getNodeAddressesMutex.RLock()
+	defer fake.getNodeAddressesMutex.RUnlock()
+	argsForCall := fake.getNodeAddressesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCluster) GetNodeAddressesReturns(result1 []string, result2 error) {
+	fake.getNodeAddressesMutex.Lock()
+	defer fake.getNodeAddressesMutex.Unlock()
+	fake.GetNodeAddressesStub = nil
+	fake.getNodeAddressesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetNodeAddressesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getNodeAddressesMutex.Lock()
+	defer fake.getNodeAddressesMutex.Unlock()
+	fake.GetNodeAddressesStub = nil
+	if fake.getNodeAddressesReturnsOnCall == nil {
+		fake.getNodeAddressesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getNodeAddressesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetResourceQuota(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.ResourceQuota, error) {
+	fake.getResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.getResourceQuotaReturnsOnCall[len(fake.getResourceQuotaArgsForCall)]
+	fake.getResourceQuotaArgsForCall = append(fake.getResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetResourceQuota", []interface{}{arg1, arg2, arg3})
+	fake.getResourceQuotaMutex.Unlock()
+	if fake.GetResourceQuotaStub != nil {
+		return fake.GetResourceQuotaStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) GetResourceQuotaCallCount() int {
+	fake.getResourceQuotaMutex.RLock()
+	defer fake.getResourceQuotaMutex.RUnlock()
+	return len(fake.getResourceQuotaArgsForCall)
+}
+
+func (fake *FakeCluster) GetResourceQuotaCalls(stub func(string, string, v1c.GetOptions) (*v1.ResourceQuota, error)) {
+	fake.getResourceQuotaMutex.Lock()
+	defer fake.getResourceQuotaMutex.Unlock()
+	fake.GetResourceQuotaStub = stub
+}
+
+func (fake *FakeCluster) GetResourceQuotaArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getResourceQuotaMutex.RLock()
+	defer fake.getResourceQuotaMutex.RUnlock()
+	argsForCall := fake.getResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCluster) GetResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.getResourceQuotaMutex.Lock()
+	defer fake.getResourceQuotaMutex.Unlock()
+	fake.GetResourceQuotaStub = nil
+	fake.getResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.getResourceQuotaMutex.Lock()
+	defer fake.getResourceQuotaMutex.Unlock()
+	fake.GetResourceQuotaStub = nil
+	if fake.getResourceQuotaReturnsOnCall == nil {
+		fake.getResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.getResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) GetSecret(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.Secret, error) {
 	fake.getSecretMutex.Lock()
 	ret, specificReturn := fake.getSecretReturnsOnCall[len(fake.getSecretArgsForCall)]
 	fake.getSecretArgsForCall = append(fake.getSecretArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}{arg1, arg2, arg3})
 	fake.recordInvocation("GetSecret", []interface{}{arg1, arg2, arg3})
 	fake.getSecretMutex.Unlock()
@@ -1210,13 +2926,13 @@ func (fake *FakeCluster) GetSecretCallCount() int {
 	return len(fake.getSecretArgsForCall)
 }
 
-func (fake *FakeCluster) GetSecretCalls(stub func(string, string, v1a.GetOptions) (*v1.Secret, error)) {
+func (fake *FakeCluster) GetSecretCalls(stub func(string, string, v1c.GetOptions) (*v1.Secret, error)) {
 	fake.getSecretMutex.Lock()
 	defer fake.getSecretMutex.Unlock()
 	fake.GetSecretStub = stub
 }
 
-func (fake *FakeCluster) GetSecretArgsForCall(i int) (string, string, v1a.GetOptions) {
+func (fake *FakeCluster) GetSecretArgsForCall(i int) (string, string, v1c.GetOptions) {
 	fake.getSecretMutex.RLock()
 	defer fake.getSecretMutex.RUnlock()
 	argsForCall := fake.getSecretArgsForCall[i]
@@ -1249,16 +2965,18 @@ func (fake *FakeCluster) GetSecretReturnsOnCall(i int, result1 *v1.Secret, resul
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) GetSecretsAndServices(arg1 string) (map[string][]map[string]interface{}, error) {
+func (fake *FakeCluster) GetSecretsAndServices(arg1 string, arg2 string, arg3 *config.NodeAddressConfig) (map[string][]map[string]interface{}, error) {
 	fake.getSecretsAndServicesMutex.Lock()
 	ret, specificReturn := fake.getSecretsAndServicesReturnsOnCall[len(fake.getSecretsAndServicesArgsForCall)]
 	fake.getSecretsAndServicesArgsForCall = append(fake.getSecretsAndServicesArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	fake.recordInvocation("GetSecretsAndServices", []interface{}{arg1})
+		arg2 string
+		arg3 *config.NodeAddressConfig
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetSecretsAndServices", []interface{}{arg1, arg2, arg3})
 	fake.getSecretsAndServicesMutex.Unlock()
 	if fake.GetSecretsAndServicesStub != nil {
-		return fake.GetSecretsAndServicesStub(arg1)
+		return fake.GetSecretsAndServicesStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1273,17 +2991,17 @@ func (fake *FakeCluster) GetSecretsAndServicesCallCount() int {
 	return len(fake.getSecretsAndServicesArgsForCall)
 }
 
-func (fake *FakeCluster) GetSecretsAndServicesCalls(stub func(string) (map[string][]map[string]interface{}, error)) {
+func (fake *FakeCluster) GetSecretsAndServicesCalls(stub func(string, string, *config.NodeAddressConfig) (map[string][]map[string]interface{}, error)) {
 	fake.getSecretsAndServicesMutex.Lock()
 	defer fake.getSecretsAndServicesMutex.Unlock()
 	fake.GetSecretsAndServicesStub = stub
 }
 
-func (fake *FakeCluster) GetSecretsAndServicesArgsForCall(i int) string {
+func (fake *FakeCluster) GetSecretsAndServicesArgsForCall(i int) (string, string, *config.NodeAddressConfig) {
 	fake.getSecretsAndServicesMutex.RLock()
 	defer fake.getSecretsAndServicesMutex.RUnlock()
 	argsForCall := fake.getSecretsAndServicesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeCluster) GetSecretsAndServicesReturns(result1 map[string][]map[string]interface{}, result2 error) {
@@ -1312,11 +3030,11 @@ func (fake *FakeCluster) GetSecretsAndServicesReturnsOnCall(i int, result1 map[s
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListClusterRoleBindings(arg1 v1a.ListOptions) (*v1beta1.ClusterRoleBindingList, error) {
+func (fake *FakeCluster) ListClusterRoleBindings(arg1 v1c.ListOptions) (*v1beta1.ClusterRoleBindingList, error) {
 	fake.listClusterRoleBindingsMutex.Lock()
 	ret, specificReturn := fake.listClusterRoleBindingsReturnsOnCall[len(fake.listClusterRoleBindingsArgsForCall)]
 	fake.listClusterRoleBindingsArgsForCall = append(fake.listClusterRoleBindingsArgsForCall, struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}{arg1})
 	fake.recordInvocation("ListClusterRoleBindings", []interface{}{arg1})
 	fake.listClusterRoleBindingsMutex.Unlock()
@@ -1336,13 +3054,13 @@ func (fake *FakeCluster) ListClusterRoleBindingsCallCount() int {
 	return len(fake.listClusterRoleBindingsArgsForCall)
 }
 
-func (fake *FakeCluster) ListClusterRoleBindingsCalls(stub func(v1a.ListOptions) (*v1beta1.ClusterRoleBindingList, error)) {
+func (fake *FakeCluster) ListClusterRoleBindingsCalls(stub func(v1c.ListOptions) (*v1beta1.ClusterRoleBindingList, error)) {
 	fake.listClusterRoleBindingsMutex.Lock()
 	defer fake.listClusterRoleBindingsMutex.Unlock()
 	fake.ListClusterRoleBindingsStub = stub
 }
 
-func (fake *FakeCluster) ListClusterRoleBindingsArgsForCall(i int) v1a.ListOptions {
+func (fake *FakeCluster) ListClusterRoleBindingsArgsForCall(i int) v1c.ListOptions {
 	fake.listClusterRoleBindingsMutex.RLock()
 	defer fake.listClusterRoleBindingsMutex.RUnlock()
 	argsForCall := fake.listClusterRoleBindingsArgsForCall[i]
@@ -1375,12 +3093,76 @@ func (fake *FakeCluster) ListClusterRoleBindingsReturnsOnCall(i int, result1 *v1
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListDeployments(arg1 string, arg2 v1a.ListOptions) (*k8s.DeploymentList, error) {
+func (fake *FakeCluster) ListConfigMaps(arg1 string, arg2 v1c.ListOptions) (*v1.ConfigMapList, error) {
+	fake.listConfigMapsMutex.Lock()
+	ret, specificReturn := fake.listConfigMapsReturnsOnCall[len(fake.listConfigMapsArgsForCall)]
+	fake.listConfigMapsArgsForCall = append(fake.listConfigMapsArgsForCall, struct {
+		arg1 string
+		arg2 v1c.ListOptions
+	}{arg1, arg2})
+	fake.recordInvocation("ListConfigMaps", []interface{}{arg1, arg2})
+	fake.listConfigMapsMutex.Unlock()
+	if fake.ListConfigMapsStub != nil {
+		return fake.ListConfigMapsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listConfigMapsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) ListConfigMapsCallCount() int {
+	fake.listConfigMapsMutex.RLock()
+	defer fake.listConfigMapsMutex.RUnlock()
+	return len(fake.listConfigMapsArgsForCall)
+}
+
+func (fake *FakeCluster) ListConfigMapsCalls(stub func(string, v1c.ListOptions) (*v1.ConfigMapList, error)) {
+	fake.listConfigMapsMutex.Lock()
+	defer fake.listConfigMapsMutex.Unlock()
+	fake.ListConfigMapsStub = stub
+}
+
+func (fake *FakeCluster) ListConfigMapsArgsForCall(i int) (string, v1c.ListOptions) {
+	fake.listConfigMapsMutex.RLock()
+	defer fake.listConfigMapsMutex.RUnlock()
+	argsForCall := fake.listConfigMapsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) ListConfigMapsReturns(result1 *v1.ConfigMapList, result2 error) {
+	fake.listConfigMapsMutex.Lock()
+	defer fake.listConfigMapsMutex.Unlock()
+	fake.ListConfigMapsStub = nil
+	fake.listConfigMapsReturns = struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) ListConfigMapsReturnsOnCall(i int, result1 *v1.ConfigMapList, result2 error) {
+	fake.listConfigMapsMutex.Lock()
+	defer fake.listConfigMapsMutex.Unlock()
+	fake.ListConfigMapsStub = nil
+	if fake.listConfigMapsReturnsOnCall == nil {
+		fake.listConfigMapsReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMapList
+			result2 error
+		})
+	}
+	fake.listConfigMapsReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) ListDeployments(arg1 string, arg2 v1c.ListOptions) (*k8s.DeploymentList, error) {
 	fake.listDeploymentsMutex.Lock()
 	ret, specificReturn := fake.listDeploymentsReturnsOnCall[len(fake.listDeploymentsArgsForCall)]
 	fake.listDeploymentsArgsForCall = append(fake.listDeploymentsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListDeployments", []interface{}{arg1, arg2})
 	fake.listDeploymentsMutex.Unlock()
@@ -1400,13 +3182,13 @@ func (fake *FakeCluster) ListDeploymentsCallCount() int {
 	return len(fake.listDeploymentsArgsForCall)
 }
 
-func (fake *FakeCluster) ListDeploymentsCalls(stub func(string, v1a.ListOptions) (*k8s.DeploymentList, error)) {
+func (fake *FakeCluster) ListDeploymentsCalls(stub func(string, v1c.ListOptions) (*k8s.DeploymentList, error)) {
 	fake.listDeploymentsMutex.Lock()
 	defer fake.listDeploymentsMutex.Unlock()
 	fake.ListDeploymentsStub = stub
 }
 
-func (fake *FakeCluster) ListDeploymentsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListDeploymentsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listDeploymentsMutex.RLock()
 	defer fake.listDeploymentsMutex.RUnlock()
 	argsForCall := fake.listDeploymentsArgsForCall[i]
@@ -1439,12 +3221,12 @@ func (fake *FakeCluster) ListDeploymentsReturnsOnCall(i int, result1 *k8s.Deploy
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListIngresses(arg1 string, arg2 v1a.ListOptions) (*v1beta1a.IngressList, error) {
+func (fake *FakeCluster) ListIngresses(arg1 string, arg2 v1c.ListOptions) (*v1beta1a.IngressList, error) {
 	fake.listIngressesMutex.Lock()
 	ret, specificReturn := fake.listIngressesReturnsOnCall[len(fake.listIngressesArgsForCall)]
 	fake.listIngressesArgsForCall = append(fake.listIngressesArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListIngresses", []interface{}{arg1, arg2})
 	fake.listIngressesMutex.Unlock()
@@ -1464,13 +3246,13 @@ func (fake *FakeCluster) ListIngressesCallCount() int {
 	return len(fake.listIngressesArgsForCall)
 }
 
-func (fake *FakeCluster) ListIngressesCalls(stub func(string, v1a.ListOptions) (*v1beta1a.IngressList, error)) {
+func (fake *FakeCluster) ListIngressesCalls(stub func(string, v1c.ListOptions) (*v1beta1a.IngressList, error)) {
 	fake.listIngressesMutex.Lock()
 	defer fake.listIngressesMutex.Unlock()
 	fake.ListIngressesStub = stub
 }
 
-func (fake *FakeCluster) ListIngressesArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListIngressesArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listIngressesMutex.RLock()
 	defer fake.listIngressesMutex.RUnlock()
 	argsForCall := fake.listIngressesArgsForCall[i]
@@ -1503,11 +3285,11 @@ func (fake *FakeCluster) ListIngressesReturnsOnCall(i int, result1 *v1beta1a.Ing
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListNodes(arg1 v1a.ListOptions) (*v1.NodeList, error) {
+func (fake *FakeCluster) ListNodes(arg1 v1c.ListOptions) (*v1.NodeList, error) {
 	fake.listNodesMutex.Lock()
 	ret, specificReturn := fake.listNodesReturnsOnCall[len(fake.listNodesArgsForCall)]
 	fake.listNodesArgsForCall = append(fake.listNodesArgsForCall, struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}{arg1})
 	fake.recordInvocation("ListNodes", []interface{}{arg1})
 	fake.listNodesMutex.Unlock()
@@ -1527,13 +3309,13 @@ func (fake *FakeCluster) ListNodesCallCount() int {
 	return len(fake.listNodesArgsForCall)
 }
 
-func (fake *FakeCluster) ListNodesCalls(stub func(v1a.ListOptions) (*v1.NodeList, error)) {
+func (fake *FakeCluster) ListNodesCalls(stub func(v1c.ListOptions) (*v1.NodeList, error)) {
 	fake.listNodesMutex.Lock()
 	defer fake.listNodesMutex.Unlock()
 	fake.ListNodesStub = stub
 }
 
-func (fake *FakeCluster) ListNodesArgsForCall(i int) v1a.ListOptions {
+func (fake *FakeCluster) ListNodesArgsForCall(i int) v1c.ListOptions {
 	fake.listNodesMutex.RLock()
 	defer fake.listNodesMutex.RUnlock()
 	argsForCall := fake.listNodesArgsForCall[i]
@@ -1566,12 +3348,12 @@ func (fake *FakeCluster) ListNodesReturnsOnCall(i int, result1 *v1.NodeList, res
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListPersistentVolumes(arg1 string, arg2 v1a.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+func (fake *FakeCluster) ListPersistentVolumes(arg1 string, arg2 v1c.ListOptions) (*v1.PersistentVolumeClaimList, error) {
 	fake.listPersistentVolumesMutex.Lock()
 	ret, specificReturn := fake.listPersistentVolumesReturnsOnCall[len(fake.listPersistentVolumesArgsForCall)]
 	fake.listPersistentVolumesArgsForCall = append(fake.listPersistentVolumesArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListPersistentVolumes", []interface{}{arg1, arg2})
 	fake.listPersistentVolumesMutex.Unlock()
@@ -1591,13 +3373,13 @@ func (fake *FakeCluster) ListPersistentVolumesCallCount() int {
 	return len(fake.listPersistentVolumesArgsForCall)
 }
 
-func (fake *FakeCluster) ListPersistentVolumesCalls(stub func(string, v1a.ListOptions) (*v1.PersistentVolumeClaimList, error)) {
+func (fake *FakeCluster) ListPersistentVolumesCalls(stub func(string, v1c.ListOptions) (*v1.PersistentVolumeClaimList, error)) {
 	fake.listPersistentVolumesMutex.Lock()
 	defer fake.listPersistentVolumesMutex.Unlock()
 	fake.ListPersistentVolumesStub = stub
 }
 
-func (fake *FakeCluster) ListPersistentVolumesArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListPersistentVolumesArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listPersistentVolumesMutex.RLock()
 	defer fake.listPersistentVolumesMutex.RUnlock()
 	argsForCall := fake.listPersistentVolumesArgsForCall[i]
@@ -1630,12 +3412,12 @@ func (fake *FakeCluster) ListPersistentVolumesReturnsOnCall(i int, result1 *v1.P
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListPods(arg1 string, arg2 v1a.ListOptions) (*v1.PodList, error) {
+func (fake *FakeCluster) ListPods(arg1 string, arg2 v1c.ListOptions) (*v1.PodList, error) {
 	fake.listPodsMutex.Lock()
 	ret, specificReturn := fake.listPodsReturnsOnCall[len(fake.listPodsArgsForCall)]
 	fake.listPodsArgsForCall = append(fake.listPodsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListPods", []interface{}{arg1, arg2})
 	fake.listPodsMutex.Unlock()
@@ -1655,13 +3437,13 @@ func (fake *FakeCluster) ListPodsCallCount() int {
 	return len(fake.listPodsArgsForCall)
 }
 
-func (fake *FakeCluster) ListPodsCalls(stub func(string, v1a.ListOptions) (*v1.PodList, error)) {
+func (fake *FakeCluster) ListPodsCalls(stub func(string, v1c.ListOptions) (*v1.PodList, error)) {
 	fake.listPodsMutex.Lock()
 	defer fake.listPodsMutex.Unlock()
 	fake.ListPodsStub = stub
 }
 
-func (fake *FakeCluster) ListPodsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListPodsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listPodsMutex.RLock()
 	defer fake.listPodsMutex.RUnlock()
 	argsForCall := fake.listPodsArgsForCall[i]
@@ -1694,12 +3476,12 @@ func (fake *FakeCluster) ListPodsReturnsOnCall(i int, result1 *v1.PodList, resul
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListSecrets(arg1 string, arg2 v1a.ListOptions) (*v1.SecretList, error) {
+func (fake *FakeCluster) ListSecrets(arg1 string, arg2 v1c.ListOptions) (*v1.SecretList, error) {
 	fake.listSecretsMutex.Lock()
 	ret, specificReturn := fake.listSecretsReturnsOnCall[len(fake.listSecretsArgsForCall)]
 	fake.listSecretsArgsForCall = append(fake.listSecretsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListSecrets", []interface{}{arg1, arg2})
 	fake.listSecretsMutex.Unlock()
@@ -1719,13 +3501,13 @@ func (fake *FakeCluster) ListSecretsCallCount() int {
 	return len(fake.listSecretsArgsForCall)
 }
 
-func (fake *FakeCluster) ListSecretsCalls(stub func(string, v1a.ListOptions) (*v1.SecretList, error)) {
+func (fake *FakeCluster) ListSecretsCalls(stub func(string, v1c.ListOptions) (*v1.SecretList, error)) {
 	fake.listSecretsMutex.Lock()
 	defer fake.listSecretsMutex.Unlock()
 	fake.ListSecretsStub = stub
 }
 
-func (fake *FakeCluster) ListSecretsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListSecretsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listSecretsMutex.RLock()
 	defer fake.listSecretsMutex.RUnlock()
 	argsForCall := fake.listSecretsArgsForCall[i]
@@ -1758,12 +3540,12 @@ func (fake *FakeCluster) ListSecretsReturnsOnCall(i int, result1 *v1.SecretList,
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListServiceAccounts(arg1 string, arg2 v1a.ListOptions) (*v1.ServiceAccountList, error) {
+func (fake *FakeCluster) ListServiceAccounts(arg1 string, arg2 v1c.ListOptions) (*v1.ServiceAccountList, error) {
 	fake.listServiceAccountsMutex.Lock()
 	ret, specificReturn := fake.listServiceAccountsReturnsOnCall[len(fake.listServiceAccountsArgsForCall)]
 	fake.listServiceAccountsArgsForCall = append(fake.listServiceAccountsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListServiceAccounts", []interface{}{arg1, arg2})
 	fake.listServiceAccountsMutex.Unlock()
@@ -1783,13 +3565,13 @@ func (fake *FakeCluster) ListServiceAccountsCallCount() int {
 	return len(fake.listServiceAccountsArgsForCall)
 }
 
-func (fake *FakeCluster) ListServiceAccountsCalls(stub func(string, v1a.ListOptions) (*v1.ServiceAccountList, error)) {
+func (fake *FakeCluster) ListServiceAccountsCalls(stub func(string, v1c.ListOptions) (*v1.ServiceAccountList, error)) {
 	fake.listServiceAccountsMutex.Lock()
 	defer fake.listServiceAccountsMutex.Unlock()
 	fake.ListServiceAccountsStub = stub
 }
 
-func (fake *FakeCluster) ListServiceAccountsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListServiceAccountsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listServiceAccountsMutex.RLock()
 	defer fake.listServiceAccountsMutex.RUnlock()
 	argsForCall := fake.listServiceAccountsArgsForCall[i]
@@ -1822,12 +3604,12 @@ func (fake *FakeCluster) ListServiceAccountsReturnsOnCall(i int, result1 *v1.Ser
 	}{result1, result2}
 }
 
-func (fake *FakeCluster) ListServices(arg1 string, arg2 v1a.ListOptions) (*v1.ServiceList, error) {
+func (fake *FakeCluster) ListServices(arg1 string, arg2 v1c.ListOptions) (*v1.ServiceList, error) {
 	fake.listServicesMutex.Lock()
 	ret, specificReturn := fake.listServicesReturnsOnCall[len(fake.listServicesArgsForCall)]
 	fake.listServicesArgsForCall = append(fake.listServicesArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListServices", []interface{}{arg1, arg2})
 	fake.listServicesMutex.Unlock()
@@ -1847,13 +3629,13 @@ func (fake *FakeCluster) ListServicesCallCount() int {
 	return len(fake.listServicesArgsForCall)
 }
 
-func (fake *FakeCluster) ListServicesCalls(stub func(string, v1a.ListOptions) (*v1.ServiceList, error)) {
+func (fake *FakeCluster) ListServicesCalls(stub func(string, v1c.ListOptions) (*v1.ServiceList, error)) {
 	fake.listServicesMutex.Lock()
 	defer fake.listServicesMutex.Unlock()
 	fake.ListServicesStub = stub
 }
 
-func (fake *FakeCluster) ListServicesArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeCluster) ListServicesArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listServicesMutex.RLock()
 	defer fake.listServicesMutex.RUnlock()
 	argsForCall := fake.listServicesArgsForCall[i]
@@ -2085,6 +3867,325 @@ func (fake *FakeCluster) SecretExistsReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakeCluster) UpdateConfigMap(arg1 string, arg2 *v1.ConfigMap) (*v1.ConfigMap, error) {
+	fake.updateConfigMapMutex.Lock()
+	ret, specificReturn := fake.updateConfigMapReturnsOnCall[len(fake.updateConfigMapArgsForCall)]
+	fake.updateConfigMapArgsForCall = append(fake.updateConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateConfigMap", []interface{}{arg1, arg2})
+	fake.updateConfigMapMutex.Unlock()
+	if fake.UpdateConfigMapStub != nil {
+		return fake.UpdateConfigMapStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) UpdateConfigMapCallCount() int {
+	fake.updateConfigMapMutex.RLock()
+	defer fake.updateConfigMapMutex.RUnlock()
+	return len(fake.updateConfigMapArgsForCall)
+}
+
+func (fake *FakeCluster) UpdateConfigMapCalls(stub func(string, *v1.ConfigMap) (*v1.ConfigMap, error)) {
+	fake.updateConfigMapMutex.Lock()
+	defer fake.updateConfigMapMutex.Unlock()
+	fake.UpdateConfigMapStub = stub
+}
+
+func (fake *FakeCluster) UpdateConfigMapArgsForCall(i int) (string, *v1.ConfigMap) {
+	fake.updateConfigMapMutex.RLock()
+	defer fake.updateConfigMapMutex.RUnlock()
+	argsForCall := fake.updateConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) UpdateConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.updateConfigMapMutex.Lock()
+	defer fake.updateConfigMapMutex.Unlock()
+	fake.UpdateConfigMapStub = nil
+	fake.updateConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.updateConfigMapMutex.Lock()
+	defer fake.updateConfigMapMutex.Unlock()
+	fake.UpdateConfigMapStub = nil
+	if fake.updateConfigMapReturnsOnCall == nil {
+		fake.updateConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.updateConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateLimitRange(arg1 string, arg2 *v1.LimitRange) (*v1.LimitRange, error) {
+	fake.updateLimitRangeMutex.Lock()
+	ret, specificReturn := fake.updateLimitRangeReturnsOnCall[len(fake.updateLimitRangeArgsForCall)]
+	fake.updateLimitRangeArgsForCall = append(fake.updateLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateLimitRange", []interface{}{arg1, arg2})
+	fake.updateLimitRangeMutex.Unlock()
+	if fake.UpdateLimitRangeStub != nil {
+		return fake.UpdateLimitRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateLimitRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) UpdateLimitRangeCallCount() int {
+	fake.updateLimitRangeMutex.RLock()
+	defer fake.updateLimitRangeMutex.RUnlock()
+	return len(fake.updateLimitRangeArgsForCall)
+}
+
+func (fake *FakeCluster) UpdateLimitRangeCalls(stub func(string, *v1.LimitRange) (*v1.LimitRange, error)) {
+	fake.updateLimitRangeMutex.Lock()
+	defer fake.updateLimitRangeMutex.Unlock()
+	fake.UpdateLimitRangeStub = stub
+}
+
+func (fake *FakeCluster) UpdateLimitRangeArgsForCall(i int) (string, *v1.LimitRange) {
+	fake.updateLimitRangeMutex.RLock()
+	defer fake.updateLimitRangeMutex.RUnlock()
+	argsForCall := fake.updateLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) UpdateLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.updateLimitRangeMutex.Lock()
+	defer fake.updateLimitRangeMutex.Unlock()
+	fake.UpdateLimitRangeStub = nil
+	fake.updateLimitRangeReturns = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.updateLimitRangeMutex.Lock()
+	defer fake.updateLimitRangeMutex.Unlock()
+	fake.UpdateLimitRangeStub = nil
+	if fake.updateLimitRangeReturnsOnCall == nil {
+		fake.updateLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
+			result2 error
+		})
+	}
+	fake.updateLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateNamespace(arg1 *v1.Namespace) (*v1.Namespace, error) {
+	fake.updateNamespaceMutex.Lock()
+	ret, specificReturn := fake.updateNamespaceReturnsOnCall[len(fake.updateNamespaceArgsForCall)]
+	fake.updateNamespaceArgsForCall = append(fake.updateNamespaceArgsForCall, struct {
+		arg1 *v1.Namespace
+	}{arg1})
+	fake.recordInvocation("UpdateNamespace", []interface{}{arg1})
+	fake.updateNamespaceMutex.Unlock()
+	if fake.UpdateNamespaceStub != nil {
+		return fake.UpdateNamespaceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateNamespaceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) UpdateNamespaceCallCount() int {
+	fake.updateNamespaceMutex.RLock()
+	defer fake.updateNamespaceMutex.RUnlock()
+	return len(fake.updateNamespaceArgsForCall)
+}
+
+func (fake *FakeCluster) UpdateNamespaceCalls(stub func(*v1.Namespace) (*v1.Namespace, error)) {
+	fake.updateNamespaceMutex.Lock()
+	defer fake.updateNamespaceMutex.Unlock()
+	fake.UpdateNamespaceStub = stub
+}
+
+func (fake *FakeCluster) UpdateNamespaceArgsForCall(i int) *v1.Namespace {
+	fake.updateNamespaceMutex.RLock()
+	defer fake.updateNamespaceMutex.RUnlock()
+	argsForCall := fake.updateNamespaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCluster) UpdateNamespaceReturns(result1 *v1.Namespace, result2 error) {
+	fake.updateNamespaceMutex.Lock()
+	defer fake.updateNamespaceMutex.Unlock()
+	fake.UpdateNamespaceStub = nil
+	fake.updateNamespaceReturns = struct {
+		result1 *v1.Namespace
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateNamespaceReturnsOnCall(i int, result1 *v1.Namespace, result2 error) {
+	fake.updateNamespaceMutex.Lock()
+	defer fake.updateNamespaceMutex.Unlock()
+	fake.UpdateNamespaceStub = nil
+	if fake.updateNamespaceReturnsOnCall == nil {
+		fake.updateNamespaceReturnsOnCall = make(map[int]struct {
+			result1 *v1.Namespace
+			result2 error
+		})
+	}
+	fake.updateNamespaceReturnsOnCall[i] = struct {
+		result1 *v1.Namespace
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateNetworkPolicy(arg1 string, arg2 *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error) {
+	fake.updateNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.updateNetworkPolicyReturnsOnCall[len(fake.updateNetworkPolicyArgsForCall)]
+	fake.updateNetworkPolicyArgsForCall = append(fake.updateNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateNetworkPolicy", []interface{}{arg1, arg2})
+	fake.updateNetworkPolicyMutex.Unlock()
+	if fake.UpdateNetworkPolicyStub != nil {
+		return fake.UpdateNetworkPolicyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateNetworkPolicyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) UpdateNetworkPolicyCallCount() int {
+	fake.updateNetworkPolicyMutex.RLock()
+	defer fake.updateNetworkPolicyMutex.RUnlock()
+	return len(fake.updateNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeCluster) UpdateNetworkPolicyCalls(stub func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)) {
+	fake.updateNetworkPolicyMutex.Lock()
+	defer fake.updateNetworkPolicyMutex.Unlock()
+	fake.UpdateNetworkPolicyStub = stub
+}
+
+func (fake *FakeCluster) UpdateNetworkPolicyArgsForCall(i int) (string, *v1b.NetworkPolicy) {
+	fake.updateNetworkPolicyMutex.RLock()
+	defer fake.updateNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.updateNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) UpdateNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.updateNetworkPolicyMutex.Lock()
+	defer fake.updateNetworkPolicyMutex.Unlock()
+	fake.UpdateNetworkPolicyStub = nil
+	fake.updateNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.updateNetworkPolicyMutex.Lock()
+	defer fake.updateNetworkPolicyMutex.Unlock()
+	fake.UpdateNetworkPolicyStub = nil
+	if fake.updateNetworkPolicyReturnsOnCall == nil {
+		fake.updateNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.updateNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateResourceQuota(arg1 string, arg2 *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	fake.updateResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.updateResourceQuotaReturnsOnCall[len(fake.updateResourceQuotaArgsForCall)]
+	fake.updateResourceQuotaArgsForCall = append(fake.updateResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateResourceQuota", []interface{}{arg1, arg2})
+	fake.updateResourceQuotaMutex.Unlock()
+	if fake.UpdateResourceQuotaStub != nil {
+		return fake.UpdateResourceQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCluster) UpdateResourceQuotaCallCount() int {
+	fake.updateResourceQuotaMutex.RLock()
+	defer fake.updateResourceQuotaMutex.RUnlock()
+	return len(fake.updateResourceQuotaArgsForCall)
+}
+
+func (fake *FakeCluster) UpdateResourceQuotaCalls(stub func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)) {
+	fake.updateResourceQuotaMutex.Lock()
+	defer fake.updateResourceQuotaMutex.Unlock()
+	fake.UpdateResourceQuotaStub = stub
+}
+
+func (fake *FakeCluster) UpdateResourceQuotaArgsForCall(i int) (string, *v1.ResourceQuota) {
+	fake.updateResourceQuotaMutex.RLock()
+	defer fake.updateResourceQuotaMutex.RUnlock()
+	argsForCall := fake.updateResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCluster) UpdateResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.updateResourceQuotaMutex.Lock()
+	defer fake.updateResourceQuotaMutex.Unlock()
+	fake.UpdateResourceQuotaStub = nil
+	fake.updateResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCluster) UpdateResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.updateResourceQuotaMutex.Lock()
+	defer fake.updateResourceQuotaMutex.Unlock()
+	fake.UpdateResourceQuotaStub = nil
+	if fake.updateResourceQuotaReturnsOnCall == nil {
+		fake.updateResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.updateResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCluster) UpdateSecret(arg1 string, arg2 *v1.Secret) (*v1.Secret, error) {
 	fake.updateSecretMutex.Lock()
 	ret, specificReturn := fake.updateSecretReturnsOnCall[len(fake.updateSecretArgsForCall)]
@@ -2154,36 +4255,80 @@ func (fake *FakeCluster) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.createClusterRoleBindingMutex.RLock()
 	defer fake.createClusterRoleBindingMutex.RUnlock()
+	fake.createConfigMapMutex.RLock()
+	defer fake.createConfigMapMutex.RUnlock()
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	fake.createLimitRangeMutex.RLock()
+	defer fake.createLimitRangeMutex.RUnlock()
 	fake.createNamespaceMutex.RLock()
 	defer fake.createNamespaceMutex.RUnlock()
 	fake.createNamespaceIfNotExistsMutex.RLock()
 	defer fake.createNamespaceIfNotExistsMutex.RUnlock()
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	fake.createOrUpdateConfigMapMutex.RLock()
+	defer fake.createOrUpdateConfigMapMutex.RUnlock()
+	fake.createOrUpdateLimitRangeMutex.RLock()
+	defer fake.createOrUpdateLimitRangeMutex.RUnlock()
+	fake.createOrUpdateNetworkPolicyMutex.RLock()
+	defer fake.createOrUpdateNetworkPolicyMutex.RUnlock()
+	fake.createOrUpdateResourceQuotaMutex.RLock()
+	defer fake.createOrUpdateResourceQuotaMutex.RUnlock()
 	fake.createOrUpdateSecretMutex.RLock()
 	defer fake.createOrUpdateSecretMutex.RUnlock()
+	fake.createResourceQuotaMutex.RLock()
+	defer fake.createResourceQuotaMutex.RUnlock()
 	fake.createSecretMutex.RLock()
 	defer fake.createSecretMutex.RUnlock()
 	fake.createServiceAccountMutex.RLock()
 	defer fake.createServiceAccountMutex.RUnlock()
+	fake.deleteConfigMapMutex.RLock()
+	defer fake.deleteConfigMapMutex.RUnlock()
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	fake.deleteLimitRangeMutex.RLock()
+	defer fake.deleteLimitRangeMutex.RUnlock()
 	fake.deleteNamespaceMutex.RLock()
 	defer fake.deleteNamespaceMutex.RUnlock()
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	fake.deleteResourceQuotaMutex.RLock()
+	defer fake.deleteResourceQuotaMutex.RUnlock()
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
 	fake.getClientMutex.RLock()
 	defer fake.getClientMutex.RUnlock()
 	fake.getClientConfigMutex.RLock()
 	defer fake.getClientConfigMutex.RUnlock()
+	fake.getConfigMapMutex.RLock()
+	defer fake.getConfigMapMutex.RUnlock()
 	fake.getDeploymentMutex.RLock()
 	defer fake.getDeploymentMutex.RUnlock()
 	fake.getIngressesMutex.RLock()
 	defer fake.getIngressesMutex.RUnlock()
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	fake.getLimitRangeMutex.RLock()
+	defer fake.getLimitRangeMutex.RUnlock()
 	fake.getNamespaceMutex.RLock()
 	defer fake.getNamespaceMutex.RUnlock()
 	fake.getNamespacesMutex.RLock()
 	defer fake.getNamespacesMutex.RUnlock()
+	fake.getNetworkPolicyMutex.RLock()
+	defer fake.getNetworkPolicyMutex.RUnlock()
+	fake.getNodeAddressesMutex.RLock()
+	defer fake.getNodeAddressesMutex.RUnlock()
+	fake.getResourceQuotaMutex.RLock()
+	defer fake.getResourceQuotaMutex.RUnlock()
 	fake.getSecretMutex.RLock()
 	defer fake.getSecretMutex.RUnlock()
 	fake.getSecretsAndServicesMutex.RLock()
 	defer fake.getSecretsAndServicesMutex.RUnlock()
 	fake.listClusterRoleBindingsMutex.RLock()
 	defer fake.listClusterRoleBindingsMutex.RUnlock()
+	fake.listConfigMapsMutex.RLock()
+	defer fake.listConfigMapsMutex.RUnlock()
 	fake.listDeploymentsMutex.RLock()
 	defer fake.listDeploymentsMutex.RUnlock()
 	fake.listIngressesMutex.RLock()
@@ -2206,6 +4351,16 @@ func (fake *FakeCluster) Invocations() map[string][][]interface{} {
 	defer fake.patchMutex.RUnlock()
 	fake.secretExistsMutex.RLock()
 	defer fake.secretExistsMutex.RUnlock()
+	fake.updateConfigMapMutex.RLock()
+	defer fake.updateConfigMapMutex.RUnlock()
+	fake.updateLimitRangeMutex.RLock()
+	defer fake.updateLimitRangeMutex.RUnlock()
+	fake.updateNamespaceMutex.RLock()
+	defer fake.updateNamespaceMutex.RUnlock()
+	fake.updateNetworkPolicyMutex.RLock()
+	defer fake.updateNetworkPolicyMutex.RUnlock()
+	fake.updateResourceQuotaMutex.RLock()
+	defer fake.updateResourceQuotaMutex.RUnlock()
 	fake.updateSecretMutex.RLock()
 	defer fake.updateSecretMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -5,10 +5,12 @@ import (
 	"sync"
 
 	"github.com/cf-platform-eng/kibosh/pkg/k8s"
+	v1a "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	v1beta1a "k8s.io/api/extensions/v1beta1"
+	v1b "k8s.io/api/networking/v1"
 	"k8s.io/api/rbac/v1beta1"
-	v1a "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1c "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -28,6 +30,48 @@ type FakeClusterDelegate struct {
 		result1 *v1beta1.ClusterRoleBinding
 		result2 error
 	}
+	CreateConfigMapStub        func(string, *v1.ConfigMap) (*v1.ConfigMap, error)
+	createConfigMapMutex       sync.RWMutex
+	createConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}
+	createConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	createConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	CreateJobStub        func(string, *v1a.Job) (*v1a.Job, error)
+	createJobMutex       sync.RWMutex
+	createJobArgsForCall []struct {
+		arg1 string
+		arg2 *v1a.Job
+	}
+	createJobReturns struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	createJobReturnsOnCall map[int]struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	CreateLimitRangeStub        func(string, *v1.LimitRange) (*v1.LimitRange, error)
+	createLimitRangeMutex       sync.RWMutex
+	createLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}
+	createLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	createLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
 	CreateNamespaceStub        func(*v1.Namespace) (*v1.Namespace, error)
 	createNamespaceMutex       sync.RWMutex
 	createNamespaceArgsForCall []struct {
@@ -41,6 +85,34 @@ type FakeClusterDelegate struct {
 		result1 *v1.Namespace
 		result2 error
 	}
+	CreateNetworkPolicyStub        func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)
+	createNetworkPolicyMutex       sync.RWMutex
+	createNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}
+	createNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	createNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	CreateResourceQuotaStub        func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)
+	createResourceQuotaMutex       sync.RWMutex
+	createResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}
+	createResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	createResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
 	CreateSecretStub        func(string, *v1.Secret) (*v1.Secret, error)
 	createSecretMutex       sync.RWMutex
 	createSecretArgsForCall []struct {
@@ -69,11 +141,50 @@ type FakeClusterDelegate struct {
 		result1 *v1.ServiceAccount
 		result2 error
 	}
-	DeleteNamespaceStub        func(string, *v1a.DeleteOptions) error
+	DeleteConfigMapStub        func(string, string, *v1c.DeleteOptions) error
+	deleteConfigMapMutex       sync.RWMutex
+	deleteConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteConfigMapReturns struct {
+		result1 error
+	}
+	deleteConfigMapReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteJobStub        func(string, string, *v1c.DeleteOptions) error
+	deleteJobMutex       sync.RWMutex
+	deleteJobArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteJobReturns struct {
+		result1 error
+	}
+	deleteJobReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteLimitRangeStub        func(string, string, *v1c.DeleteOptions) error
+	deleteLimitRangeMutex       sync.RWMutex
+	deleteLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteLimitRangeReturns struct {
+		result1 error
+	}
+	deleteLimitRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteNamespaceStub        func(string, *v1c.DeleteOptions) error
 	deleteNamespaceMutex       sync.RWMutex
 	deleteNamespaceArgsForCall []struct {
 		arg1 string
-		arg2 *v1a.DeleteOptions
+		arg2 *v1c.DeleteOptions
 	}
 	deleteNamespaceReturns struct {
 		result1 error
@@ -81,6 +192,45 @@ type FakeClusterDelegate struct {
 	deleteNamespaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteNetworkPolicyStub        func(string, string, *v1c.DeleteOptions) error
+	deleteNetworkPolicyMutex       sync.RWMutex
+	deleteNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteNetworkPolicyReturns struct {
+		result1 error
+	}
+	deleteNetworkPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteResourceQuotaStub        func(string, string, *v1c.DeleteOptions) error
+	deleteResourceQuotaMutex       sync.RWMutex
+	deleteResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteResourceQuotaReturns struct {
+		result1 error
+	}
+	deleteResourceQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteSecretStub        func(string, string, *v1c.DeleteOptions) error
+	deleteSecretMutex       sync.RWMutex
+	deleteSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}
+	deleteSecretReturns struct {
+		result1 error
+	}
+	deleteSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetClientStub        func() kubernetes.Interface
 	getClientMutex       sync.RWMutex
 	getClientArgsForCall []struct {
@@ -101,12 +251,27 @@ type FakeClusterDelegate struct {
 	getClientConfigReturnsOnCall map[int]struct {
 		result1 *rest.Config
 	}
-	GetDeploymentStub        func(string, string, v1a.GetOptions) (*v1beta1a.Deployment, error)
+	GetConfigMapStub        func(string, string, v1c.GetOptions) (*v1.ConfigMap, error)
+	getConfigMapMutex       sync.RWMutex
+	getConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	getConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	GetDeploymentStub        func(string, string, v1c.GetOptions) (*v1beta1a.Deployment, error)
 	getDeploymentMutex       sync.RWMutex
 	getDeploymentArgsForCall []struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}
 	getDeploymentReturns struct {
 		result1 *v1beta1a.Deployment
@@ -116,11 +281,41 @@ type FakeClusterDelegate struct {
 		result1 *v1beta1a.Deployment
 		result2 error
 	}
-	GetNamespaceStub        func(string, *v1a.GetOptions) (*v1.Namespace, error)
+	GetJobStub        func(string, string, v1c.GetOptions) (*v1a.Job, error)
+	getJobMutex       sync.RWMutex
+	getJobArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getJobReturns struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	getJobReturnsOnCall map[int]struct {
+		result1 *v1a.Job
+		result2 error
+	}
+	GetLimitRangeStub        func(string, string, v1c.GetOptions) (*v1.LimitRange, error)
+	getLimitRangeMutex       sync.RWMutex
+	getLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	getLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	GetNamespaceStub        func(string, *v1c.GetOptions) (*v1.Namespace, error)
 	getNamespaceMutex       sync.RWMutex
 	getNamespaceArgsForCall []struct {
 		arg1 string
-		arg2 *v1a.GetOptions
+		arg2 *v1c.GetOptions
 	}
 	getNamespaceReturns struct {
 		result1 *v1.Namespace
@@ -142,12 +337,42 @@ type FakeClusterDelegate struct {
 		result1 *v1.NamespaceList
 		result2 error
 	}
-	GetSecretStub        func(string, string, v1a.GetOptions) (*v1.Secret, error)
+	GetNetworkPolicyStub        func(string, string, v1c.GetOptions) (*v1b.NetworkPolicy, error)
+	getNetworkPolicyMutex       sync.RWMutex
+	getNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	getNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	GetResourceQuotaStub        func(string, string, v1c.GetOptions) (*v1.ResourceQuota, error)
+	getResourceQuotaMutex       sync.RWMutex
+	getResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}
+	getResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	getResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	GetSecretStub        func(string, string, v1c.GetOptions) (*v1.Secret, error)
 	getSecretMutex       sync.RWMutex
 	getSecretArgsForCall []struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}
 	getSecretReturns struct {
 		result1 *v1.Secret
@@ -157,10 +382,10 @@ type FakeClusterDelegate struct {
 		result1 *v1.Secret
 		result2 error
 	}
-	ListClusterRoleBindingsStub        func(v1a.ListOptions) (*v1beta1.ClusterRoleBindingList, error)
+	ListClusterRoleBindingsStub        func(v1c.ListOptions) (*v1beta1.ClusterRoleBindingList, error)
 	listClusterRoleBindingsMutex       sync.RWMutex
 	listClusterRoleBindingsArgsForCall []struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}
 	listClusterRoleBindingsReturns struct {
 		result1 *v1beta1.ClusterRoleBindingList
@@ -170,11 +395,25 @@ type FakeClusterDelegate struct {
 		result1 *v1beta1.ClusterRoleBindingList
 		result2 error
 	}
-	ListDeploymentsStub        func(string, v1a.ListOptions) (*k8s.DeploymentList, error)
+	ListConfigMapsStub        func(string, v1c.ListOptions) (*v1.ConfigMapList, error)
+	listConfigMapsMutex       sync.RWMutex
+	listConfigMapsArgsForCall []struct {
+		arg1 string
+		arg2 v1c.ListOptions
+	}
+	listConfigMapsReturns struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}
+	listConfigMapsReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}
+	ListDeploymentsStub        func(string, v1c.ListOptions) (*k8s.DeploymentList, error)
 	listDeploymentsMutex       sync.RWMutex
 	listDeploymentsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listDeploymentsReturns struct {
 		result1 *k8s.DeploymentList
@@ -184,11 +423,11 @@ type FakeClusterDelegate struct {
 		result1 *k8s.DeploymentList
 		result2 error
 	}
-	ListIngressesStub        func(string, v1a.ListOptions) (*v1beta1a.IngressList, error)
+	ListIngressesStub        func(string, v1c.ListOptions) (*v1beta1a.IngressList, error)
 	listIngressesMutex       sync.RWMutex
 	listIngressesArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listIngressesReturns struct {
 		result1 *v1beta1a.IngressList
@@ -198,10 +437,10 @@ type FakeClusterDelegate struct {
 		result1 *v1beta1a.IngressList
 		result2 error
 	}
-	ListNodesStub        func(v1a.ListOptions) (*v1.NodeList, error)
+	ListNodesStub        func(v1c.ListOptions) (*v1.NodeList, error)
 	listNodesMutex       sync.RWMutex
 	listNodesArgsForCall []struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}
 	listNodesReturns struct {
 		result1 *v1.NodeList
@@ -211,11 +450,11 @@ type FakeClusterDelegate struct {
 		result1 *v1.NodeList
 		result2 error
 	}
-	ListPersistentVolumesStub        func(string, v1a.ListOptions) (*v1.PersistentVolumeClaimList, error)
+	ListPersistentVolumesStub        func(string, v1c.ListOptions) (*v1.PersistentVolumeClaimList, error)
 	listPersistentVolumesMutex       sync.RWMutex
 	listPersistentVolumesArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listPersistentVolumesReturns struct {
 		result1 *v1.PersistentVolumeClaimList
@@ -225,11 +464,11 @@ type FakeClusterDelegate struct {
 		result1 *v1.PersistentVolumeClaimList
 		result2 error
 	}
-	ListPodsStub        func(string, v1a.ListOptions) (*v1.PodList, error)
+	ListPodsStub        func(string, v1c.ListOptions) (*v1.PodList, error)
 	listPodsMutex       sync.RWMutex
 	listPodsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listPodsReturns struct {
 		result1 *v1.PodList
@@ -239,11 +478,11 @@ type FakeClusterDelegate struct {
 		result1 *v1.PodList
 		result2 error
 	}
-	ListSecretsStub        func(string, v1a.ListOptions) (*v1.SecretList, error)
+	ListSecretsStub        func(string, v1c.ListOptions) (*v1.SecretList, error)
 	listSecretsMutex       sync.RWMutex
 	listSecretsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listSecretsReturns struct {
 		result1 *v1.SecretList
@@ -253,11 +492,11 @@ type FakeClusterDelegate struct {
 		result1 *v1.SecretList
 		result2 error
 	}
-	ListServiceAccountsStub        func(string, v1a.ListOptions) (*v1.ServiceAccountList, error)
+	ListServiceAccountsStub        func(string, v1c.ListOptions) (*v1.ServiceAccountList, error)
 	listServiceAccountsMutex       sync.RWMutex
 	listServiceAccountsArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listServiceAccountsReturns struct {
 		result1 *v1.ServiceAccountList
@@ -267,11 +506,11 @@ type FakeClusterDelegate struct {
 		result1 *v1.ServiceAccountList
 		result2 error
 	}
-	ListServicesStub        func(string, v1a.ListOptions) (*v1.ServiceList, error)
+	ListServicesStub        func(string, v1c.ListOptions) (*v1.ServiceList, error)
 	listServicesMutex       sync.RWMutex
 	listServicesArgsForCall []struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}
 	listServicesReturns struct {
 		result1 *v1.ServiceList
@@ -298,6 +537,75 @@ type FakeClusterDelegate struct {
 		result1 *v1.ServiceAccount
 		result2 error
 	}
+	UpdateConfigMapStub        func(string, *v1.ConfigMap) (*v1.ConfigMap, error)
+	updateConfigMapMutex       sync.RWMutex
+	updateConfigMapArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}
+	updateConfigMapReturns struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	updateConfigMapReturnsOnCall map[int]struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}
+	UpdateLimitRangeStub        func(string, *v1.LimitRange) (*v1.LimitRange, error)
+	updateLimitRangeMutex       sync.RWMutex
+	updateLimitRangeArgsForCall []struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}
+	updateLimitRangeReturns struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	updateLimitRangeReturnsOnCall map[int]struct {
+		result1 *v1.LimitRange
+		result2 error
+	}
+	UpdateNamespaceStub        func(*v1.Namespace) (*v1.Namespace, error)
+	updateNamespaceMutex       sync.RWMutex
+	updateNamespaceArgsForCall []struct {
+		arg1 *v1.Namespace
+	}
+	updateNamespaceReturns struct {
+		result1 *v1.Namespace
+		result2 error
+	}
+	updateNamespaceReturnsOnCall map[int]struct {
+		result1 *v1.Namespace
+		result2 error
+	}
+	UpdateNetworkPolicyStub        func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)
+	updateNetworkPolicyMutex       sync.RWMutex
+	updateNetworkPolicyArgsForCall []struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}
+	updateNetworkPolicyReturns struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	updateNetworkPolicyReturnsOnCall map[int]struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}
+	UpdateResourceQuotaStub        func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)
+	updateResourceQuotaMutex       sync.RWMutex
+	updateResourceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}
+	updateResourceQuotaReturns struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
+	updateResourceQuotaReturnsOnCall map[int]struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}
 	UpdateSecretStub        func(string, *v1.Secret) (*v1.Secret, error)
 	updateSecretMutex       sync.RWMutex
 	updateSecretArgsForCall []struct {
@@ -379,6 +687,198 @@ func (fake *FakeClusterDelegate) CreateClusterRoleBindingReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *FakeClusterDelegate) CreateConfigMap(arg1 string, arg2 *v1.ConfigMap) (*v1.ConfigMap, error) {
+	fake.createConfigMapMutex.Lock()
+	ret, specificReturn := fake.createConfigMapReturnsOnCall[len(fake.createConfigMapArgsForCall)]
+	fake.createConfigMapArgsForCall = append(fake.createConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}{arg1, arg2})
+	fake.recordInvocation("CreateConfigMap", []interface{}{arg1, arg2})
+	fake.createConfigMapMutex.Unlock()
+	if fake.CreateConfigMapStub != nil {
+		return fake.CreateConfigMapStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) CreateConfigMapCallCount() int {
+	fake.createConfigMapMutex.RLock()
+	defer fake.createConfigMapMutex.RUnlock()
+	return len(fake.createConfigMapArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) CreateConfigMapCalls(stub func(string, *v1.ConfigMap) (*v1.ConfigMap, error)) {
+	fake.createConfigMapMutex.Lock()
+	defer fake.createConfigMapMutex.Unlock()
+	fake.CreateConfigMapStub = stub
+}
+
+func (fake *FakeClusterDelegate) CreateConfigMapArgsForCall(i int) (string, *v1.ConfigMap) {
+	fake.createConfigMapMutex.RLock()
+	defer fake.createConfigMapMutex.RUnlock()
+	argsForCall := fake.createConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) CreateConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.createConfigMapMutex.Lock()
+	defer fake.createConfigMapMutex.Unlock()
+	fake.CreateConfigMapStub = nil
+	fake.createConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.createConfigMapMutex.Lock()
+	defer fake.createConfigMapMutex.Unlock()
+	fake.CreateConfigMapStub = nil
+	if fake.createConfigMapReturnsOnCall == nil {
+		fake.createConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.createConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateJob(arg1 string, arg2 *v1a.Job) (*v1a.Job, error) {
+	fake.createJobMutex.Lock()
+	ret, specificReturn := fake.createJobReturnsOnCall[len(fake.createJobArgsForCall)]
+	fake.createJobArgsForCall = append(fake.createJobArgsForCall, struct {
+		arg1 string
+		arg2 *v1a.Job
+	}{arg1, arg2})
+	fake.recordInvocation("CreateJob", []interface{}{arg1, arg2})
+	fake.createJobMutex.Unlock()
+	if fake.CreateJobStub != nil {
+		return fake.CreateJobStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createJobReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) CreateJobCallCount() int {
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	return len(fake.createJobArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) CreateJobCalls(stub func(string, *v1a.Job) (*v1a.Job, error)) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = stub
+}
+
+func (fake *FakeClusterDelegate) CreateJobArgsForCall(i int) (string, *v1a.Job) {
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	argsForCall := fake.createJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) CreateJobReturns(result1 *v1a.Job, result2 error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = nil
+	fake.createJobReturns = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateJobReturnsOnCall(i int, result1 *v1a.Job, result2 error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = nil
+	if fake.createJobReturnsOnCall == nil {
+		fake.createJobReturnsOnCall = make(map[int]struct {
+			result1 *v1a.Job
+			result2 error
+		})
+	}
+	fake.createJobReturnsOnCall[i] = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateLimitRange(arg1 string, arg2 *v1.LimitRange) (*v1.LimitRange, error) {
+	fake.createLimitRangeMutex.Lock()
+	ret, specificReturn := fake.createLimitRangeReturnsOnCall[len(fake.createLimitRangeArgsForCall)]
+	fake.createLimitRangeArgsForCall = append(fake.createLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}{arg1, arg2})
+	fake.recordInvocation("CreateLimitRange", []interface{}{arg1, arg2})
+	fake.createLimitRangeMutex.Unlock()
+	if fake.CreateLimitRangeStub != nil {
+		return fake.CreateLimitRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createLimitRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) CreateLimitRangeCallCount() int {
+	fake.createLimitRangeMutex.RLock()
+	defer fake.createLimitRangeMutex.RUnlock()
+	return len(fake.createLimitRangeArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) CreateLimitRangeCalls(stub func(string, *v1.LimitRange) (*v1.LimitRange, error)) {
+	fake.createLimitRangeMutex.Lock()
+	defer fake.createLimitRangeMutex.Unlock()
+	fake.CreateLimitRangeStub = stub
+}
+
+func (fake *FakeClusterDelegate) CreateLimitRangeArgsForCall(i int) (string, *v1.LimitRange) {
+	fake.createLimitRangeMutex.RLock()
+	defer fake.createLimitRangeMutex.RUnlock()
+	argsForCall := fake.createLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) CreateLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.createLimitRangeMutex.Lock()
+	defer fake.createLimitRangeMutex.Unlock()
+	fake.CreateLimitRangeStub = nil
+	fake.createLimitRangeReturns = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.createLimitRangeMutex.Lock()
+	defer fake.createLimitRangeMutex.Unlock()
+	fake.CreateLimitRangeStub = nil
+	if fake.createLimitRangeReturnsOnCall == nil {
+		fake.createLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
+			result2 error
+		})
+	}
+	fake.createLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClusterDelegate) CreateNamespace(arg1 *v1.Namespace) (*v1.Namespace, error) {
 	fake.createNamespaceMutex.Lock()
 	ret, specificReturn := fake.createNamespaceReturnsOnCall[len(fake.createNamespaceArgsForCall)]
@@ -442,56 +942,184 @@ func (fake *FakeClusterDelegate) CreateNamespaceReturnsOnCall(i int, result1 *v1
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) CreateSecret(arg1 string, arg2 *v1.Secret) (*v1.Secret, error) {
-	fake.createSecretMutex.Lock()
-	ret, specificReturn := fake.createSecretReturnsOnCall[len(fake.createSecretArgsForCall)]
-	fake.createSecretArgsForCall = append(fake.createSecretArgsForCall, struct {
+func (fake *FakeClusterDelegate) CreateNetworkPolicy(arg1 string, arg2 *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error) {
+	fake.createNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.createNetworkPolicyReturnsOnCall[len(fake.createNetworkPolicyArgsForCall)]
+	fake.createNetworkPolicyArgsForCall = append(fake.createNetworkPolicyArgsForCall, struct {
 		arg1 string
-		arg2 *v1.Secret
+		arg2 *v1b.NetworkPolicy
 	}{arg1, arg2})
-	fake.recordInvocation("CreateSecret", []interface{}{arg1, arg2})
-	fake.createSecretMutex.Unlock()
-	if fake.CreateSecretStub != nil {
-		return fake.CreateSecretStub(arg1, arg2)
+	fake.recordInvocation("CreateNetworkPolicy", []interface{}{arg1, arg2})
+	fake.createNetworkPolicyMutex.Unlock()
+	if fake.CreateNetworkPolicyStub != nil {
+		return fake.CreateNetworkPolicyStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.createSecretReturns
+	fakeReturns := fake.createNetworkPolicyReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeClusterDelegate) CreateSecretCallCount() int {
-	fake.createSecretMutex.RLock()
-	defer fake.createSecretMutex.RUnlock()
-	return len(fake.createSecretArgsForCall)
+func (fake *FakeClusterDelegate) CreateNetworkPolicyCallCount() int {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	return len(fake.createNetworkPolicyArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) CreateSecretCalls(stub func(string, *v1.Secret) (*v1.Secret, error)) {
-	fake.createSecretMutex.Lock()
-	defer fake.createSecretMutex.Unlock()
-	fake.CreateSecretStub = stub
+func (fake *FakeClusterDelegate) CreateNetworkPolicyCalls(stub func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = stub
 }
 
-func (fake *FakeClusterDelegate) CreateSecretArgsForCall(i int) (string, *v1.Secret) {
-	fake.createSecretMutex.RLock()
-	defer fake.createSecretMutex.RUnlock()
-	argsForCall := fake.createSecretArgsForCall[i]
+func (fake *FakeClusterDelegate) CreateNetworkPolicyArgsForCall(i int) (string, *v1b.NetworkPolicy) {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.createNetworkPolicyArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeClusterDelegate) CreateSecretReturns(result1 *v1.Secret, result2 error) {
-	fake.createSecretMutex.Lock()
-	defer fake.createSecretMutex.Unlock()
-	fake.CreateSecretStub = nil
-	fake.createSecretReturns = struct {
-		result1 *v1.Secret
+func (fake *FakeClusterDelegate) CreateNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	fake.createNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) CreateSecretReturnsOnCall(i int, result1 *v1.Secret, result2 error) {
-	fake.createSecretMutex.Lock()
+func (fake *FakeClusterDelegate) CreateNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	if fake.createNetworkPolicyReturnsOnCall == nil {
+		fake.createNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.createNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateResourceQuota(arg1 string, arg2 *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	fake.createResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.createResourceQuotaReturnsOnCall[len(fake.createResourceQuotaArgsForCall)]
+	fake.createResourceQuotaArgsForCall = append(fake.createResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}{arg1, arg2})
+	fake.recordInvocation("CreateResourceQuota", []interface{}{arg1, arg2})
+	fake.createResourceQuotaMutex.Unlock()
+	if fake.CreateResourceQuotaStub != nil {
+		return fake.CreateResourceQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) CreateResourceQuotaCallCount() int {
+	fake.createResourceQuotaMutex.RLock()
+	defer fake.createResourceQuotaMutex.RUnlock()
+	return len(fake.createResourceQuotaArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) CreateResourceQuotaCalls(stub func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)) {
+	fake.createResourceQuotaMutex.Lock()
+	defer fake.createResourceQuotaMutex.Unlock()
+	fake.CreateResourceQuotaStub = stub
+}
+
+func (fake *FakeClusterDelegate) CreateResourceQuotaArgsForCall(i int) (string, *v1.ResourceQuota) {
+	fake.createResourceQuotaMutex.RLock()
+	defer fake.createResourceQuotaMutex.RUnlock()
+	argsForCall := fake.createResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) CreateResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.createResourceQuotaMutex.Lock()
+	defer fake.createResourceQuotaMutex.Unlock()
+	fake.CreateResourceQuotaStub = nil
+	fake.createResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.createResourceQuotaMutex.Lock()
+	defer fake.createResourceQuotaMutex.Unlock()
+	fake.CreateResourceQuotaStub = nil
+	if fake.createResourceQuotaReturnsOnCall == nil {
+		fake.createResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.createResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateSecret(arg1 string, arg2 *v1.Secret) (*v1.Secret, error) {
+	fake.createSecretMutex.Lock()
+	ret, specificReturn := fake.createSecretReturnsOnCall[len(fake.createSecretArgsForCall)]
+	fake.createSecretArgsForCall = append(fake.createSecretArgsForCall, struct {
+		arg1 string
+		arg2 *v1.Secret
+	}{arg1, arg2})
+	fake.recordInvocation("CreateSecret", []interface{}{arg1, arg2})
+	fake.createSecretMutex.Unlock()
+	if fake.CreateSecretStub != nil {
+		return fake.CreateSecretStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createSecretReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) CreateSecretCallCount() int {
+	fake.createSecretMutex.RLock()
+	defer fake.createSecretMutex.RUnlock()
+	return len(fake.createSecretArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) CreateSecretCalls(stub func(string, *v1.Secret) (*v1.Secret, error)) {
+	fake.createSecretMutex.Lock()
+	defer fake.createSecretMutex.Unlock()
+	fake.CreateSecretStub = stub
+}
+
+func (fake *FakeClusterDelegate) CreateSecretArgsForCall(i int) (string, *v1.Secret) {
+	fake.createSecretMutex.RLock()
+	defer fake.createSecretMutex.RUnlock()
+	argsForCall := fake.createSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) CreateSecretReturns(result1 *v1.Secret, result2 error) {
+	fake.createSecretMutex.Lock()
+	defer fake.createSecretMutex.Unlock()
+	fake.CreateSecretStub = nil
+	fake.createSecretReturns = struct {
+		result1 *v1.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) CreateSecretReturnsOnCall(i int, result1 *v1.Secret, result2 error) {
+	fake.createSecretMutex.Lock()
 	defer fake.createSecretMutex.Unlock()
 	fake.CreateSecretStub = nil
 	if fake.createSecretReturnsOnCall == nil {
@@ -570,12 +1198,198 @@ func (fake *FakeClusterDelegate) CreateServiceAccountReturnsOnCall(i int, result
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) DeleteNamespace(arg1 string, arg2 *v1a.DeleteOptions) error {
+func (fake *FakeClusterDelegate) DeleteConfigMap(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteConfigMapMutex.Lock()
+	ret, specificReturn := fake.deleteConfigMapReturnsOnCall[len(fake.deleteConfigMapArgsForCall)]
+	fake.deleteConfigMapArgsForCall = append(fake.deleteConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteConfigMap", []interface{}{arg1, arg2, arg3})
+	fake.deleteConfigMapMutex.Unlock()
+	if fake.DeleteConfigMapStub != nil {
+		return fake.DeleteConfigMapStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteConfigMapReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterDelegate) DeleteConfigMapCallCount() int {
+	fake.deleteConfigMapMutex.RLock()
+	defer fake.deleteConfigMapMutex.RUnlock()
+	return len(fake.deleteConfigMapArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) DeleteConfigMapCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteConfigMapMutex.Lock()
+	defer fake.deleteConfigMapMutex.Unlock()
+	fake.DeleteConfigMapStub = stub
+}
+
+func (fake *FakeClusterDelegate) DeleteConfigMapArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteConfigMapMutex.RLock()
+	defer fake.deleteConfigMapMutex.RUnlock()
+	argsForCall := fake.deleteConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) DeleteConfigMapReturns(result1 error) {
+	fake.deleteConfigMapMutex.Lock()
+	defer fake.deleteConfigMapMutex.Unlock()
+	fake.DeleteConfigMapStub = nil
+	fake.deleteConfigMapReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteConfigMapReturnsOnCall(i int, result1 error) {
+	fake.deleteConfigMapMutex.Lock()
+	defer fake.deleteConfigMapMutex.Unlock()
+	fake.DeleteConfigMapStub = nil
+	if fake.deleteConfigMapReturnsOnCall == nil {
+		fake.deleteConfigMapReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteConfigMapReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteJob(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteJobMutex.Lock()
+	ret, specificReturn := fake.deleteJobReturnsOnCall[len(fake.deleteJobArgsForCall)]
+	fake.deleteJobArgsForCall = append(fake.deleteJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteJob", []interface{}{arg1, arg2, arg3})
+	fake.deleteJobMutex.Unlock()
+	if fake.DeleteJobStub != nil {
+		return fake.DeleteJobStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteJobReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterDelegate) DeleteJobCallCount() int {
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	return len(fake.deleteJobArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) DeleteJobCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = stub
+}
+
+func (fake *FakeClusterDelegate) DeleteJobArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	argsForCall := fake.deleteJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) DeleteJobReturns(result1 error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = nil
+	fake.deleteJobReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteJobReturnsOnCall(i int, result1 error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = nil
+	if fake.deleteJobReturnsOnCall == nil {
+		fake.deleteJobReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteJobReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteLimitRange(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteLimitRangeMutex.Lock()
+	ret, specificReturn := fake.deleteLimitRangeReturnsOnCall[len(fake.deleteLimitRangeArgsForCall)]
+	fake.deleteLimitRangeArgsForCall = append(fake.deleteLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteLimitRange", []interface{}{arg1, arg2, arg3})
+	fake.deleteLimitRangeMutex.Unlock()
+	if fake.DeleteLimitRangeStub != nil {
+		return fake.DeleteLimitRangeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteLimitRangeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterDelegate) DeleteLimitRangeCallCount() int {
+	fake.deleteLimitRangeMutex.RLock()
+	defer fake.deleteLimitRangeMutex.RUnlock()
+	return len(fake.deleteLimitRangeArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) DeleteLimitRangeCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteLimitRangeMutex.Lock()
+	defer fake.deleteLimitRangeMutex.Unlock()
+	fake.DeleteLimitRangeStub = stub
+}
+
+func (fake *FakeClusterDelegate) DeleteLimitRangeArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteLimitRangeMutex.RLock()
+	defer fake.deleteLimitRangeMutex.RUnlock()
+	argsForCall := fake.deleteLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) DeleteLimitRangeReturns(result1 error) {
+	fake.deleteLimitRangeMutex.Lock()
+	defer fake.deleteLimitRangeMutex.Unlock()
+	fake.DeleteLimitRangeStub = nil
+	fake.deleteLimitRangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteLimitRangeReturnsOnCall(i int, result1 error) {
+	fake.deleteLimitRangeMutex.Lock()
+	defer fake.deleteLimitRangeMutex.Unlock()
+	fake.DeleteLimitRangeStub = nil
+	if fake.deleteLimitRangeReturnsOnCall == nil {
+		fake.deleteLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteLimitRangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteNamespace(arg1 string, arg2 *v1c.DeleteOptions) error {
 	fake.deleteNamespaceMutex.Lock()
 	ret, specificReturn := fake.deleteNamespaceReturnsOnCall[len(fake.deleteNamespaceArgsForCall)]
 	fake.deleteNamespaceArgsForCall = append(fake.deleteNamespaceArgsForCall, struct {
 		arg1 string
-		arg2 *v1a.DeleteOptions
+		arg2 *v1c.DeleteOptions
 	}{arg1, arg2})
 	fake.recordInvocation("DeleteNamespace", []interface{}{arg1, arg2})
 	fake.deleteNamespaceMutex.Unlock()
@@ -595,13 +1409,13 @@ func (fake *FakeClusterDelegate) DeleteNamespaceCallCount() int {
 	return len(fake.deleteNamespaceArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) DeleteNamespaceCalls(stub func(string, *v1a.DeleteOptions) error) {
+func (fake *FakeClusterDelegate) DeleteNamespaceCalls(stub func(string, *v1c.DeleteOptions) error) {
 	fake.deleteNamespaceMutex.Lock()
 	defer fake.deleteNamespaceMutex.Unlock()
 	fake.DeleteNamespaceStub = stub
 }
 
-func (fake *FakeClusterDelegate) DeleteNamespaceArgsForCall(i int) (string, *v1a.DeleteOptions) {
+func (fake *FakeClusterDelegate) DeleteNamespaceArgsForCall(i int) (string, *v1c.DeleteOptions) {
 	fake.deleteNamespaceMutex.RLock()
 	defer fake.deleteNamespaceMutex.RUnlock()
 	argsForCall := fake.deleteNamespaceArgsForCall[i]
@@ -631,6 +1445,192 @@ func (fake *FakeClusterDelegate) DeleteNamespaceReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeClusterDelegate) DeleteNetworkPolicy(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.deleteNetworkPolicyReturnsOnCall[len(fake.deleteNetworkPolicyArgsForCall)]
+	fake.deleteNetworkPolicyArgsForCall = append(fake.deleteNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.deleteNetworkPolicyMutex.Unlock()
+	if fake.DeleteNetworkPolicyStub != nil {
+		return fake.DeleteNetworkPolicyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteNetworkPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterDelegate) DeleteNetworkPolicyCallCount() int {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	return len(fake.deleteNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) DeleteNetworkPolicyCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = stub
+}
+
+func (fake *FakeClusterDelegate) DeleteNetworkPolicyArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.deleteNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) DeleteNetworkPolicyReturns(result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	fake.deleteNetworkPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteNetworkPolicyReturnsOnCall(i int, result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	if fake.deleteNetworkPolicyReturnsOnCall == nil {
+		fake.deleteNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteNetworkPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteResourceQuota(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.deleteResourceQuotaReturnsOnCall[len(fake.deleteResourceQuotaArgsForCall)]
+	fake.deleteResourceQuotaArgsForCall = append(fake.deleteResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteResourceQuota", []interface{}{arg1, arg2, arg3})
+	fake.deleteResourceQuotaMutex.Unlock()
+	if fake.DeleteResourceQuotaStub != nil {
+		return fake.DeleteResourceQuotaStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteResourceQuotaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterDelegate) DeleteResourceQuotaCallCount() int {
+	fake.deleteResourceQuotaMutex.RLock()
+	defer fake.deleteResourceQuotaMutex.RUnlock()
+	return len(fake.deleteResourceQuotaArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) DeleteResourceQuotaCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteResourceQuotaMutex.Lock()
+	defer fake.deleteResourceQuotaMutex.Unlock()
+	fake.DeleteResourceQuotaStub = stub
+}
+
+func (fake *FakeClusterDelegate) DeleteResourceQuotaArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteResourceQuotaMutex.RLock()
+	defer fake.deleteResourceQuotaMutex.RUnlock()
+	argsForCall := fake.deleteResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) DeleteResourceQuotaReturns(result1 error) {
+	fake.deleteResourceQuotaMutex.Lock()
+	defer fake.deleteResourceQuotaMutex.Unlock()
+	fake.DeleteResourceQuotaStub = nil
+	fake.deleteResourceQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteResourceQuotaReturnsOnCall(i int, result1 error) {
+	fake.deleteResourceQuotaMutex.Lock()
+	defer fake.deleteResourceQuotaMutex.Unlock()
+	fake.DeleteResourceQuotaStub = nil
+	if fake.deleteResourceQuotaReturnsOnCall == nil {
+		fake.deleteResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteResourceQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteSecret(arg1 string, arg2 string, arg3 *v1c.DeleteOptions) error {
+	fake.deleteSecretMutex.Lock()
+	ret, specificReturn := fake.deleteSecretReturnsOnCall[len(fake.deleteSecretArgsForCall)]
+	fake.deleteSecretArgsForCall = append(fake.deleteSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *v1c.DeleteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteSecret", []interface{}{arg1, arg2, arg3})
+	fake.deleteSecretMutex.Unlock()
+	if fake.DeleteSecretStub != nil {
+		return fake.DeleteSecretStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSecretReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterDelegate) DeleteSecretCallCount() int {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	return len(fake.deleteSecretArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) DeleteSecretCalls(stub func(string, string, *v1c.DeleteOptions) error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = stub
+}
+
+func (fake *FakeClusterDelegate) DeleteSecretArgsForCall(i int) (string, string, *v1c.DeleteOptions) {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	argsForCall := fake.deleteSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) DeleteSecretReturns(result1 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	fake.deleteSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterDelegate) DeleteSecretReturnsOnCall(i int, result1 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	if fake.deleteSecretReturnsOnCall == nil {
+		fake.deleteSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClusterDelegate) GetClient() kubernetes.Interface {
 	fake.getClientMutex.Lock()
 	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
@@ -735,77 +1735,272 @@ func (fake *FakeClusterDelegate) GetClientConfigReturnsOnCall(i int, result1 *re
 	}{result1}
 }
 
-func (fake *FakeClusterDelegate) GetDeployment(arg1 string, arg2 string, arg3 v1a.GetOptions) (*v1beta1a.Deployment, error) {
-	fake.getDeploymentMutex.Lock()
-	ret, specificReturn := fake.getDeploymentReturnsOnCall[len(fake.getDeploymentArgsForCall)]
-	fake.getDeploymentArgsForCall = append(fake.getDeploymentArgsForCall, struct {
+func (fake *FakeClusterDelegate) GetConfigMap(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.ConfigMap, error) {
+	fake.getConfigMapMutex.Lock()
+	ret, specificReturn := fake.getConfigMapReturnsOnCall[len(fake.getConfigMapArgsForCall)]
+	fake.getConfigMapArgsForCall = append(fake.getConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetConfigMap", []interface{}{arg1, arg2, arg3})
+	fake.getConfigMapMutex.Unlock()
+	if fake.GetConfigMapStub != nil {
+		return fake.GetConfigMapStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) GetConfigMapCallCount() int {
+	fake.getConfigMapMutex.RLock()
+	defer fake.getConfigMapMutex.RUnlock()
+	return len(fake.getConfigMapArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) GetConfigMapCalls(stub func(string, string, v1c.GetOptions) (*v1.ConfigMap, error)) {
+	fake.getConfigMapMutex.Lock()
+	defer fake.getConfigMapMutex.Unlock()
+	fake.GetConfigMapStub = stub
+}
+
+func (fake *FakeClusterDelegate) GetConfigMapArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getConfigMapMutex.RLock()
+	defer fake.getConfigMapMutex.RUnlock()
+	argsForCall := fake.getConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) GetConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.getConfigMapMutex.Lock()
+	defer fake.getConfigMapMutex.Unlock()
+	fake.GetConfigMapStub = nil
+	fake.getConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.getConfigMapMutex.Lock()
+	defer fake.getConfigMapMutex.Unlock()
+	fake.GetConfigMapStub = nil
+	if fake.getConfigMapReturnsOnCall == nil {
+		fake.getConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.getConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetDeployment(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1beta1a.Deployment, error) {
+	fake.getDeploymentMutex.Lock()
+	ret, specificReturn := fake.getDeploymentReturnsOnCall[len(fake.getDeploymentArgsForCall)]
+	fake.getDeploymentArgsForCall = append(fake.getDeploymentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetDeployment", []interface{}{arg1, arg2, arg3})
+	fake.getDeploymentMutex.Unlock()
+	if fake.GetDeploymentStub != nil {
+		return fake.GetDeploymentStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeploymentReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) GetDeploymentCallCount() int {
+	fake.getDeploymentMutex.RLock()
+	defer fake.getDeploymentMutex.RUnlock()
+	return len(fake.getDeploymentArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) GetDeploymentCalls(stub func(string, string, v1c.GetOptions) (*v1beta1a.Deployment, error)) {
+	fake.getDeploymentMutex.Lock()
+	defer fake.getDeploymentMutex.Unlock()
+	fake.GetDeploymentStub = stub
+}
+
+func (fake *FakeClusterDelegate) GetDeploymentArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getDeploymentMutex.RLock()
+	defer fake.getDeploymentMutex.RUnlock()
+	argsForCall := fake.getDeploymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) GetDeploymentReturns(result1 *v1beta1a.Deployment, result2 error) {
+	fake.getDeploymentMutex.Lock()
+	defer fake.getDeploymentMutex.Unlock()
+	fake.GetDeploymentStub = nil
+	fake.getDeploymentReturns = struct {
+		result1 *v1beta1a.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetDeploymentReturnsOnCall(i int, result1 *v1beta1a.Deployment, result2 error) {
+	fake.getDeploymentMutex.Lock()
+	defer fake.getDeploymentMutex.Unlock()
+	fake.GetDeploymentStub = nil
+	if fake.getDeploymentReturnsOnCall == nil {
+		fake.getDeploymentReturnsOnCall = make(map[int]struct {
+			result1 *v1beta1a.Deployment
+			result2 error
+		})
+	}
+	fake.getDeploymentReturnsOnCall[i] = struct {
+		result1 *v1beta1a.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetJob(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1a.Job, error) {
+	fake.getJobMutex.Lock()
+	ret, specificReturn := fake.getJobReturnsOnCall[len(fake.getJobArgsForCall)]
+	fake.getJobArgsForCall = append(fake.getJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetJob", []interface{}{arg1, arg2, arg3})
+	fake.getJobMutex.Unlock()
+	if fake.GetJobStub != nil {
+		return fake.GetJobStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getJobReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) GetJobCallCount() int {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	return len(fake.getJobArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) GetJobCalls(stub func(string, string, v1c.GetOptions) (*v1a.Job, error)) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = stub
+}
+
+func (fake *FakeClusterDelegate) GetJobArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	argsForCall := fake.getJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) GetJobReturns(result1 *v1a.Job, result2 error) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = nil
+	fake.getJobReturns = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetJobReturnsOnCall(i int, result1 *v1a.Job, result2 error) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = nil
+	if fake.getJobReturnsOnCall == nil {
+		fake.getJobReturnsOnCall = make(map[int]struct {
+			result1 *v1a.Job
+			result2 error
+		})
+	}
+	fake.getJobReturnsOnCall[i] = struct {
+		result1 *v1a.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetLimitRange(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.LimitRange, error) {
+	fake.getLimitRangeMutex.Lock()
+	ret, specificReturn := fake.getLimitRangeReturnsOnCall[len(fake.getLimitRangeArgsForCall)]
+	fake.getLimitRangeArgsForCall = append(fake.getLimitRangeArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}{arg1, arg2, arg3})
-	fake.recordInvocation("GetDeployment", []interface{}{arg1, arg2, arg3})
-	fake.getDeploymentMutex.Unlock()
-	if fake.GetDeploymentStub != nil {
-		return fake.GetDeploymentStub(arg1, arg2, arg3)
+	fake.recordInvocation("GetLimitRange", []interface{}{arg1, arg2, arg3})
+	fake.getLimitRangeMutex.Unlock()
+	if fake.GetLimitRangeStub != nil {
+		return fake.GetLimitRangeStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getDeploymentReturns
+	fakeReturns := fake.getLimitRangeReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeClusterDelegate) GetDeploymentCallCount() int {
-	fake.getDeploymentMutex.RLock()
-	defer fake.getDeploymentMutex.RUnlock()
-	return len(fake.getDeploymentArgsForCall)
+func (fake *FakeClusterDelegate) GetLimitRangeCallCount() int {
+	fake.getLimitRangeMutex.RLock()
+	defer fake.getLimitRangeMutex.RUnlock()
+	return len(fake.getLimitRangeArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) GetDeploymentCalls(stub func(string, string, v1a.GetOptions) (*v1beta1a.Deployment, error)) {
-	fake.getDeploymentMutex.Lock()
-	defer fake.getDeploymentMutex.Unlock()
-	fake.GetDeploymentStub = stub
+func (fake *FakeClusterDelegate) GetLimitRangeCalls(stub func(string, string, v1c.GetOptions) (*v1.LimitRange, error)) {
+	fake.getLimitRangeMutex.Lock()
+	defer fake.getLimitRangeMutex.Unlock()
+	fake.GetLimitRangeStub = stub
 }
 
-func (fake *FakeClusterDelegate) GetDeploymentArgsForCall(i int) (string, string, v1a.GetOptions) {
-	fake.getDeploymentMutex.RLock()
-	defer fake.getDeploymentMutex.RUnlock()
-	argsForCall := fake.getDeploymentArgsForCall[i]
+func (fake *FakeClusterDelegate) GetLimitRangeArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getLimitRangeMutex.RLock()
+	defer fake.getLimitRangeMutex.RUnlock()
+	argsForCall := fake.getLimitRangeArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeClusterDelegate) GetDeploymentReturns(result1 *v1beta1a.Deployment, result2 error) {
-	fake.getDeploymentMutex.Lock()
-	defer fake.getDeploymentMutex.Unlock()
-	fake.GetDeploymentStub = nil
-	fake.getDeploymentReturns = struct {
-		result1 *v1beta1a.Deployment
+func (fake *FakeClusterDelegate) GetLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.getLimitRangeMutex.Lock()
+	defer fake.getLimitRangeMutex.Unlock()
+	fake.GetLimitRangeStub = nil
+	fake.getLimitRangeReturns = struct {
+		result1 *v1.LimitRange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) GetDeploymentReturnsOnCall(i int, result1 *v1beta1a.Deployment, result2 error) {
-	fake.getDeploymentMutex.Lock()
-	defer fake.getDeploymentMutex.Unlock()
-	fake.GetDeploymentStub = nil
-	if fake.getDeploymentReturnsOnCall == nil {
-		fake.getDeploymentReturnsOnCall = make(map[int]struct {
-			result1 *v1beta1a.Deployment
+func (fake *FakeClusterDelegate) GetLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.getLimitRangeMutex.Lock()
+	defer fake.getLimitRangeMutex.Unlock()
+	fake.GetLimitRangeStub = nil
+	if fake.getLimitRangeReturnsOnCall == nil {
+		fake.getLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
 			result2 error
 		})
 	}
-	fake.getDeploymentReturnsOnCall[i] = struct {
-		result1 *v1beta1a.Deployment
+	fake.getLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) GetNamespace(arg1 string, arg2 *v1a.GetOptions) (*v1.Namespace, error) {
+func (fake *FakeClusterDelegate) GetNamespace(arg1 string, arg2 *v1c.GetOptions) (*v1.Namespace, error) {
 	fake.getNamespaceMutex.Lock()
 	ret, specificReturn := fake.getNamespaceReturnsOnCall[len(fake.getNamespaceArgsForCall)]
 	fake.getNamespaceArgsForCall = append(fake.getNamespaceArgsForCall, struct {
 		arg1 string
-		arg2 *v1a.GetOptions
+		arg2 *v1c.GetOptions
 	}{arg1, arg2})
 	fake.recordInvocation("GetNamespace", []interface{}{arg1, arg2})
 	fake.getNamespaceMutex.Unlock()
@@ -825,13 +2020,13 @@ func (fake *FakeClusterDelegate) GetNamespaceCallCount() int {
 	return len(fake.getNamespaceArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) GetNamespaceCalls(stub func(string, *v1a.GetOptions) (*v1.Namespace, error)) {
+func (fake *FakeClusterDelegate) GetNamespaceCalls(stub func(string, *v1c.GetOptions) (*v1.Namespace, error)) {
 	fake.getNamespaceMutex.Lock()
 	defer fake.getNamespaceMutex.Unlock()
 	fake.GetNamespaceStub = stub
 }
 
-func (fake *FakeClusterDelegate) GetNamespaceArgsForCall(i int) (string, *v1a.GetOptions) {
+func (fake *FakeClusterDelegate) GetNamespaceArgsForCall(i int) (string, *v1c.GetOptions) {
 	fake.getNamespaceMutex.RLock()
 	defer fake.getNamespaceMutex.RUnlock()
 	argsForCall := fake.getNamespaceArgsForCall[i]
@@ -919,13 +2114,143 @@ func (fake *FakeClusterDelegate) GetNamespacesReturnsOnCall(i int, result1 *v1.N
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) GetSecret(arg1 string, arg2 string, arg3 v1a.GetOptions) (*v1.Secret, error) {
+func (fake *FakeClusterDelegate) GetNetworkPolicy(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1b.NetworkPolicy, error) {
+	fake.getNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.getNetworkPolicyReturnsOnCall[len(fake.getNetworkPolicyArgsForCall)]
+	fake.getNetworkPolicyArgsForCall = append(fake.getNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.getNetworkPolicyMutex.Unlock()
+	if fake.GetNetworkPolicyStub != nil {
+		return fake.GetNetworkPolicyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getNetworkPolicyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) GetNetworkPolicyCallCount() int {
+	fake.getNetworkPolicyMutex.RLock()
+	defer fake.getNetworkPolicyMutex.RUnlock()
+	return len(fake.getNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) GetNetworkPolicyCalls(stub func(string, string, v1c.GetOptions) (*v1b.NetworkPolicy, error)) {
+	fake.getNetworkPolicyMutex.Lock()
+	defer fake.getNetworkPolicyMutex.Unlock()
+	fake.GetNetworkPolicyStub = stub
+}
+
+func (fake *FakeClusterDelegate) GetNetworkPolicyArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getNetworkPolicyMutex.RLock()
+	defer fake.getNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.getNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) GetNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.getNetworkPolicyMutex.Lock()
+	defer fake.getNetworkPolicyMutex.Unlock()
+	fake.GetNetworkPolicyStub = nil
+	fake.getNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.getNetworkPolicyMutex.Lock()
+	defer fake.getNetworkPolicyMutex.Unlock()
+	fake.GetNetworkPolicyStub = nil
+	if fake.getNetworkPolicyReturnsOnCall == nil {
+		fake.getNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.getNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetResourceQuota(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.ResourceQuota, error) {
+	fake.getResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.getResourceQuotaReturnsOnCall[len(fake.getResourceQuotaArgsForCall)]
+	fake.getResourceQuotaArgsForCall = append(fake.getResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1c.GetOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetResourceQuota", []interface{}{arg1, arg2, arg3})
+	fake.getResourceQuotaMutex.Unlock()
+	if fake.GetResourceQuotaStub != nil {
+		return fake.GetResourceQuotaStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) GetResourceQuotaCallCount() int {
+	fake.getResourceQuotaMutex.RLock()
+	defer fake.getResourceQuotaMutex.RUnlock()
+	return len(fake.getResourceQuotaArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) GetResourceQuotaCalls(stub func(string, string, v1c.GetOptions) (*v1.ResourceQuota, error)) {
+	fake.getResourceQuotaMutex.Lock()
+	defer fake.getResourceQuotaMutex.Unlock()
+	fake.GetResourceQuotaStub = stub
+}
+
+func (fake *FakeClusterDelegate) GetResourceQuotaArgsForCall(i int) (string, string, v1c.GetOptions) {
+	fake.getResourceQuotaMutex.RLock()
+	defer fake.getResourceQuotaMutex.RUnlock()
+	argsForCall := fake.getResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterDelegate) GetResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.getResourceQuotaMutex.Lock()
+	defer fake.getResourceQuotaMutex.Unlock()
+	fake.GetResourceQuotaStub = nil
+	fake.getResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.getResourceQuotaMutex.Lock()
+	defer fake.getResourceQuotaMutex.Unlock()
+	fake.GetResourceQuotaStub = nil
+	if fake.getResourceQuotaReturnsOnCall == nil {
+		fake.getResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.getResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) GetSecret(arg1 string, arg2 string, arg3 v1c.GetOptions) (*v1.Secret, error) {
 	fake.getSecretMutex.Lock()
 	ret, specificReturn := fake.getSecretReturnsOnCall[len(fake.getSecretArgsForCall)]
 	fake.getSecretArgsForCall = append(fake.getSecretArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 v1a.GetOptions
+		arg3 v1c.GetOptions
 	}{arg1, arg2, arg3})
 	fake.recordInvocation("GetSecret", []interface{}{arg1, arg2, arg3})
 	fake.getSecretMutex.Unlock()
@@ -945,13 +2270,13 @@ func (fake *FakeClusterDelegate) GetSecretCallCount() int {
 	return len(fake.getSecretArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) GetSecretCalls(stub func(string, string, v1a.GetOptions) (*v1.Secret, error)) {
+func (fake *FakeClusterDelegate) GetSecretCalls(stub func(string, string, v1c.GetOptions) (*v1.Secret, error)) {
 	fake.getSecretMutex.Lock()
 	defer fake.getSecretMutex.Unlock()
 	fake.GetSecretStub = stub
 }
 
-func (fake *FakeClusterDelegate) GetSecretArgsForCall(i int) (string, string, v1a.GetOptions) {
+func (fake *FakeClusterDelegate) GetSecretArgsForCall(i int) (string, string, v1c.GetOptions) {
 	fake.getSecretMutex.RLock()
 	defer fake.getSecretMutex.RUnlock()
 	argsForCall := fake.getSecretArgsForCall[i]
@@ -984,11 +2309,11 @@ func (fake *FakeClusterDelegate) GetSecretReturnsOnCall(i int, result1 *v1.Secre
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListClusterRoleBindings(arg1 v1a.ListOptions) (*v1beta1.ClusterRoleBindingList, error) {
+func (fake *FakeClusterDelegate) ListClusterRoleBindings(arg1 v1c.ListOptions) (*v1beta1.ClusterRoleBindingList, error) {
 	fake.listClusterRoleBindingsMutex.Lock()
 	ret, specificReturn := fake.listClusterRoleBindingsReturnsOnCall[len(fake.listClusterRoleBindingsArgsForCall)]
 	fake.listClusterRoleBindingsArgsForCall = append(fake.listClusterRoleBindingsArgsForCall, struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}{arg1})
 	fake.recordInvocation("ListClusterRoleBindings", []interface{}{arg1})
 	fake.listClusterRoleBindingsMutex.Unlock()
@@ -1008,13 +2333,13 @@ func (fake *FakeClusterDelegate) ListClusterRoleBindingsCallCount() int {
 	return len(fake.listClusterRoleBindingsArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListClusterRoleBindingsCalls(stub func(v1a.ListOptions) (*v1beta1.ClusterRoleBindingList, error)) {
+func (fake *FakeClusterDelegate) ListClusterRoleBindingsCalls(stub func(v1c.ListOptions) (*v1beta1.ClusterRoleBindingList, error)) {
 	fake.listClusterRoleBindingsMutex.Lock()
 	defer fake.listClusterRoleBindingsMutex.Unlock()
 	fake.ListClusterRoleBindingsStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListClusterRoleBindingsArgsForCall(i int) v1a.ListOptions {
+func (fake *FakeClusterDelegate) ListClusterRoleBindingsArgsForCall(i int) v1c.ListOptions {
 	fake.listClusterRoleBindingsMutex.RLock()
 	defer fake.listClusterRoleBindingsMutex.RUnlock()
 	argsForCall := fake.listClusterRoleBindingsArgsForCall[i]
@@ -1047,12 +2372,76 @@ func (fake *FakeClusterDelegate) ListClusterRoleBindingsReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListDeployments(arg1 string, arg2 v1a.ListOptions) (*k8s.DeploymentList, error) {
+func (fake *FakeClusterDelegate) ListConfigMaps(arg1 string, arg2 v1c.ListOptions) (*v1.ConfigMapList, error) {
+	fake.listConfigMapsMutex.Lock()
+	ret, specificReturn := fake.listConfigMapsReturnsOnCall[len(fake.listConfigMapsArgsForCall)]
+	fake.listConfigMapsArgsForCall = append(fake.listConfigMapsArgsForCall, struct {
+		arg1 string
+		arg2 v1c.ListOptions
+	}{arg1, arg2})
+	fake.recordInvocation("ListConfigMaps", []interface{}{arg1, arg2})
+	fake.listConfigMapsMutex.Unlock()
+	if fake.ListConfigMapsStub != nil {
+		return fake.ListConfigMapsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listConfigMapsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) ListConfigMapsCallCount() int {
+	fake.listConfigMapsMutex.RLock()
+	defer fake.listConfigMapsMutex.RUnlock()
+	return len(fake.listConfigMapsArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) ListConfigMapsCalls(stub func(string, v1c.ListOptions) (*v1.ConfigMapList, error)) {
+	fake.listConfigMapsMutex.Lock()
+	defer fake.listConfigMapsMutex.Unlock()
+	fake.ListConfigMapsStub = stub
+}
+
+func (fake *FakeClusterDelegate) ListConfigMapsArgsForCall(i int) (string, v1c.ListOptions) {
+	fake.listConfigMapsMutex.RLock()
+	defer fake.listConfigMapsMutex.RUnlock()
+	argsForCall := fake.listConfigMapsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) ListConfigMapsReturns(result1 *v1.ConfigMapList, result2 error) {
+	fake.listConfigMapsMutex.Lock()
+	defer fake.listConfigMapsMutex.Unlock()
+	fake.ListConfigMapsStub = nil
+	fake.listConfigMapsReturns = struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) ListConfigMapsReturnsOnCall(i int, result1 *v1.ConfigMapList, result2 error) {
+	fake.listConfigMapsMutex.Lock()
+	defer fake.listConfigMapsMutex.Unlock()
+	fake.ListConfigMapsStub = nil
+	if fake.listConfigMapsReturnsOnCall == nil {
+		fake.listConfigMapsReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMapList
+			result2 error
+		})
+	}
+	fake.listConfigMapsReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMapList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) ListDeployments(arg1 string, arg2 v1c.ListOptions) (*k8s.DeploymentList, error) {
 	fake.listDeploymentsMutex.Lock()
 	ret, specificReturn := fake.listDeploymentsReturnsOnCall[len(fake.listDeploymentsArgsForCall)]
 	fake.listDeploymentsArgsForCall = append(fake.listDeploymentsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListDeployments", []interface{}{arg1, arg2})
 	fake.listDeploymentsMutex.Unlock()
@@ -1072,13 +2461,13 @@ func (fake *FakeClusterDelegate) ListDeploymentsCallCount() int {
 	return len(fake.listDeploymentsArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListDeploymentsCalls(stub func(string, v1a.ListOptions) (*k8s.DeploymentList, error)) {
+func (fake *FakeClusterDelegate) ListDeploymentsCalls(stub func(string, v1c.ListOptions) (*k8s.DeploymentList, error)) {
 	fake.listDeploymentsMutex.Lock()
 	defer fake.listDeploymentsMutex.Unlock()
 	fake.ListDeploymentsStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListDeploymentsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListDeploymentsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listDeploymentsMutex.RLock()
 	defer fake.listDeploymentsMutex.RUnlock()
 	argsForCall := fake.listDeploymentsArgsForCall[i]
@@ -1111,12 +2500,12 @@ func (fake *FakeClusterDelegate) ListDeploymentsReturnsOnCall(i int, result1 *k8
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListIngresses(arg1 string, arg2 v1a.ListOptions) (*v1beta1a.IngressList, error) {
+func (fake *FakeClusterDelegate) ListIngresses(arg1 string, arg2 v1c.ListOptions) (*v1beta1a.IngressList, error) {
 	fake.listIngressesMutex.Lock()
 	ret, specificReturn := fake.listIngressesReturnsOnCall[len(fake.listIngressesArgsForCall)]
 	fake.listIngressesArgsForCall = append(fake.listIngressesArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListIngresses", []interface{}{arg1, arg2})
 	fake.listIngressesMutex.Unlock()
@@ -1136,13 +2525,13 @@ func (fake *FakeClusterDelegate) ListIngressesCallCount() int {
 	return len(fake.listIngressesArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListIngressesCalls(stub func(string, v1a.ListOptions) (*v1beta1a.IngressList, error)) {
+func (fake *FakeClusterDelegate) ListIngressesCalls(stub func(string, v1c.ListOptions) (*v1beta1a.IngressList, error)) {
 	fake.listIngressesMutex.Lock()
 	defer fake.listIngressesMutex.Unlock()
 	fake.ListIngressesStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListIngressesArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListIngressesArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listIngressesMutex.RLock()
 	defer fake.listIngressesMutex.RUnlock()
 	argsForCall := fake.listIngressesArgsForCall[i]
@@ -1175,11 +2564,11 @@ func (fake *FakeClusterDelegate) ListIngressesReturnsOnCall(i int, result1 *v1be
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListNodes(arg1 v1a.ListOptions) (*v1.NodeList, error) {
+func (fake *FakeClusterDelegate) ListNodes(arg1 v1c.ListOptions) (*v1.NodeList, error) {
 	fake.listNodesMutex.Lock()
 	ret, specificReturn := fake.listNodesReturnsOnCall[len(fake.listNodesArgsForCall)]
 	fake.listNodesArgsForCall = append(fake.listNodesArgsForCall, struct {
-		arg1 v1a.ListOptions
+		arg1 v1c.ListOptions
 	}{arg1})
 	fake.recordInvocation("ListNodes", []interface{}{arg1})
 	fake.listNodesMutex.Unlock()
@@ -1199,13 +2588,13 @@ func (fake *FakeClusterDelegate) ListNodesCallCount() int {
 	return len(fake.listNodesArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListNodesCalls(stub func(v1a.ListOptions) (*v1.NodeList, error)) {
+func (fake *FakeClusterDelegate) ListNodesCalls(stub func(v1c.ListOptions) (*v1.NodeList, error)) {
 	fake.listNodesMutex.Lock()
 	defer fake.listNodesMutex.Unlock()
 	fake.ListNodesStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListNodesArgsForCall(i int) v1a.ListOptions {
+func (fake *FakeClusterDelegate) ListNodesArgsForCall(i int) v1c.ListOptions {
 	fake.listNodesMutex.RLock()
 	defer fake.listNodesMutex.RUnlock()
 	argsForCall := fake.listNodesArgsForCall[i]
@@ -1238,12 +2627,12 @@ func (fake *FakeClusterDelegate) ListNodesReturnsOnCall(i int, result1 *v1.NodeL
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListPersistentVolumes(arg1 string, arg2 v1a.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+func (fake *FakeClusterDelegate) ListPersistentVolumes(arg1 string, arg2 v1c.ListOptions) (*v1.PersistentVolumeClaimList, error) {
 	fake.listPersistentVolumesMutex.Lock()
 	ret, specificReturn := fake.listPersistentVolumesReturnsOnCall[len(fake.listPersistentVolumesArgsForCall)]
 	fake.listPersistentVolumesArgsForCall = append(fake.listPersistentVolumesArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListPersistentVolumes", []interface{}{arg1, arg2})
 	fake.listPersistentVolumesMutex.Unlock()
@@ -1263,13 +2652,13 @@ func (fake *FakeClusterDelegate) ListPersistentVolumesCallCount() int {
 	return len(fake.listPersistentVolumesArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListPersistentVolumesCalls(stub func(string, v1a.ListOptions) (*v1.PersistentVolumeClaimList, error)) {
+func (fake *FakeClusterDelegate) ListPersistentVolumesCalls(stub func(string, v1c.ListOptions) (*v1.PersistentVolumeClaimList, error)) {
 	fake.listPersistentVolumesMutex.Lock()
 	defer fake.listPersistentVolumesMutex.Unlock()
 	fake.ListPersistentVolumesStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListPersistentVolumesArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListPersistentVolumesArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listPersistentVolumesMutex.RLock()
 	defer fake.listPersistentVolumesMutex.RUnlock()
 	argsForCall := fake.listPersistentVolumesArgsForCall[i]
@@ -1302,12 +2691,12 @@ func (fake *FakeClusterDelegate) ListPersistentVolumesReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListPods(arg1 string, arg2 v1a.ListOptions) (*v1.PodList, error) {
+func (fake *FakeClusterDelegate) ListPods(arg1 string, arg2 v1c.ListOptions) (*v1.PodList, error) {
 	fake.listPodsMutex.Lock()
 	ret, specificReturn := fake.listPodsReturnsOnCall[len(fake.listPodsArgsForCall)]
 	fake.listPodsArgsForCall = append(fake.listPodsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListPods", []interface{}{arg1, arg2})
 	fake.listPodsMutex.Unlock()
@@ -1327,13 +2716,13 @@ func (fake *FakeClusterDelegate) ListPodsCallCount() int {
 	return len(fake.listPodsArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListPodsCalls(stub func(string, v1a.ListOptions) (*v1.PodList, error)) {
+func (fake *FakeClusterDelegate) ListPodsCalls(stub func(string, v1c.ListOptions) (*v1.PodList, error)) {
 	fake.listPodsMutex.Lock()
 	defer fake.listPodsMutex.Unlock()
 	fake.ListPodsStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListPodsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListPodsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listPodsMutex.RLock()
 	defer fake.listPodsMutex.RUnlock()
 	argsForCall := fake.listPodsArgsForCall[i]
@@ -1366,12 +2755,12 @@ func (fake *FakeClusterDelegate) ListPodsReturnsOnCall(i int, result1 *v1.PodLis
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListSecrets(arg1 string, arg2 v1a.ListOptions) (*v1.SecretList, error) {
+func (fake *FakeClusterDelegate) ListSecrets(arg1 string, arg2 v1c.ListOptions) (*v1.SecretList, error) {
 	fake.listSecretsMutex.Lock()
 	ret, specificReturn := fake.listSecretsReturnsOnCall[len(fake.listSecretsArgsForCall)]
 	fake.listSecretsArgsForCall = append(fake.listSecretsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListSecrets", []interface{}{arg1, arg2})
 	fake.listSecretsMutex.Unlock()
@@ -1391,13 +2780,13 @@ func (fake *FakeClusterDelegate) ListSecretsCallCount() int {
 	return len(fake.listSecretsArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListSecretsCalls(stub func(string, v1a.ListOptions) (*v1.SecretList, error)) {
+func (fake *FakeClusterDelegate) ListSecretsCalls(stub func(string, v1c.ListOptions) (*v1.SecretList, error)) {
 	fake.listSecretsMutex.Lock()
 	defer fake.listSecretsMutex.Unlock()
 	fake.ListSecretsStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListSecretsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListSecretsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listSecretsMutex.RLock()
 	defer fake.listSecretsMutex.RUnlock()
 	argsForCall := fake.listSecretsArgsForCall[i]
@@ -1430,12 +2819,12 @@ func (fake *FakeClusterDelegate) ListSecretsReturnsOnCall(i int, result1 *v1.Sec
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListServiceAccounts(arg1 string, arg2 v1a.ListOptions) (*v1.ServiceAccountList, error) {
+func (fake *FakeClusterDelegate) ListServiceAccounts(arg1 string, arg2 v1c.ListOptions) (*v1.ServiceAccountList, error) {
 	fake.listServiceAccountsMutex.Lock()
 	ret, specificReturn := fake.listServiceAccountsReturnsOnCall[len(fake.listServiceAccountsArgsForCall)]
 	fake.listServiceAccountsArgsForCall = append(fake.listServiceAccountsArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListServiceAccounts", []interface{}{arg1, arg2})
 	fake.listServiceAccountsMutex.Unlock()
@@ -1455,13 +2844,13 @@ func (fake *FakeClusterDelegate) ListServiceAccountsCallCount() int {
 	return len(fake.listServiceAccountsArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListServiceAccountsCalls(stub func(string, v1a.ListOptions) (*v1.ServiceAccountList, error)) {
+func (fake *FakeClusterDelegate) ListServiceAccountsCalls(stub func(string, v1c.ListOptions) (*v1.ServiceAccountList, error)) {
 	fake.listServiceAccountsMutex.Lock()
 	defer fake.listServiceAccountsMutex.Unlock()
 	fake.ListServiceAccountsStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListServiceAccountsArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListServiceAccountsArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listServiceAccountsMutex.RLock()
 	defer fake.listServiceAccountsMutex.RUnlock()
 	argsForCall := fake.listServiceAccountsArgsForCall[i]
@@ -1494,12 +2883,12 @@ func (fake *FakeClusterDelegate) ListServiceAccountsReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeClusterDelegate) ListServices(arg1 string, arg2 v1a.ListOptions) (*v1.ServiceList, error) {
+func (fake *FakeClusterDelegate) ListServices(arg1 string, arg2 v1c.ListOptions) (*v1.ServiceList, error) {
 	fake.listServicesMutex.Lock()
 	ret, specificReturn := fake.listServicesReturnsOnCall[len(fake.listServicesArgsForCall)]
 	fake.listServicesArgsForCall = append(fake.listServicesArgsForCall, struct {
 		arg1 string
-		arg2 v1a.ListOptions
+		arg2 v1c.ListOptions
 	}{arg1, arg2})
 	fake.recordInvocation("ListServices", []interface{}{arg1, arg2})
 	fake.listServicesMutex.Unlock()
@@ -1519,13 +2908,13 @@ func (fake *FakeClusterDelegate) ListServicesCallCount() int {
 	return len(fake.listServicesArgsForCall)
 }
 
-func (fake *FakeClusterDelegate) ListServicesCalls(stub func(string, v1a.ListOptions) (*v1.ServiceList, error)) {
+func (fake *FakeClusterDelegate) ListServicesCalls(stub func(string, v1c.ListOptions) (*v1.ServiceList, error)) {
 	fake.listServicesMutex.Lock()
 	defer fake.listServicesMutex.Unlock()
 	fake.ListServicesStub = stub
 }
 
-func (fake *FakeClusterDelegate) ListServicesArgsForCall(i int) (string, v1a.ListOptions) {
+func (fake *FakeClusterDelegate) ListServicesArgsForCall(i int) (string, v1c.ListOptions) {
 	fake.listServicesMutex.RLock()
 	defer fake.listServicesMutex.RUnlock()
 	argsForCall := fake.listServicesArgsForCall[i]
@@ -1630,6 +3019,325 @@ func (fake *FakeClusterDelegate) PatchReturnsOnCall(i int, result1 *v1.ServiceAc
 	}{result1, result2}
 }
 
+func (fake *FakeClusterDelegate) UpdateConfigMap(arg1 string, arg2 *v1.ConfigMap) (*v1.ConfigMap, error) {
+	fake.updateConfigMapMutex.Lock()
+	ret, specificReturn := fake.updateConfigMapReturnsOnCall[len(fake.updateConfigMapArgsForCall)]
+	fake.updateConfigMapArgsForCall = append(fake.updateConfigMapArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ConfigMap
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateConfigMap", []interface{}{arg1, arg2})
+	fake.updateConfigMapMutex.Unlock()
+	if fake.UpdateConfigMapStub != nil {
+		return fake.UpdateConfigMapStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateConfigMapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) UpdateConfigMapCallCount() int {
+	fake.updateConfigMapMutex.RLock()
+	defer fake.updateConfigMapMutex.RUnlock()
+	return len(fake.updateConfigMapArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) UpdateConfigMapCalls(stub func(string, *v1.ConfigMap) (*v1.ConfigMap, error)) {
+	fake.updateConfigMapMutex.Lock()
+	defer fake.updateConfigMapMutex.Unlock()
+	fake.UpdateConfigMapStub = stub
+}
+
+func (fake *FakeClusterDelegate) UpdateConfigMapArgsForCall(i int) (string, *v1.ConfigMap) {
+	fake.updateConfigMapMutex.RLock()
+	defer fake.updateConfigMapMutex.RUnlock()
+	argsForCall := fake.updateConfigMapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) UpdateConfigMapReturns(result1 *v1.ConfigMap, result2 error) {
+	fake.updateConfigMapMutex.Lock()
+	defer fake.updateConfigMapMutex.Unlock()
+	fake.UpdateConfigMapStub = nil
+	fake.updateConfigMapReturns = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateConfigMapReturnsOnCall(i int, result1 *v1.ConfigMap, result2 error) {
+	fake.updateConfigMapMutex.Lock()
+	defer fake.updateConfigMapMutex.Unlock()
+	fake.UpdateConfigMapStub = nil
+	if fake.updateConfigMapReturnsOnCall == nil {
+		fake.updateConfigMapReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigMap
+			result2 error
+		})
+	}
+	fake.updateConfigMapReturnsOnCall[i] = struct {
+		result1 *v1.ConfigMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateLimitRange(arg1 string, arg2 *v1.LimitRange) (*v1.LimitRange, error) {
+	fake.updateLimitRangeMutex.Lock()
+	ret, specificReturn := fake.updateLimitRangeReturnsOnCall[len(fake.updateLimitRangeArgsForCall)]
+	fake.updateLimitRangeArgsForCall = append(fake.updateLimitRangeArgsForCall, struct {
+		arg1 string
+		arg2 *v1.LimitRange
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateLimitRange", []interface{}{arg1, arg2})
+	fake.updateLimitRangeMutex.Unlock()
+	if fake.UpdateLimitRangeStub != nil {
+		return fake.UpdateLimitRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateLimitRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) UpdateLimitRangeCallCount() int {
+	fake.updateLimitRangeMutex.RLock()
+	defer fake.updateLimitRangeMutex.RUnlock()
+	return len(fake.updateLimitRangeArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) UpdateLimitRangeCalls(stub func(string, *v1.LimitRange) (*v1.LimitRange, error)) {
+	fake.updateLimitRangeMutex.Lock()
+	defer fake.updateLimitRangeMutex.Unlock()
+	fake.UpdateLimitRangeStub = stub
+}
+
+func (fake *FakeClusterDelegate) UpdateLimitRangeArgsForCall(i int) (string, *v1.LimitRange) {
+	fake.updateLimitRangeMutex.RLock()
+	defer fake.updateLimitRangeMutex.RUnlock()
+	argsForCall := fake.updateLimitRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) UpdateLimitRangeReturns(result1 *v1.LimitRange, result2 error) {
+	fake.updateLimitRangeMutex.Lock()
+	defer fake.updateLimitRangeMutex.Unlock()
+	fake.UpdateLimitRangeStub = nil
+	fake.updateLimitRangeReturns = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateLimitRangeReturnsOnCall(i int, result1 *v1.LimitRange, result2 error) {
+	fake.updateLimitRangeMutex.Lock()
+	defer fake.updateLimitRangeMutex.Unlock()
+	fake.UpdateLimitRangeStub = nil
+	if fake.updateLimitRangeReturnsOnCall == nil {
+		fake.updateLimitRangeReturnsOnCall = make(map[int]struct {
+			result1 *v1.LimitRange
+			result2 error
+		})
+	}
+	fake.updateLimitRangeReturnsOnCall[i] = struct {
+		result1 *v1.LimitRange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateNamespace(arg1 *v1.Namespace) (*v1.Namespace, error) {
+	fake.updateNamespaceMutex.Lock()
+	ret, specificReturn := fake.updateNamespaceReturnsOnCall[len(fake.updateNamespaceArgsForCall)]
+	fake.updateNamespaceArgsForCall = append(fake.updateNamespaceArgsForCall, struct {
+		arg1 *v1.Namespace
+	}{arg1})
+	fake.recordInvocation("UpdateNamespace", []interface{}{arg1})
+	fake.updateNamespaceMutex.Unlock()
+	if fake.UpdateNamespaceStub != nil {
+		return fake.UpdateNamespaceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateNamespaceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) UpdateNamespaceCallCount() int {
+	fake.updateNamespaceMutex.RLock()
+	defer fake.updateNamespaceMutex.RUnlock()
+	return len(fake.updateNamespaceArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) UpdateNamespaceCalls(stub func(*v1.Namespace) (*v1.Namespace, error)) {
+	fake.updateNamespaceMutex.Lock()
+	defer fake.updateNamespaceMutex.Unlock()
+	fake.UpdateNamespaceStub = stub
+}
+
+func (fake *FakeClusterDelegate) UpdateNamespaceArgsForCall(i int) *v1.Namespace {
+	fake.updateNamespaceMutex.RLock()
+	defer fake.updateNamespaceMutex.RUnlock()
+	argsForCall := fake.updateNamespaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClusterDelegate) UpdateNamespaceReturns(result1 *v1.Namespace, result2 error) {
+	fake.updateNamespaceMutex.Lock()
+	defer fake.updateNamespaceMutex.Unlock()
+	fake.UpdateNamespaceStub = nil
+	fake.updateNamespaceReturns = struct {
+		result1 *v1.Namespace
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateNamespaceReturnsOnCall(i int, result1 *v1.Namespace, result2 error) {
+	fake.updateNamespaceMutex.Lock()
+	defer fake.updateNamespaceMutex.Unlock()
+	fake.UpdateNamespaceStub = nil
+	if fake.updateNamespaceReturnsOnCall == nil {
+		fake.updateNamespaceReturnsOnCall = make(map[int]struct {
+			result1 *v1.Namespace
+			result2 error
+		})
+	}
+	fake.updateNamespaceReturnsOnCall[i] = struct {
+		result1 *v1.Namespace
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateNetworkPolicy(arg1 string, arg2 *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error) {
+	fake.updateNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.updateNetworkPolicyReturnsOnCall[len(fake.updateNetworkPolicyArgsForCall)]
+	fake.updateNetworkPolicyArgsForCall = append(fake.updateNetworkPolicyArgsForCall, struct {
+		arg1 string
+		arg2 *v1b.NetworkPolicy
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateNetworkPolicy", []interface{}{arg1, arg2})
+	fake.updateNetworkPolicyMutex.Unlock()
+	if fake.UpdateNetworkPolicyStub != nil {
+		return fake.UpdateNetworkPolicyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateNetworkPolicyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) UpdateNetworkPolicyCallCount() int {
+	fake.updateNetworkPolicyMutex.RLock()
+	defer fake.updateNetworkPolicyMutex.RUnlock()
+	return len(fake.updateNetworkPolicyArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) UpdateNetworkPolicyCalls(stub func(string, *v1b.NetworkPolicy) (*v1b.NetworkPolicy, error)) {
+	fake.updateNetworkPolicyMutex.Lock()
+	defer fake.updateNetworkPolicyMutex.Unlock()
+	fake.UpdateNetworkPolicyStub = stub
+}
+
+func (fake *FakeClusterDelegate) UpdateNetworkPolicyArgsForCall(i int) (string, *v1b.NetworkPolicy) {
+	fake.updateNetworkPolicyMutex.RLock()
+	defer fake.updateNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.updateNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) UpdateNetworkPolicyReturns(result1 *v1b.NetworkPolicy, result2 error) {
+	fake.updateNetworkPolicyMutex.Lock()
+	defer fake.updateNetworkPolicyMutex.Unlock()
+	fake.UpdateNetworkPolicyStub = nil
+	fake.updateNetworkPolicyReturns = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateNetworkPolicyReturnsOnCall(i int, result1 *v1b.NetworkPolicy, result2 error) {
+	fake.updateNetworkPolicyMutex.Lock()
+	defer fake.updateNetworkPolicyMutex.Unlock()
+	fake.UpdateNetworkPolicyStub = nil
+	if fake.updateNetworkPolicyReturnsOnCall == nil {
+		fake.updateNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1b.NetworkPolicy
+			result2 error
+		})
+	}
+	fake.updateNetworkPolicyReturnsOnCall[i] = struct {
+		result1 *v1b.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateResourceQuota(arg1 string, arg2 *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	fake.updateResourceQuotaMutex.Lock()
+	ret, specificReturn := fake.updateResourceQuotaReturnsOnCall[len(fake.updateResourceQuotaArgsForCall)]
+	fake.updateResourceQuotaArgsForCall = append(fake.updateResourceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 *v1.ResourceQuota
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateResourceQuota", []interface{}{arg1, arg2})
+	fake.updateResourceQuotaMutex.Unlock()
+	if fake.UpdateResourceQuotaStub != nil {
+		return fake.UpdateResourceQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateResourceQuotaReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterDelegate) UpdateResourceQuotaCallCount() int {
+	fake.updateResourceQuotaMutex.RLock()
+	defer fake.updateResourceQuotaMutex.RUnlock()
+	return len(fake.updateResourceQuotaArgsForCall)
+}
+
+func (fake *FakeClusterDelegate) UpdateResourceQuotaCalls(stub func(string, *v1.ResourceQuota) (*v1.ResourceQuota, error)) {
+	fake.updateResourceQuotaMutex.Lock()
+	defer fake.updateResourceQuotaMutex.Unlock()
+	fake.UpdateResourceQuotaStub = stub
+}
+
+func (fake *FakeClusterDelegate) UpdateResourceQuotaArgsForCall(i int) (string, *v1.ResourceQuota) {
+	fake.updateResourceQuotaMutex.RLock()
+	defer fake.updateResourceQuotaMutex.RUnlock()
+	argsForCall := fake.updateResourceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterDelegate) UpdateResourceQuotaReturns(result1 *v1.ResourceQuota, result2 error) {
+	fake.updateResourceQuotaMutex.Lock()
+	defer fake.updateResourceQuotaMutex.Unlock()
+	fake.UpdateResourceQuotaStub = nil
+	fake.updateResourceQuotaReturns = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterDelegate) UpdateResourceQuotaReturnsOnCall(i int, result1 *v1.ResourceQuota, result2 error) {
+	fake.updateResourceQuotaMutex.Lock()
+	defer fake.updateResourceQuotaMutex.Unlock()
+	fake.UpdateResourceQuotaStub = nil
+	if fake.updateResourceQuotaReturnsOnCall == nil {
+		fake.updateResourceQuotaReturnsOnCall = make(map[int]struct {
+			result1 *v1.ResourceQuota
+			result2 error
+		})
+	}
+	fake.updateResourceQuotaReturnsOnCall[i] = struct {
+		result1 *v1.ResourceQuota
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClusterDelegate) UpdateSecret(arg1 string, arg2 *v1.Secret) (*v1.Secret, error) {
 	fake.updateSecretMutex.Lock()
 	ret, specificReturn := fake.updateSecretReturnsOnCall[len(fake.updateSecretArgsForCall)]
@@ -1699,28 +3407,62 @@ func (fake *FakeClusterDelegate) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.createClusterRoleBindingMutex.RLock()
 	defer fake.createClusterRoleBindingMutex.RUnlock()
+	fake.createConfigMapMutex.RLock()
+	defer fake.createConfigMapMutex.RUnlock()
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	fake.createLimitRangeMutex.RLock()
+	defer fake.createLimitRangeMutex.RUnlock()
 	fake.createNamespaceMutex.RLock()
 	defer fake.createNamespaceMutex.RUnlock()
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	fake.createResourceQuotaMutex.RLock()
+	defer fake.createResourceQuotaMutex.RUnlock()
 	fake.createSecretMutex.RLock()
 	defer fake.createSecretMutex.RUnlock()
 	fake.createServiceAccountMutex.RLock()
 	defer fake.createServiceAccountMutex.RUnlock()
+	fake.deleteConfigMapMutex.RLock()
+	defer fake.deleteConfigMapMutex.RUnlock()
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	fake.deleteLimitRangeMutex.RLock()
+	defer fake.deleteLimitRangeMutex.RUnlock()
 	fake.deleteNamespaceMutex.RLock()
 	defer fake.deleteNamespaceMutex.RUnlock()
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	fake.deleteResourceQuotaMutex.RLock()
+	defer fake.deleteResourceQuotaMutex.RUnlock()
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
 	fake.getClientMutex.RLock()
 	defer fake.getClientMutex.RUnlock()
 	fake.getClientConfigMutex.RLock()
 	defer fake.getClientConfigMutex.RUnlock()
+	fake.getConfigMapMutex.RLock()
+	defer fake.getConfigMapMutex.RUnlock()
 	fake.getDeploymentMutex.RLock()
 	defer fake.getDeploymentMutex.RUnlock()
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	fake.getLimitRangeMutex.RLock()
+	defer fake.getLimitRangeMutex.RUnlock()
 	fake.getNamespaceMutex.RLock()
 	defer fake.getNamespaceMutex.RUnlock()
 	fake.getNamespacesMutex.RLock()
 	defer fake.getNamespacesMutex.RUnlock()
+	fake.getNetworkPolicyMutex.RLock()
+	defer fake.getNetworkPolicyMutex.RUnlock()
+	fake.getResourceQuotaMutex.RLock()
+	defer fake.getResourceQuotaMutex.RUnlock()
 	fake.getSecretMutex.RLock()
 	defer fake.getSecretMutex.RUnlock()
 	fake.listClusterRoleBindingsMutex.RLock()
 	defer fake.listClusterRoleBindingsMutex.RUnlock()
+	fake.listConfigMapsMutex.RLock()
+	defer fake.listConfigMapsMutex.RUnlock()
 	fake.listDeploymentsMutex.RLock()
 	defer fake.listDeploymentsMutex.RUnlock()
 	fake.listIngressesMutex.RLock()
@@ -1739,6 +3481,16 @@ func (fake *FakeClusterDelegate) Invocations() map[string][][]interface{} {
 	defer fake.listServicesMutex.RUnlock()
 	fake.patchMutex.RLock()
 	defer fake.patchMutex.RUnlock()
+	fake.updateConfigMapMutex.RLock()
+	defer fake.updateConfigMapMutex.RUnlock()
+	fake.updateLimitRangeMutex.RLock()
+	defer fake.updateLimitRangeMutex.RUnlock()
+	fake.updateNamespaceMutex.RLock()
+	defer fake.updateNamespaceMutex.RUnlock()
+	fake.updateNetworkPolicyMutex.RLock()
+	defer fake.updateNetworkPolicyMutex.RUnlock()
+	fake.updateResourceQuotaMutex.RLock()
+	defer fake.updateResourceQuotaMutex.RUnlock()
 	fake.updateSecretMutex.RLock()
 	defer fake.updateSecretMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -1,5 +1,6 @@
 import datetime
 import time
+import urllib.parse
 
 import requests
 
@@ -14,7 +15,8 @@ class TestDeprovision(TestBrokerBase):
 
         delete_response = self.call_broker(path, {}, requests.delete)
         self.assertIn("operation", delete_response)
-        self.assertEqual(delete_response["operation"], "deprovision")
+        self.assertTrue(delete_response["operation"].startswith("deprovision:"))
+        operation = urllib.parse.quote(delete_response["operation"])
 
         start_time = datetime.datetime.now()
         diff = datetime.timedelta(seconds=0)
@@ -22,8 +24,8 @@ class TestDeprovision(TestBrokerBase):
         while state == "in progress" and diff < datetime.timedelta(minutes=2):
             print("deprovisioning in progress, state: {}...".format(state))
             time.sleep(5)
-            path = "/v2/service_instances/{}/last_operation?operation=deprovision&service_id={}&plan_id={}".format(
-                self.instance_id, self.service_id, self.plan_id)
+            path = "/v2/service_instances/{}/last_operation?operation={}&service_id={}&plan_id={}".format(
+                self.instance_id, operation, self.service_id, self.plan_id)
             delete_status = self.call_broker(path, {}, requests.get)
 
             state = delete_status["state"]
@@ -1,5 +1,6 @@
 import datetime
 import time
+import urllib.parse
 
 import requests.auth
 
@@ -16,7 +17,8 @@ class TestProvision(TestBrokerBase):
 
         create_response = self.call_broker(path, body, requests.put)
         self.assertIn("operation", create_response)
-        self.assertEqual(create_response["operation"], "provision")
+        self.assertTrue(create_response["operation"].startswith("provision:"))
+        operation = urllib.parse.quote(create_response["operation"])
 
         start_time = datetime.datetime.now()
         diff = datetime.timedelta(seconds=0)
@@ -24,8 +26,8 @@ class TestProvision(TestBrokerBase):
         while state == "in progress" and diff < datetime.timedelta(minutes=2):
             print("provisioning in progress, state: {}...".format(state))
             time.sleep(5)
-            path = "/v2/service_instances/{}/last_operation?operation=provision&service_id={}&plan_id={}".format(
-                self.instance_id, self.service_id, self.plan_id)
+            path = "/v2/service_instances/{}/last_operation?operation={}&service_id={}&plan_id={}".format(
+                self.instance_id, operation, self.service_id, self.plan_id)
             create_status = self.call_broker(path, {}, requests.get)
 
             state = create_status["state"]